@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	prefixBlocks       = "blocks/"
+	prefixBlockResults = "block_results/"
+	blobExt            = ".json.gz"
+)
+
+// Archive keeps the raw blocks and block results by height, encoded as the node RPC JSON and gzip
+// compressed, so the chain can be re-indexed without a node.
+type Archive struct {
+	store BlobStore
+}
+
+// NewArchive returns an archive stored in the blob store.
+func NewArchive(store BlobStore) *Archive {
+	return &Archive{store: store}
+}
+
+// StoreBlock stores the block and its results.
+func (a *Archive) StoreBlock(ctx context.Context, blk *tmtypes.Block, results *coretypes.ResultBlockResults) error {
+	if err := a.put(ctx, blockKey(prefixBlockResults, blk.Height), results); err != nil {
+		return err
+	}
+	// the block is stored last, an archived block always has its results.
+	return a.put(ctx, blockKey(prefixBlocks, blk.Height), blk)
+}
+
+// Block returns the archived block, ErrNotFound if it was not archived.
+func (a *Archive) Block(ctx context.Context, height int64) (*tmtypes.Block, error) {
+	var blk tmtypes.Block
+	if err := a.get(ctx, blockKey(prefixBlocks, height), &blk); err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+// BlockResults returns the archived block results, ErrNotFound if they were not archived.
+func (a *Archive) BlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	var results coretypes.ResultBlockResults
+	if err := a.get(ctx, blockKey(prefixBlockResults, height), &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// Heights returns the heights of the archived blocks sorted.
+func (a *Archive) Heights(ctx context.Context) ([]int64, error) {
+	keys, err := a.store.List(ctx, prefixBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]int64, 0, len(keys))
+	for _, key := range keys {
+		height, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(key, prefixBlocks), blobExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
+// Close closes the blob store.
+func (a *Archive) Close() error {
+	return a.store.Close()
+}
+
+func (a *Archive) put(ctx context.Context, key string, v any) error {
+	bz, err := cmtjson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(bz); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return a.store.Put(ctx, key, buf.Bytes())
+}
+
+func (a *Archive) get(ctx context.Context, key string, v any) error {
+	data, err := a.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error decompressing %s: %w", key, err)
+	}
+	bz, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error decompressing %s: %w", key, err)
+	}
+	return cmtjson.Unmarshal(bz, v)
+}
+
+// blockKey pads the height, so the keys are listed in height order.
+func blockKey(prefix string, height int64) string {
+	return fmt.Sprintf("%s%012d%s", prefix, height, blobExt)
+}
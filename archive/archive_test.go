@@ -0,0 +1,131 @@
+package archive_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/replay"
+)
+
+// recording is the archive of the blocks 7942001 to 7942004 recorded for the indexer tests.
+const recording = "../idx/testdata/umee-liquidations"
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	recorded := recordedArchive(t)
+	blk, err := recorded.Block(ctx, 7942001)
+	require.NoError(t, err)
+	results, err := recorded.BlockResults(ctx, 7942001)
+	require.NoError(t, err)
+
+	a := newArchive(t)
+	require.NoError(t, a.StoreBlock(ctx, blk, results))
+
+	stored, err := a.Block(ctx, 7942001)
+	require.NoError(t, err)
+	require.Equal(t, blk.Hash(), stored.Hash())
+	require.Equal(t, blk.Data.Txs, stored.Data.Txs)
+	storedResults, err := a.BlockResults(ctx, 7942001)
+	require.NoError(t, err)
+	require.Equal(t, results, storedResults)
+
+	heights, err := a.Heights(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{7942001}, heights)
+
+	// the heights that were not archived.
+	_, err = a.Block(ctx, 7942002)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+	_, err = a.BlockResults(ctx, 7942002)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+}
+
+func TestSink(t *testing.T) {
+	ctx := context.Background()
+	store, err := archive.NewLocalStore(recording)
+	require.NoError(t, err)
+	node, err := replay.NewBlockchain(store, 0)
+	require.NoError(t, err)
+
+	a := newArchive(t)
+	sink := archive.NewSink(node, a, zerolog.Nop())
+	blk, _, err := sink.Block(ctx, 7942002)
+	require.NoError(t, err)
+
+	// the block returned by the node is archived with its results.
+	heights, err := a.Heights(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{7942002}, heights)
+	archived, err := a.Block(ctx, 7942002)
+	require.NoError(t, err)
+	require.Equal(t, blk.Hash(), archived.Hash())
+	_, err = a.BlockResults(ctx, 7942002)
+	require.NoError(t, err)
+}
+
+func TestBlockchain(t *testing.T) {
+	ctx := context.Background()
+	recorded := recordedArchive(t)
+	a := newArchive(t)
+	for height := int64(7942002); height <= 7942003; height++ {
+		blk, err := recorded.Block(ctx, height)
+		require.NoError(t, err)
+		results, err := recorded.BlockResults(ctx, height)
+		require.NoError(t, err)
+		require.NoError(t, a.StoreBlock(ctx, blk, results))
+	}
+
+	b, err := archive.NewBlockchain(a, nil, 0)
+	require.NoError(t, err)
+
+	chainID, height, err := b.ChainHeader()
+	require.NoError(t, err)
+	require.Equal(t, "umee-1", chainID)
+	require.Equal(t, uint64(7942003), height)
+
+	blk, minimumBlkHeight, err := b.Block(ctx, 7942002)
+	require.NoError(t, err)
+	require.Equal(t, int64(7942002), blk.Height)
+	require.Equal(t, 7942002, minimumBlkHeight)
+	results, err := b.BlockResults(ctx, 7942002)
+	require.NoError(t, err)
+	require.Equal(t, int64(7942002), results.Height)
+
+	// the results of the txs of the blocks served.
+	require.NotEmpty(t, blk.Data.Txs)
+	for idx, tx := range blk.Data.Txs {
+		result, err := b.TxResult(ctx, tx)
+		require.NoError(t, err)
+		require.Equal(t, results.TxsResults[idx], result)
+	}
+
+	// a block before the archive answers the lowest height archived, like a node.
+	blk, minimumBlkHeight, err = b.Block(ctx, 7942001)
+	require.NoError(t, err)
+	require.Nil(t, blk)
+	require.Equal(t, 7942002, minimumBlkHeight)
+	_, err = b.BlockResults(ctx, 7942001)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	_, _, err = b.Block(ctx, 7942004)
+	require.ErrorContains(t, err, "block 7942004 was not archived")
+
+	// without a node, the chain state is not available.
+	_, err = b.OracleParams(ctx, 7942002)
+	require.ErrorIs(t, err, archive.ErrNotArchived)
+}
+
+func recordedArchive(t *testing.T) *archive.Archive {
+	store, err := archive.NewLocalStore(recording)
+	require.NoError(t, err)
+	return archive.NewArchive(store)
+}
+
+func newArchive(t *testing.T) *archive.Archive {
+	store, err := archive.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	return archive.NewArchive(store)
+}
@@ -0,0 +1,310 @@
+package archive
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umee/v6/x/metoken"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"github.com/umee-network/umeed-indexer/chain"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	// txResultsBlocks is the amount of blocks that keep their tx results in memory, the indexer
+	// asks for the tx results after it asked for their block.
+	txResultsBlocks = 256
+)
+
+// ErrNotArchived is returned for the chain state queries when there is no node to answer them.
+var ErrNotArchived = errors.New("not available in the archive")
+
+// Blockchain serves the blocks, block results and tx results from the archive, the txs are decoded
+// locally. The chain state queries are answered by the node, if there is one.
+type Blockchain struct {
+	archive  *Archive
+	decoders *chain.DecoderRegistry
+	// node is optional, without it the state queries return ErrNotArchived.
+	node idx.Blockchain
+	// fromHeight is the first block sent by SubscribeNewBlock.
+	fromHeight int64
+
+	mu      sync.Mutex
+	chainID string
+	// txResults are the results of the txs of the last blocks served, by tx hash.
+	txResults       map[string]*abcitypes.ResponseDeliverTx
+	txResultsHashes map[int64][]string
+	txResultsOrder  []int64
+}
+
+var _ idx.Blockchain = &Blockchain{}
+
+// NewBlockchain returns a blockchain served from the archive, the node is optional. SubscribeNewBlock
+// sends the archived blocks from the height on, at disk speed.
+func NewBlockchain(archive *Archive, node idx.Blockchain, fromHeight int64) (*Blockchain, error) {
+	decoders, err := chain.NewUmeeDecoderRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Blockchain{
+		archive:         archive,
+		decoders:        decoders,
+		node:            node,
+		fromHeight:      fromHeight,
+		txResults:       make(map[string]*abcitypes.ResponseDeliverTx),
+		txResultsHashes: make(map[int64][]string),
+	}, nil
+}
+
+func (b *Blockchain) Close(ctx context.Context) error {
+	err := b.archive.Close()
+	if b.node != nil {
+		err = errors.Join(err, b.node.Close(ctx))
+	}
+	return err
+}
+
+func (b *Blockchain) ChainID() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.chainID
+}
+
+// ChainHeader returns the chain id and height of the last archived block.
+func (b *Blockchain) ChainHeader() (chainID string, height uint64, err error) {
+	ctx := context.Background()
+	heights, err := b.archive.Heights(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(heights) == 0 {
+		return "", 0, fmt.Errorf("the archive has no blocks")
+	}
+
+	last, err := b.archive.Block(ctx, heights[len(heights)-1])
+	if err != nil {
+		return "", 0, err
+	}
+	b.SetChainHeader(last)
+	return last.ChainID, uint64(last.Height), nil
+}
+
+func (b *Blockchain) SetChainHeader(blk *tmtypes.Block) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.chainID = blk.ChainID
+}
+
+func (b *Blockchain) DecodeTx(height int64, tx tmtypes.Tx) (sdktypes.Tx, error) {
+	return b.decoders.DecodeTx(height, tx)
+}
+
+func (b *Blockchain) DecodeICAPacketMsgs(data []byte) ([]sdktypes.Msg, error) {
+	return b.decoders.DecodeICAPacketMsgs(data)
+}
+
+func (b *Blockchain) MsgJSON(msg proto.Message) (string, error) {
+	return b.decoders.MsgJSON(msg)
+}
+
+// SubscribeNewBlock sends the archived blocks in height order, from the height informed on creation.
+// After the last archived block, nothing else is sent until the context is done.
+func (b *Blockchain) SubscribeNewBlock(ctx context.Context) (cNewBlock <-chan *tmtypes.Block, err error) {
+	heights, err := b.archive.Heights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newBlock := make(chan *tmtypes.Block, 1)
+	go func() {
+		for _, height := range heights {
+			if height < b.fromHeight {
+				continue
+			}
+			blk, _, err := b.Block(ctx, height)
+			if err != nil || blk == nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case newBlock <- blk:
+			}
+		}
+	}()
+	return newBlock, nil
+}
+
+// Block returns the archived block. Like the nodes without the block, a block that was not archived
+// returns the lowest archived height.
+func (b *Blockchain) Block(ctx context.Context, height int64) (blk *tmtypes.Block, minimumBlkHeight int, err error) {
+	blk, err = b.archive.Block(ctx, height)
+	if errors.Is(err, ErrNotFound) {
+		heights, err := b.archive.Heights(ctx)
+		if err != nil || len(heights) == 0 || heights[0] < height {
+			return nil, 0, errors.Join(fmt.Errorf("block %d was not archived", height), err)
+		}
+		return nil, int(heights[0]), nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := b.archive.BlockResults(ctx, height)
+	if err != nil {
+		return nil, 0, err
+	}
+	b.keepTxResults(blk, results)
+	return blk, int(blk.Height), nil
+}
+
+func (b *Blockchain) BlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	return b.archive.BlockResults(ctx, height)
+}
+
+func (b *Blockchain) TxResult(_ context.Context, tx tmtypes.Tx) (result *abcitypes.ResponseDeliverTx, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result, ok := b.txResults[hex.EncodeToString(tx.Hash())]
+	if !ok {
+		return nil, fmt.Errorf("result of tx %X not found, its block was not served by the archive", tx.Hash())
+	}
+	return result, nil
+}
+
+func (b *Blockchain) CheckTx(ctx context.Context, tx tmtypes.Tx) (err error) {
+	result, err := b.TxResult(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if result.IsErr() {
+		return fmt.Errorf("%w: error checking tx %X - code %d %s", types.ErrTxFailed, tx.Hash(), result.Code, result.Log)
+	}
+	return nil
+}
+
+// keepTxResults indexes the tx results of the block by hash, forgetting the ones of the oldest blocks.
+func (b *Blockchain) keepTxResults(blk *tmtypes.Block, results *coretypes.ResultBlockResults) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.txResultsHashes[blk.Height]; ok {
+		return
+	}
+
+	hashes := make([]string, 0, len(blk.Data.Txs))
+	for idx, tx := range blk.Data.Txs {
+		if idx >= len(results.TxsResults) {
+			break
+		}
+		hash := hex.EncodeToString(tx.Hash())
+		b.txResults[hash] = results.TxsResults[idx]
+		hashes = append(hashes, hash)
+	}
+	b.txResultsHashes[blk.Height] = hashes
+	b.txResultsOrder = append(b.txResultsOrder, blk.Height)
+
+	for len(b.txResultsOrder) > txResultsBlocks {
+		oldest := b.txResultsOrder[0]
+		b.txResultsOrder = b.txResultsOrder[1:]
+		for _, hash := range b.txResultsHashes[oldest] {
+			delete(b.txResults, hash)
+		}
+		delete(b.txResultsHashes, oldest)
+	}
+}
+
+/*
+	Chain state queries, answered by the node.
+*/
+
+func (b *Blockchain) OracleParams(ctx context.Context, height int64) (oracletypes.Params, error) {
+	if b.node == nil {
+		return oracletypes.Params{}, ErrNotArchived
+	}
+	return b.node.OracleParams(ctx, height)
+}
+
+func (b *Blockchain) OracleAggregateVotes(ctx context.Context, height int64) (voters []string, err error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.OracleAggregateVotes(ctx, height)
+}
+
+func (b *Blockchain) BondedValidators(ctx context.Context, height int64) (valopers []string, err error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.BondedValidators(ctx, height)
+}
+
+func (b *Blockchain) GovProposal(ctx context.Context, proposalID uint64, height int64) (*govv1.Proposal, error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.GovProposal(ctx, proposalID, height)
+}
+
+func (b *Blockchain) OngoingIncentivePrograms(ctx context.Context) (programs []incentive.IncentiveProgram, err error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.OngoingIncentivePrograms(ctx)
+}
+
+func (b *Blockchain) MetokenIndexBalances(ctx context.Context) (balances []metoken.IndexBalances, err error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.MetokenIndexBalances(ctx)
+}
+
+func (b *Blockchain) UIBCParams(ctx context.Context, height int64) (uibc.Params, error) {
+	if b.node == nil {
+		return uibc.Params{}, ErrNotArchived
+	}
+	return b.node.UIBCParams(ctx, height)
+}
+
+func (b *Blockchain) UIBCOutflows(ctx context.Context) (outflows []uibc.DecCoinSymbol, err error) {
+	if b.node == nil {
+		return nil, ErrNotArchived
+	}
+	return b.node.UIBCOutflows(ctx)
+}
+
+func (b *Blockchain) UIBCQuotaExpires(ctx context.Context, height int64) (time.Time, error) {
+	if b.node == nil {
+		return time.Time{}, ErrNotArchived
+	}
+	return b.node.UIBCQuotaExpires(ctx, height)
+}
+
+// DenomTrace parses the native denoms locally, the ibc/HASH denoms need the node.
+func (b *Blockchain) DenomTrace(ctx context.Context, denom string) (transfertypes.DenomTrace, error) {
+	if b.node == nil {
+		if !strings.HasPrefix(denom, transfertypes.DenomPrefix+"/") {
+			return transfertypes.ParseDenomTrace(denom), nil
+		}
+		return transfertypes.DenomTrace{}, ErrNotArchived
+	}
+	return b.node.DenomTrace(ctx, denom)
+}
@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"context"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+// Sink is a blockchain that archives every block it returns, with its results queried from the node.
+// Archiving is optional for the indexer, failures are logged and the blocks are still returned.
+type Sink struct {
+	idx.Blockchain
+	archive *Archive
+	logger  zerolog.Logger
+}
+
+var _ idx.Blockchain = &Sink{}
+
+// NewSink returns the blockchain b archiving its blocks.
+func NewSink(b idx.Blockchain, archive *Archive, logger zerolog.Logger) *Sink {
+	return &Sink{
+		Blockchain: b,
+		archive:    archive,
+		logger:     logger.With().Str("package", "archive").Logger(),
+	}
+}
+
+// Block returns the block of the node and archives it.
+func (s *Sink) Block(ctx context.Context, height int64) (blk *tmtypes.Block, minimumBlkHeight int, err error) {
+	blk, minimumBlkHeight, err = s.Blockchain.Block(ctx, height)
+	if err == nil && blk != nil {
+		s.store(ctx, blk)
+	}
+	return blk, minimumBlkHeight, err
+}
+
+// SubscribeNewBlock archives every new block before sending it.
+func (s *Sink) SubscribeNewBlock(ctx context.Context) (cNewBlock <-chan *tmtypes.Block, err error) {
+	newBlock, err := s.Blockchain.SubscribeNewBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make(chan *tmtypes.Block, 1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case blk := <-newBlock:
+				s.store(ctx, blk)
+				select {
+				case archived <- blk:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return archived, nil
+}
+
+// Close closes the blockchain and the archive.
+func (s *Sink) Close(ctx context.Context) error {
+	if err := s.archive.Close(); err != nil {
+		s.logger.Err(err).Msg("error closing archive")
+	}
+	return s.Blockchain.Close(ctx)
+}
+
+func (s *Sink) store(ctx context.Context, blk *tmtypes.Block) {
+	results, err := s.Blockchain.BlockResults(ctx, blk.Height)
+	if err != nil {
+		s.logger.Err(err).Int64("height", blk.Height).Msg("error querying block results to archive")
+		return
+	}
+	if err := s.archive.StoreBlock(ctx, blk, results); err != nil {
+		s.logger.Err(err).Int64("height", blk.Height).Msg("error archiving block")
+	}
+}
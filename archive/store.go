@@ -0,0 +1,172 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// gcsScheme is the prefix of the archive locations in google cloud storage, ex.: gs://bucket/prefix.
+	gcsScheme = "gs://"
+)
+
+// ErrNotFound is returned by the blob stores when the key was not stored.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore is where the archive keeps the compressed blobs, keys are slash separated paths.
+type BlobStore interface {
+	// Put stores the data, overwriting the key if it exists.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrNotFound if the key was not stored.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// List returns the keys with the prefix sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Close closes the needed connections.
+	Close() error
+}
+
+// NewBlobStore returns the store of the location, a gs://bucket/prefix location is stored in
+// google cloud storage and anything else is a local directory.
+func NewBlobStore(ctx context.Context, location string) (BlobStore, error) {
+	if strings.HasPrefix(location, gcsScheme) {
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, gcsScheme), "/")
+		return NewGCSStore(ctx, bucket, prefix)
+	}
+	return NewLocalStore(location)
+}
+
+// LocalStore stores the blobs as files inside of a directory.
+type LocalStore struct {
+	dir string
+}
+
+var _ BlobStore = LocalStore{}
+
+// NewLocalStore returns a store in the directory, creating it if needed.
+func NewLocalStore(dir string) (LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return LocalStore{}, err
+	}
+	return LocalStore{dir: dir}, nil
+}
+
+// Put writes the file atomically, renaming it after it is fully written.
+func (s LocalStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s LocalStore) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return data, err
+}
+
+func (s LocalStore) List(_ context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+func (LocalStore) Close() error {
+	return nil
+}
+
+// GCSStore stores the blobs as objects of a google cloud storage bucket.
+type GCSStore struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+var _ BlobStore = &GCSStore{}
+
+// NewGCSStore returns a store in the bucket, the keys are stored under the prefix.
+func NewGCSStore(ctx context.Context, bucket, prefix string) (*GCSStore, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &GCSStore{client: client, bucket: client.Bucket(bucket), prefix: prefix}, nil
+}
+
+func (s *GCSStore) Put(ctx context.Context, key string, data []byte) error {
+	w := s.bucket.Object(s.prefix + key).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s *GCSStore) Get(ctx context.Context, key string) ([]byte, error) {
+	r, err := s.bucket.Object(s.prefix + key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	iter := s.bucket.Objects(ctx, &storage.Query{Prefix: s.prefix + prefix})
+	for {
+		attrs, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, strings.TrimPrefix(attrs.Name, s.prefix))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *GCSStore) Close() error {
+	return s.client.Close()
+}
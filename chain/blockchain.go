@@ -9,16 +9,20 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
-	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
 
 	umeeapp "github.com/umee-network/umee/v6/app"
-	umeeparams "github.com/umee-network/umee/v6/app/params"
+	idxtypes "github.com/umee-network/umeed-indexer/graph/types"
 )
 
 const (
@@ -28,13 +32,17 @@ const (
 
 // Blockchain defines the structure to get information about the chain.
 type Blockchain struct {
+	// mu guards the fields of the struct, the requests to the node are not serialized.
 	mu        sync.Mutex
 	conn      *Conn
 	rpcRespID uint32
 
 	chainID string
+	// denomTraces keeps the traces already resolved, they never change for the same ibc/HASH.
+	denomTraces map[string]transfertypes.DenomTrace
 
-	umeeEncodingConfig testutil.TestEncodingConfig
+	// decoders decode the txs by block height with the codec of their upgrade era.
+	decoders *DecoderRegistry
 }
 
 // NewBlockchain returns a new blockchain structure with a RPC connection
@@ -42,7 +50,11 @@ type Blockchain struct {
 // rpcEndpoint ex.: tcp://0.0.0.0:26657, https://umee-rpc.polkachu.com:443
 // grpcEndpoint ex.: 127.0.0.1:9090.
 func NewBlockchain(rpc, grpc string) (*Blockchain, error) {
-	conn, err := NewConn(rpc, grpc)
+	decoders, err := NewUmeeDecoderRegistry()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := NewConn(rpc, grpc, decoders.Latest().EncodingConfig.InterfaceRegistry)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +63,12 @@ func NewBlockchain(rpc, grpc string) (*Blockchain, error) {
 		return nil, err
 	}
 
-	encodingConfig := umeeparams.MakeEncodingConfig(umeeModBasics()...)
-
 	return &Blockchain{
-		conn:               conn,
-		rpcRespID:          0,
-		chainID:            "",
-		umeeEncodingConfig: encodingConfig,
+		conn:        conn,
+		rpcRespID:   0,
+		chainID:     "",
+		denomTraces: make(map[string]transfertypes.DenomTrace),
+		decoders:    decoders,
 	}, nil
 }
 
@@ -82,14 +93,19 @@ func (b *Blockchain) SubscribeNewBlock(ctx context.Context) (cNewBlock <-chan *t
 	go func() {
 		for {
 			select {
-			// only closes the connections if the context is done.
+			// stops sending new blocks once the context is done.
 			case <-ctx.Done():
+				return
 			case blk := <-chanResultEvtNewBlock: // listen to new blocks being produced.
 				evtNewBlock, ok := blk.Data.(tmtypes.EventDataNewBlock)
 				if !ok {
 					continue
 				}
-				channelNewBlock <- evtNewBlock.Block
+				select {
+				case channelNewBlock <- evtNewBlock.Block:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
@@ -122,9 +138,16 @@ func (b *Blockchain) SetChainHeader(blk *tmtypes.Block) {
 	b.chainID = blk.ChainID
 }
 
-// DecodeTx decodes a tx into msgs.
-func (b *Blockchain) DecodeTx(tx tmtypes.Tx) (sdktypes.Tx, error) {
-	return b.umeeEncodingConfig.TxConfig.TxDecoder()(tx)
+// DecodeTx decodes a tx into msgs with the codec of the block height, the msgs of unknown types
+// are returned as raw Any msgs.
+func (b *Blockchain) DecodeTx(height int64, tx tmtypes.Tx) (sdktypes.Tx, error) {
+	return b.decoders.DecodeTx(height, tx)
+}
+
+// DecodeICAPacketMsgs decodes the msgs an interchain account executes on umee from the packet data
+// sent by the controller chain, packets that do not execute txs return no msgs.
+func (b *Blockchain) DecodeICAPacketMsgs(data []byte) ([]sdktypes.Msg, error) {
+	return b.decoders.DecodeICAPacketMsgs(data)
 }
 
 // ChainHeader queries the chain by the last block height.
@@ -133,7 +156,10 @@ func (b *Blockchain) ChainHeader() (string, uint64, error) {
 	req := types.NewRPCRequest(idSent, "block", nil)
 
 	var respRPC RPCRespChainID
-	if err := b.makeRPCRequest(req, &respRPC); err != nil {
+	start := time.Now()
+	err := b.makeRPCRequest(req, &respRPC)
+	observeRequest("block", start, err)
+	if err != nil {
 		return "", 0, err
 	}
 
@@ -167,10 +193,9 @@ func (b *Blockchain) makeRPCRequest(req any, responseStruct any) error {
 }
 
 // Block returns the block for that given height
+// The requests are not serialized, the cometBFT client sets their ids safely, so the backfill workers fetch in parallel.
 func (b *Blockchain) Block(ctx context.Context, height int64) (blk *tmtypes.Block, minimumBlkHeight int, err error) {
-	// it pannics inside cometBFT if the mutex is not used.
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	defer func(start time.Time) { observeRequest("block", start, err) }(time.Now())
 	blkResult, err := b.conn.websocketRPC.Block(ctx, &height)
 	if err != nil {
 		// usually a node does not have all the blocks, in this case we could parse the last block that node has available and start from there.
@@ -194,18 +219,42 @@ func (b *Blockchain) Block(ctx context.Context, height int64) (blk *tmtypes.Bloc
 
 // CheckTx returns nil if the tx was processed correctly without any errors.
 func (b *Blockchain) CheckTx(ctx context.Context, tx tmtypes.Tx) (err error) {
-	// it pannics inside cometBFT if the mutex is not used.
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
+	start := time.Now()
 	txResult, err := b.conn.websocketRPC.Tx(ctx, tx.Hash(), true)
+	observeRequest("tx", start, err)
 	if err != nil {
 		return err
 	}
 
 	if txResult.TxResult.IsErr() {
-		return fmt.Errorf("error checking tx %s - %+v", tx.String(), txResult)
+		return fmt.Errorf("%w: error checking tx %s - %+v", idxtypes.ErrTxFailed, tx.String(), txResult)
 	}
 
 	return nil
 }
+
+// BlockResults returns the results of the block execution, with the events emitted at the begin and end of the block.
+func (b *Blockchain) BlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	start := time.Now()
+	results, err := b.conn.websocketRPC.BlockResults(ctx, &height)
+	observeRequest("block_results", start, err)
+	return results, err
+}
+
+// MsgJSON encodes the msg as JSON with the umee codec, which also knows how to encode the msgs packed as Any.
+func (b *Blockchain) MsgJSON(msg proto.Message) (string, error) {
+	return b.decoders.MsgJSON(msg)
+}
+
+// TxResult returns the result of the tx execution, with the events emitted by it.
+// It does not error out if the tx execution failed, the result code should be checked.
+func (b *Blockchain) TxResult(ctx context.Context, tx tmtypes.Tx) (result *abcitypes.ResponseDeliverTx, err error) {
+	start := time.Now()
+	txResult, err := b.conn.websocketRPC.Tx(ctx, tx.Hash(), false)
+	observeRequest("tx", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txResult.TxResult, nil
+}
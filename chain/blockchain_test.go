@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestBlockFetchesOverlap(t *testing.T) {
+	const workers = 4
+
+	// the node answers the block requests only after all the workers are waiting for one, or after the
+	// timeout if they are serialized.
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		allWaiting  = make(chan struct{})
+	)
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		if inFlight == workers {
+			close(allWaiting)
+		}
+		mu.Unlock()
+
+		select {
+		case <-allWaiting:
+		case <-time.After(time.Second):
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32603,"message":"Internal error","data":"height 1 is not available, lowest height is 7942001"}}`, req.ID)
+	}))
+	defer node.Close()
+
+	decoders, err := NewUmeeDecoderRegistry()
+	require.NoError(t, err)
+	conn, err := NewConn(node.URL, "127.0.0.1:0", decoders.Latest().EncodingConfig.InterfaceRegistry)
+	require.NoError(t, err)
+	b := &Blockchain{conn: conn, decoders: decoders}
+
+	var g errgroup.Group
+	for w := 0; w < workers; w++ {
+		g.Go(func() error {
+			_, lowest, err := b.Block(context.Background(), 1)
+			if err == nil && lowest != 7942001 {
+				err = fmt.Errorf("lowest height %d is not 7942001", lowest)
+			}
+			return err
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.Equal(t, workers, maxInFlight, "the blocks are fetched in parallel")
+}
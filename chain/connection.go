@@ -12,6 +12,7 @@ import (
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	tmjsonclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 )
 
 // Conn stores the connections needed to index events and transactions.
@@ -30,7 +31,8 @@ type Conn struct {
 // tcp://0.0.0.0:26657
 // examples of grpc endpoints are:
 // 127.0.0.1:9090
-func NewConn(rpc, grpc string) (*Conn, error) {
+// the interface registry is used by the gRPC codec to unpack the Any fields of the responses.
+func NewConn(rpc, grpc string, registry codectypes.InterfaceRegistry) (*Conn, error) {
 	httpClient, err := tmjsonclient.DefaultHTTPClient(rpc)
 	if err != nil {
 		return nil, err
@@ -46,9 +48,10 @@ func NewConn(rpc, grpc string) (*Conn, error) {
 	grpcConn, err := ggrpc.Dial(
 		grpc, // your gRPC server address.
 		ggrpc.WithTransportCredentials(insecure.NewCredentials()), // The Cosmos SDK doesn't support any transport security mechanism.
-		// This instantiates a general gRPC codec which handles proto bytes. We pass in a nil interface registry
-		// if the request/response types contain interface instead of 'nil' you should pass the application specific codec.
-		ggrpc.WithDefaultCallOptions(ggrpc.ForceCodec(codec.NewProtoCodec(nil).GRPCCodec())),
+		// This instantiates a general gRPC codec which handles proto bytes. The application specific
+		// interface registry is passed, because some request/response types contain interfaces (ex.: validator pubkeys).
+		ggrpc.WithDefaultCallOptions(ggrpc.ForceCodec(codec.NewProtoCodec(registry).GRPCCodec())),
+		ggrpc.WithUnaryInterceptor(metricsInterceptor),
 	)
 	if err != nil {
 		return nil, err
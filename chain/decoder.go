@@ -0,0 +1,163 @@
+package chain
+
+import (
+	"fmt"
+	"sort"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+
+	umeeparams "github.com/umee-network/umee/v6/app/params"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// CodecEra is the codec used to decode the txs of the blocks from the upgrade height on,
+// until the height of the next era.
+type CodecEra struct {
+	Name           string
+	UpgradeHeight  int64
+	EncodingConfig testutil.TestEncodingConfig
+}
+
+// DecoderRegistry decodes the txs with the codec of the upgrade era of their block height.
+type DecoderRegistry struct {
+	// eras are sorted by upgrade height.
+	eras []CodecEra
+}
+
+// umeeCodecEras returns the codec eras known by the indexer. Only the umee v6 module basics are
+// available, it decodes the txs since genesis and the msg types it no longer registers fall back
+// to raw Any msgs. The codec of an earlier upgrade is added here with its height when needed.
+func umeeCodecEras() []CodecEra {
+	return []CodecEra{
+		{
+			Name:           "v6",
+			UpgradeHeight:  0,
+			EncodingConfig: umeeparams.MakeEncodingConfig(umeeModBasics()...),
+		},
+	}
+}
+
+// NewUmeeDecoderRegistry returns the registry of the umee codec eras known by the indexer.
+func NewUmeeDecoderRegistry() (*DecoderRegistry, error) {
+	return NewDecoderRegistry(umeeCodecEras()...)
+}
+
+// NewDecoderRegistry returns a registry with the eras, it errors out if there are no eras or if two
+// of them start at the same height.
+func NewDecoderRegistry(eras ...CodecEra) (*DecoderRegistry, error) {
+	if len(eras) == 0 {
+		return nil, fmt.Errorf("decoder registry needs at least one codec era")
+	}
+
+	sorted := make([]CodecEra, len(eras))
+	copy(sorted, eras)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UpgradeHeight < sorted[j].UpgradeHeight
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].UpgradeHeight == sorted[i-1].UpgradeHeight {
+			return nil, fmt.Errorf("codec eras %s and %s start at the same height %d", sorted[i-1].Name, sorted[i].Name, sorted[i].UpgradeHeight)
+		}
+	}
+	return &DecoderRegistry{eras: sorted}, nil
+}
+
+// Era returns the codec era of the block height, the first era is used for the heights before it.
+func (r *DecoderRegistry) Era(height int64) CodecEra {
+	era := r.eras[0]
+	for _, e := range r.eras[1:] {
+		if e.UpgradeHeight > height {
+			break
+		}
+		era = e
+	}
+	return era
+}
+
+// Latest returns the codec era of the current chain version.
+func (r *DecoderRegistry) Latest() CodecEra {
+	return r.eras[len(r.eras)-1]
+}
+
+// DecodeTx decodes the tx with the codec of the block height. If the codec fails to decode it, the
+// tx body is decoded without verification, the msgs it knows are unpacked and the unknown ones are
+// returned as raw Any msgs.
+func (r *DecoderRegistry) DecodeTx(height int64, tx tmtypes.Tx) (sdktypes.Tx, error) {
+	era := r.Era(height)
+	decoded, err := era.EncodingConfig.TxConfig.TxDecoder()(tx)
+	if err == nil {
+		return decoded, nil
+	}
+
+	raw, rawErr := decodeRawTx(era, tx)
+	if rawErr != nil {
+		return nil, fmt.Errorf("%w: decoding raw tx body: %s", err, rawErr.Error())
+	}
+	return raw, nil
+}
+
+// DecodeICAPacketMsgs decodes the msgs an interchain account executes on umee from the packet data
+// sent by the controller chain, packets that do not execute txs return no msgs.
+func (r *DecoderRegistry) DecodeICAPacketMsgs(data []byte) ([]sdktypes.Msg, error) {
+	var packetData icatypes.InterchainAccountPacketData
+	if err := icatypes.ModuleCdc.UnmarshalJSON(data, &packetData); err != nil {
+		return nil, err
+	}
+	if packetData.Type != icatypes.EXECUTE_TX {
+		return nil, nil
+	}
+	return icatypes.DeserializeCosmosTx(r.Latest().EncodingConfig.Codec, packetData.Data)
+}
+
+// MsgJSON encodes the msg as JSON with the latest codec, which also knows how to encode the msgs packed as Any.
+func (r *DecoderRegistry) MsgJSON(msg proto.Message) (string, error) {
+	bz, err := r.Latest().EncodingConfig.Codec.MarshalInterfaceJSON(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// rawTx is a tx decoded without the codec verifications, only its msgs are available.
+type rawTx struct {
+	msgs []sdktypes.Msg
+}
+
+var _ sdktypes.Tx = rawTx{}
+
+func (tx rawTx) GetMsgs() []sdktypes.Msg {
+	return tx.msgs
+}
+
+func (rawTx) ValidateBasic() error {
+	return nil
+}
+
+func decodeRawTx(era CodecEra, tx tmtypes.Tx) (sdktypes.Tx, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(tx); err != nil {
+		return nil, err
+	}
+
+	var body txtypes.TxBody
+	if err := body.Unmarshal(raw.BodyBytes); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]sdktypes.Msg, len(body.Messages))
+	for idx, anyMsg := range body.Messages {
+		var msg sdktypes.Msg
+		if err := era.EncodingConfig.InterfaceRegistry.UnpackAny(anyMsg, &msg); err != nil {
+			msgs[idx] = types.NewRawAnyMsg(anyMsg)
+			continue
+		}
+		msgs[idx] = msg
+	}
+	return rawTx{msgs: msgs}, nil
+}
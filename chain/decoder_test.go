@@ -0,0 +1,141 @@
+package chain_test
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	umeeparams "github.com/umee-network/umee/v6/app/params"
+	"github.com/umee-network/umeed-indexer/chain"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestNewDecoderRegistry(t *testing.T) {
+	era := func(name string, height int64) chain.CodecEra {
+		return chain.CodecEra{Name: name, UpgradeHeight: height}
+	}
+
+	_, err := chain.NewDecoderRegistry()
+	require.ErrorContains(t, err, "at least one codec era")
+	_, err = chain.NewDecoderRegistry(era("v5", 100), era("v6", 100))
+	require.ErrorContains(t, err, "v5 and v6 start at the same height 100")
+
+	// the eras are sorted by upgrade height whatever their order.
+	r, err := chain.NewDecoderRegistry(era("v6", 200), era("v4", 0), era("v5", 100))
+	require.NoError(t, err)
+	require.Equal(t, "v6", r.Latest().Name)
+
+	tcs := []struct {
+		title  string
+		height int64
+		era    string
+	}{
+		{title: "first block", height: 1, era: "v4"},
+		{title: "before the upgrade", height: 99, era: "v4"},
+		{title: "upgrade height", height: 100, era: "v5"},
+		{title: "between upgrades", height: 150, era: "v5"},
+		{title: "latest era", height: 9_000_000, era: "v6"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.era, r.Era(tc.height).Name)
+		})
+	}
+
+	// the heights before the first era use it.
+	r, err = chain.NewDecoderRegistry(era("v6", 100))
+	require.NoError(t, err)
+	require.Equal(t, "v6", r.Era(1).Name)
+}
+
+func TestDecodeTx(t *testing.T) {
+	umee, err := chain.NewUmeeDecoderRegistry()
+	require.NoError(t, err)
+	// an era before the bank module was registered, its txs with bank msgs fall back to raw Any msgs.
+	r, err := chain.NewDecoderRegistry(
+		chain.CodecEra{Name: "v0", UpgradeHeight: 0, EncodingConfig: umeeparams.MakeEncodingConfig()},
+		chain.CodecEra{Name: "v6", UpgradeHeight: 100, EncodingConfig: umee.Latest().EncodingConfig},
+	)
+	require.NoError(t, err)
+
+	send := &banktypes.MsgSend{
+		FromAddress: "umee1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5d53pzw",
+		ToAddress:   "umee19q5j52ev95hz7vp3xgengdfkxuurjw3mcyzlf8",
+		Amount:      sdktypes.NewCoins(sdktypes.NewInt64Coin("uumee", 1000)),
+	}
+	sendAny, err := codectypes.NewAnyWithValue(send)
+	require.NoError(t, err)
+	// a msg of a type registered by an older umee version only.
+	goneAny := &codectypes.Any{TypeUrl: "/umee.leverage.v1.MsgGone", Value: []byte{0x0a, 0x01, 0x61}}
+
+	txConfig := umee.Latest().EncodingConfig.TxConfig
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(send))
+	sendTx, err := txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	rawTx := func(msgs ...*codectypes.Any) []byte {
+		body, err := (&txtypes.TxBody{Messages: msgs}).Marshal()
+		require.NoError(t, err)
+		bz, err := (&txtypes.TxRaw{BodyBytes: body}).Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+
+	tcs := []struct {
+		title  string
+		height int64
+		tx     []byte
+		msgs   []string
+		errMsg string
+	}{
+		{
+			title:  "known msgs are decoded by the codec of the era",
+			height: 100,
+			tx:     sendTx,
+			msgs:   []string{types.MsgNameSend},
+		},
+		{
+			title:  "msgs unknown by the codec of the era fall back to raw Any msgs",
+			height: 99,
+			tx:     sendTx,
+			msgs:   []string{types.MsgNameRawAny},
+		},
+		{
+			title:  "unknown msgs fall back to raw Any msgs, keeping the known ones",
+			height: 100,
+			tx:     rawTx(goneAny, sendAny),
+			msgs:   []string{types.MsgNameRawAny, types.MsgNameSend},
+		},
+		{
+			title:  "txs that are not protobuf fail",
+			height: 100,
+			tx:     []byte("not a tx"),
+			errMsg: "decoding raw tx body",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			tx, err := r.DecodeTx(tc.height, tc.tx)
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			msgs := tx.GetMsgs()
+			require.Len(t, msgs, len(tc.msgs))
+			for idx, msg := range msgs {
+				require.Equal(t, tc.msgs[idx], proto.MessageName(msg))
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package chain
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umee/v6/x/metoken"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// grpcPageLimit is the maximum amount of items requested per page in paginated queries.
+	grpcPageLimit = 500
+)
+
+// ctxAtHeight returns a context that makes the gRPC query against the state of the given block height.
+// the node needs to have that height available (not pruned) to answer.
+func ctxAtHeight(ctx context.Context, height int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+}
+
+// OracleParams returns the oracle module params at the end of the given block height, the vote period
+// and slash window can be changed by governance.
+func (b *Blockchain) OracleParams(ctx context.Context, height int64) (oracletypes.Params, error) {
+	resp, err := oracletypes.NewQueryClient(b.conn.grpcConn).Params(ctxAtHeight(ctx, height), &oracletypes.QueryParams{})
+	if err != nil {
+		return oracletypes.Params{}, err
+	}
+	return resp.Params, nil
+}
+
+// OracleAggregateVotes returns the validator addresses which had an aggregate vote stored
+// at the end of the given block height.
+func (b *Blockchain) OracleAggregateVotes(ctx context.Context, height int64) (voters []string, err error) {
+	resp, err := oracletypes.NewQueryClient(b.conn.grpcConn).AggregateVotes(ctxAtHeight(ctx, height), &oracletypes.QueryAggregateVotes{})
+	if err != nil {
+		return nil, err
+	}
+
+	voters = make([]string, len(resp.AggregateVotes))
+	for i, vote := range resp.AggregateVotes {
+		voters[i] = vote.Voter
+	}
+	return voters, nil
+}
+
+// BondedValidators returns the operator address of the bonded validators at the given block height.
+func (b *Blockchain) BondedValidators(ctx context.Context, height int64) (valopers []string, err error) {
+	client := stakingtypes.NewQueryClient(b.conn.grpcConn)
+	req := &stakingtypes.QueryValidatorsRequest{
+		Status:     stakingtypes.Bonded.String(),
+		Pagination: &query.PageRequest{Limit: grpcPageLimit},
+	}
+
+	for {
+		resp, err := client.Validators(ctxAtHeight(ctx, height), req)
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range resp.Validators {
+			valopers = append(valopers, val.OperatorAddress)
+		}
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return valopers, nil
+		}
+		req.Pagination.Key = resp.Pagination.NextKey
+	}
+}
+
+// GovProposal returns the proposal stored at the end of the given block height.
+func (b *Blockchain) GovProposal(ctx context.Context, proposalID uint64, height int64) (*govv1.Proposal, error) {
+	resp, err := govv1.NewQueryClient(b.conn.grpcConn).Proposal(ctxAtHeight(ctx, height), &govv1.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Proposal, nil
+}
+
+// OngoingIncentivePrograms returns the incentive programs that are currently distributing rewards.
+func (b *Blockchain) OngoingIncentivePrograms(ctx context.Context) (programs []incentive.IncentiveProgram, err error) {
+	resp, err := incentive.NewQueryClient(b.conn.grpcConn).OngoingIncentivePrograms(ctx, &incentive.QueryOngoingIncentivePrograms{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Programs, nil
+}
+
+// MetokenIndexBalances returns the balances of all the meToken indexes.
+func (b *Blockchain) MetokenIndexBalances(ctx context.Context) (balances []metoken.IndexBalances, err error) {
+	resp, err := metoken.NewQueryClient(b.conn.grpcConn).IndexBalances(ctx, &metoken.QueryIndexBalances{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.IndexBalances, nil
+}
+
+// UIBCParams returns the uibc module params at the end of the given block height, they are not kept in
+// memory as the quota params are frequently changed by governance.
+func (b *Blockchain) UIBCParams(ctx context.Context, height int64) (uibc.Params, error) {
+	resp, err := uibc.NewQueryClient(b.conn.grpcConn).Params(ctxAtHeight(ctx, height), &uibc.QueryParams{})
+	if err != nil {
+		return uibc.Params{}, err
+	}
+	return resp.Params, nil
+}
+
+// UIBCOutflows returns the IBC outflows of each denom in the current quota period.
+func (b *Blockchain) UIBCOutflows(ctx context.Context) (outflows []uibc.DecCoinSymbol, err error) {
+	resp, err := uibc.NewQueryClient(b.conn.grpcConn).AllOutflows(ctx, &uibc.QueryAllOutflows{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Outflows, nil
+}
+
+// UIBCQuotaExpires returns when the uibc quota period of the given block height ends.
+func (b *Blockchain) UIBCQuotaExpires(ctx context.Context, height int64) (time.Time, error) {
+	resp, err := uibc.NewQueryClient(b.conn.grpcConn).QuotaExpires(ctxAtHeight(ctx, height), &uibc.QueryQuotaExpires{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return resp.EndTime, nil
+}
+
+// DenomTrace resolves the denom trace of an ibc/HASH denom, native denoms are returned without path.
+// The traces are kept in memory after the first request.
+func (b *Blockchain) DenomTrace(ctx context.Context, denom string) (transfertypes.DenomTrace, error) {
+	if !strings.HasPrefix(denom, transfertypes.DenomPrefix+"/") {
+		return transfertypes.ParseDenomTrace(denom), nil
+	}
+
+	b.mu.Lock()
+	trace, ok := b.denomTraces[denom]
+	b.mu.Unlock()
+	if ok {
+		return trace, nil
+	}
+
+	// the lock is not held by the request, a trace queried twice at the same time is the same.
+	resp, err := transfertypes.NewQueryClient(b.conn.grpcConn).DenomTrace(ctx, &transfertypes.QueryDenomTraceRequest{Hash: denom})
+	if err != nil {
+		return transfertypes.DenomTrace{}, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.denomTraces[denom] = *resp.DenomTrace
+	return *resp.DenomTrace, nil
+}
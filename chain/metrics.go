@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "chain",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the RPC and gRPC requests to the node by method.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"method"})
+	requestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "chain",
+		Name:      "request_errors_total",
+		Help:      "Amount of RPC and gRPC requests to the node that failed by method.",
+	}, []string{"method"})
+)
+
+// observeRequest records the latency of the request to the node since the start and if it failed.
+func observeRequest(method string, start time.Time, err error) {
+	requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		requestErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// metricsInterceptor records every gRPC query by its full method name.
+func metricsInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observeRequest(method, start, err)
+	return err
+}
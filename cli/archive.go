@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/chain"
+	"github.com/umee-network/umeed-indexer/config"
+	"github.com/umee-network/umeed-indexer/idx"
+	"github.com/umee-network/umeed-indexer/replay"
+)
+
+const (
+	FlagArchive     = "archive"
+	FlagFromArchive = "from-archive"
+	FlagRecord      = "record"
+	FlagReplay      = "replay"
+)
+
+// addArchiveFlags adds the flags of the raw blocks archive to the command.
+func addArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagArchive, "", fmt.Sprintf("%s=/data/archive or %s=gs://bucket/prefix to store the raw blocks and block results", FlagArchive, FlagArchive))
+	cmd.Flags().Bool(FlagFromArchive, false, fmt.Sprintf("%s=true to read the blocks from the archive instead of the node, which is only used for the chain state queries if %s is set", FlagFromArchive, config.EnvChainRPC))
+	cmd.Flags().String(FlagRecord, "", fmt.Sprintf("%s=/data/recording to record the blocks and the chain state queries answered by the node", FlagRecord))
+	cmd.Flags().String(FlagReplay, "", fmt.Sprintf("%s=/data/recording to index a recording without a node", FlagReplay))
+}
+
+// loadBlockchain returns the replay of a recording, or the blockchain of the node or the archive
+// recorded if there is a recording location. New blocks are sent from the height on.
+func loadBlockchain(ctx context.Context, cmd *cobra.Command, node config.Chain, logger zerolog.Logger, fromHeight int) (idx.Blockchain, error) {
+	recordLocation, err := cmd.Flags().GetString(FlagRecord)
+	if err != nil {
+		return nil, err
+	}
+	replayLocation, err := cmd.Flags().GetString(FlagReplay)
+	if err != nil {
+		return nil, err
+	}
+
+	if replayLocation != "" {
+		if recordLocation != "" {
+			return nil, fmt.Errorf("%s and %s can not be used together", FlagRecord, FlagReplay)
+		}
+		store, err := archive.NewBlobStore(ctx, replayLocation)
+		if err != nil {
+			return nil, err
+		}
+		return replay.NewBlockchain(store, int64(fromHeight))
+	}
+
+	b, err := loadArchiveBlockchain(ctx, cmd, node, logger, fromHeight)
+	if err != nil || recordLocation == "" {
+		return b, err
+	}
+
+	store, err := archive.NewBlobStore(ctx, recordLocation)
+	if err != nil {
+		return nil, err
+	}
+	return replay.NewRecorder(b, store, logger)
+}
+
+// loadArchiveBlockchain returns the node blockchain, archiving its blocks if there is an archive, or the
+// archive blockchain if the blocks are read from it, where new blocks are sent from the height on.
+func loadArchiveBlockchain(ctx context.Context, cmd *cobra.Command, node config.Chain, logger zerolog.Logger, fromHeight int) (idx.Blockchain, error) {
+	location, err := cmd.Flags().GetString(FlagArchive)
+	if err != nil {
+		return nil, err
+	}
+	fromArchive, err := cmd.Flags().GetBool(FlagFromArchive)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == "" {
+		if fromArchive {
+			return nil, fmt.Errorf("%s needs the %s location", FlagFromArchive, FlagArchive)
+		}
+		return chain.NewBlockchain(node.RPC, node.GRPC)
+	}
+
+	store, err := archive.NewBlobStore(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	a := archive.NewArchive(store)
+
+	if !fromArchive {
+		b, err := chain.NewBlockchain(node.RPC, node.GRPC)
+		if err != nil {
+			return nil, err
+		}
+		return archive.NewSink(b, a, logger), nil
+	}
+
+	var nodeChain idx.Blockchain
+	if node.RPC != "" {
+		b, err := chain.NewBlockchain(node.RPC, node.GRPC)
+		if err != nil {
+			return nil, err
+		}
+		nodeChain = b
+	}
+	return archive.NewBlockchain(a, nodeChain, int64(fromHeight))
+}
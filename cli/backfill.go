@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	FlagWorkers    = "workers"
+	defaultWorkers = 8
+)
+
+// CmdBackfill indexes a range of old blocks and exits.
+func CmdBackfill() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Indexes the blocks between the heights that were not indexed yet and exits, without listening to new blocks.",
+		Long: `Indexes the blocks between the heights with a pool of workers fetching the blocks, printing the progress.
+The intervals indexed are merged with the stored ones, so it can run as a batch job apart from the indexer started with start.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetInt(FlagFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetInt(FlagTo)
+			if err != nil {
+				return err
+			}
+			flagMsgs, err := cmd.Flags().GetStringSlice(FlagMsg)
+			if err != nil {
+				return err
+			}
+			msgNames, err := findCosmosMsgNames(flagMsgs...)
+			if err != nil {
+				return err
+			}
+
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, from)
+			if err != nil {
+				return err
+			}
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+
+			i, err := idx.NewIndexer(ctx, b, db, logger, from)
+			if err != nil {
+				return err
+			}
+			defer i.Close(ctx)
+
+			go func() {
+				if err := listenMetrics(ctx, cfg.Metrics, shutdownDeadline(defaultShutdownTimeout), logger); err != nil {
+					logger.Err(err).Msg("error serving the metrics")
+				}
+			}()
+			return i.Backfill(ctx, from, to, cfg.Backfill.Workers, msgNames, newProgressPrinter())
+		},
+	}
+
+	cmd.Flags().Int(FlagFrom, 0, "first block height to backfill")
+	cmd.Flags().Int(FlagTo, 0, "last block height to backfill (inclusive)")
+	cmd.Flags().StringSlice(FlagMsg, nil, "proto names of the msgs to backfill, all of them if not set, ex.: MsgLiquidate,MsgSend")
+	cmd.Flags().Int(FlagWorkers, defaultWorkers, "amount of blocks fetched in parallel")
+	_ = cmd.MarkFlagRequired(FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagTo)
+	addArchiveFlags(cmd)
+	return cmd
+}
+
+// findCosmosMsgNames returns the proto names of the msgs indexed by their full or short names.
+func findCosmosMsgNames(names ...string) ([]string, error) {
+	cosmosMsgs := types.DefaultChainInfo("").CosmosMsgs
+	msgNames := make([]string, 0, len(names))
+	for _, name := range names {
+		msgName, found := types.FindCosmosMsgName(cosmosMsgs, name)
+		if !found {
+			return nil, fmt.Errorf("msg %q is not indexed", name)
+		}
+		msgNames = append(msgNames, msgName)
+	}
+	return msgNames, nil
+}
+
+// newProgressPrinter prints the backfill progress at most once a second and when it is done.
+func newProgressPrinter() func(idx.BackfillProgress) {
+	var lastPrint time.Time
+	return func(p idx.BackfillProgress) {
+		if p.Done < p.Total && time.Since(lastPrint) < time.Second {
+			return
+		}
+		lastPrint = time.Now()
+		fmt.Printf("indexed %d/%d blocks (height %d, %d failed), elapsed %s, ETA %s\n",
+			p.Done, p.Total, p.Height, p.Failed, p.Elapsed.Round(time.Second), p.ETA().Round(time.Second))
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/umee-network/umeed-indexer/config"
+	"github.com/umee-network/umeed-indexer/server"
+)
+
+const (
+	FlagConfig = "config"
+	FlagDB     = "db"
+)
+
+// addConfigFlags adds the flags of the config shared by all the commands.
+func addConfigFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(FlagConfig, "", fmt.Sprintf("%s=indexer.yaml to load the config from a YAML or TOML file, the env and flags override it", FlagConfig))
+	cmd.PersistentFlags().String(FlagDB, "", fmt.Sprintf("database backend used instead of the one of the config and %s: firebase or memory", config.EnvDBBackend))
+}
+
+// loadConfig returns the validated config of the command, loaded from the config file, the env and
+// the flags set, and the logger of its settings.
+func loadConfig(cmd *cobra.Command) (config.Config, zerolog.Logger, error) {
+	path, err := cmd.Flags().GetString(FlagConfig)
+	if err != nil {
+		return config.Config{}, zerolog.Nop(), err
+	}
+	cfg, err := config.Load(path, os.Getenv)
+	if err != nil {
+		return cfg, zerolog.Nop(), err
+	}
+	if err := applyFlags(cmd.Flags(), &cfg); err != nil {
+		return cfg, zerolog.Nop(), err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, zerolog.Nop(), fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	logger, err := server.NewLogger(cfg.Log.FilePath, cfg.Log.Level)
+	if err != nil {
+		return cfg, zerolog.Nop(), fmt.Errorf("error loading logger: %w", err)
+	}
+	return cfg, logger, nil
+}
+
+// applyFlags overrides the config by the flags set in the command line, the defaults of the flags
+// do not override it.
+func applyFlags(flags *pflag.FlagSet, cfg *config.Config) (err error) {
+	changed := func(name string) bool {
+		f := flags.Lookup(name)
+		return f != nil && f.Changed
+	}
+
+	if changed(FlagDB) {
+		db, _ := flags.GetString(FlagDB)
+		if err := cfg.DB.Backend.UnmarshalText([]byte(db)); err != nil {
+			return err
+		}
+	}
+	if changed(FlagMinimumBlockHeight) {
+		if cfg.Indexer.MinimumBlockHeight, err = flags.GetInt(FlagMinimumBlockHeight); err != nil {
+			return err
+		}
+	}
+	if changed(FlagRunWithAPI) {
+		if cfg.API.Enabled, err = flags.GetBool(FlagRunWithAPI); err != nil {
+			return err
+		}
+	}
+	if changed(FlagWorkers) {
+		if cfg.Backfill.Workers, err = flags.GetInt(FlagWorkers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CmdConfig prints the config resolved from the config file, the env and the flags.
+func CmdConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validates and prints the config resolved from the config file, the env and the flags, without the secrets.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, _, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			fmt.Print(cfg)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/export"
+)
+
+const (
+	FlagOut    = "out"
+	FlagFormat = "format"
+)
+
+// CmdExport writes the chain info and the records indexed of the chain to a file.
+func CmdExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [chain-id]",
+		Short: "Writes the chain info and the records indexed of the chain to a JSONL or Parquet file.",
+		Long: `Writes the chain info and the records indexed of the chain, one by line in JSONL or one by row in Parquet,
+with the chain id, the collection, the doc id, the block height and the doc as JSON. --msg and --from/--to filter the records,
+the intervals indexed of the chain info are restricted to the filter as well.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			out, err := cmd.Flags().GetString(FlagOut)
+			if err != nil {
+				return err
+			}
+			format, err := cmd.Flags().GetString(FlagFormat)
+			if err != nil {
+				return err
+			}
+			if format == "" {
+				format = export.FormatOfFile(out)
+			}
+			flagMsgs, err := cmd.Flags().GetStringSlice(FlagMsg)
+			if err != nil {
+				return err
+			}
+			msgNames, err := findCosmosMsgNames(flagMsgs...)
+			if err != nil {
+				return err
+			}
+			from, err := cmd.Flags().GetInt(FlagFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetInt(FlagTo)
+			if err != nil {
+				return err
+			}
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			w, err := export.CreateFile(out, format)
+			if err != nil {
+				return err
+			}
+			exported, err := export.Export(ctx, db, args[0], export.Filter{MsgNames: msgNames, FromHeight: from, ToHeight: to}, w)
+			if err != nil {
+				_ = w.Close()
+				return err
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			fmt.Printf("%d records exported to %s\n", exported, out)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagOut, "", "file to write, ex.: umee-1.jsonl or umee-1.parquet")
+	cmd.Flags().String(FlagFormat, "", "jsonl or parquet, by the file extension if not set")
+	cmd.Flags().StringSlice(FlagMsg, nil, "proto names of the msgs whose records are exported, all the records if not set")
+	cmd.Flags().Int(FlagFrom, 0, "first block height of the records exported")
+	cmd.Flags().Int(FlagTo, 0, "last block height of the records exported (inclusive)")
+	_ = cmd.MarkFlagRequired(FlagOut)
+	return cmd
+}
+
+// CmdImport loads the records of a file written by export into the database.
+func CmdImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Loads the records of a JSONL or Parquet file written by export into the database.",
+		Long: `Loads the records of a file written by export into the database, overwriting the docs with the same id.
+The intervals indexed of the chain info are merged with the stored ones.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			format, err := cmd.Flags().GetString(FlagFormat)
+			if err != nil {
+				return err
+			}
+			if format == "" {
+				format = export.FormatOfFile(args[0])
+			}
+
+			r, closeFile, err := export.OpenFile(args[0], format)
+			if err != nil {
+				return err
+			}
+			defer closeFile()
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			imported, err := export.Import(ctx, db, r)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d records imported from %s\n", imported, args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagFormat, "", "jsonl or parquet, by the file extension if not set")
+	return cmd
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/export"
+)
+
+const (
+	FlagCheckpoint = "checkpoint"
+	FlagBatchSize  = "batch"
+)
+
+// CmdMigrateDB copies the chain data from a database backend to another.
+func CmdMigrateDB() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-db [chain-id]",
+		Short: "Copies the chain info and all the records of the chain from a database backend to another, then verifies them.",
+		Long: `Copies the records of every collection of the chain in batches, saving a checkpoint after each batch, and the chain
+info at last. Running it again with the same checkpoint resumes after the last batch copied. Once everything is copied, the amount
+of docs and a checksum of every collection are compared between both databases. The indexer must be stopped while it runs.
+The databases available are firebase and memory, the memory one is dropped when the command exits, it only checks the source.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			chainID := args[0]
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetString(FlagFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetString(FlagTo)
+			if err != nil {
+				return err
+			}
+			checkpointPath, err := cmd.Flags().GetString(FlagCheckpoint)
+			if err != nil {
+				return err
+			}
+			if checkpointPath == "" {
+				checkpointPath = fmt.Sprintf("migrate-db-%s.json", chainID)
+			}
+			batchSize, err := cmd.Flags().GetInt(FlagBatchSize)
+			if err != nil {
+				return err
+			}
+
+			srcType, err := database.ParseTypeDB(from)
+			if err != nil {
+				return err
+			}
+			dstType, err := database.ParseTypeDB(to)
+			if err != nil {
+				return err
+			}
+			if srcType == dstType {
+				return fmt.Errorf("the source and the destination are both %s", from)
+			}
+
+			// both databases share the settings of the config, as the firebase project.
+			srcCfg, dstCfg := cfg.DB, cfg.DB
+			srcCfg.Backend, dstCfg.Backend = srcType, dstType
+			src, err := database.NewDB(srcCfg, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			dst, err := database.NewDB(dstCfg, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer dst.Close()
+
+			cp, err := export.LoadCheckpoint(checkpointPath, chainID)
+			if err != nil {
+				return err
+			}
+			err = export.Migrate(ctx, src, dst, cp, batchSize, func(cp *export.Checkpoint) error {
+				return cp.Save(checkpointPath)
+			})
+			if err != nil {
+				return fmt.Errorf("migration stopped, run it again to resume from %s: %w", checkpointPath, err)
+			}
+			collNames := make([]string, 0, len(cp.Copied))
+			for collName := range cp.Copied {
+				collNames = append(collNames, collName)
+			}
+			sort.Strings(collNames)
+			for _, collName := range collNames {
+				fmt.Printf("%s: %d docs copied\n", collName, cp.Copied[collName])
+			}
+
+			mismatches, err := export.VerifyMigration(ctx, src, dst, chainID)
+			if err != nil {
+				return err
+			}
+			for _, m := range mismatches {
+				fmt.Printf("%s differs: %s has %d docs (checksum %s), %s has %d docs (checksum %s)\n", m.Source.Collection,
+					from, m.Source.Count, m.Source.Checksum, to, m.Destination.Count, m.Destination.Checksum)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("%d collections differ after the migration", len(mismatches))
+			}
+			fmt.Printf("migration of %s from %s to %s verified\n", chainID, from, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagFrom, "firebase", "database to copy from: firebase or memory")
+	cmd.Flags().String(FlagTo, "", "database to copy to: firebase or memory")
+	cmd.Flags().String(FlagCheckpoint, "", "file of the checkpoint to resume from, migrate-db-[chain-id].json if not set")
+	cmd.Flags().Int(FlagBatchSize, 500, "amount of docs copied by batch")
+	_ = cmd.MarkFlagRequired(FlagTo)
+	return cmd
+}
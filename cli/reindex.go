@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	FlagFrom = "from"
+	FlagTo   = "to"
+	FlagMsg  = "msg"
+	FlagSync = "sync"
+)
+
+// CmdReindex deletes what was indexed of a msg in a block range, so it is indexed again.
+func CmdReindex() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex [chain-id]",
+		Short: "Deletes the records of the msg between the block heights and marks them to be indexed again.",
+		Long: `Removes the block heights from the intervals indexed of the msg and deletes the records stored for them.
+The indexer must be stopped while it runs, it fails if the chain head was stored in the last minute. The next
+start indexes the block heights again with the backfill of old blocks, or --sync indexes them right away.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetInt(FlagFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetInt(FlagTo)
+			if err != nil {
+				return err
+			}
+			flagMsg, err := cmd.Flags().GetString(FlagMsg)
+			if err != nil {
+				return err
+			}
+			syncIndex, err := cmd.Flags().GetBool(FlagSync)
+			if err != nil {
+				return err
+			}
+
+			msgNames, err := findCosmosMsgNames(flagMsg)
+			if err != nil {
+				return err
+			}
+			msgName := msgNames[0]
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+
+			deleted, err := idx.Reindex(ctx, db, args[0], msgName, from, to)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s unindexed from %d to %d, %d records deleted\n", msgName, from, to, deleted)
+			if !syncIndex {
+				return db.Close()
+			}
+
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, from)
+			if err != nil {
+				return err
+			}
+			i, err := idx.NewIndexer(ctx, b, db, logger, from)
+			if err != nil {
+				return err
+			}
+			defer i.Close(ctx)
+
+			return i.Backfill(ctx, from, to, cfg.Backfill.Workers, msgNames, newProgressPrinter())
+		},
+	}
+
+	cmd.Flags().Int(FlagFrom, 0, "first block height to reindex")
+	cmd.Flags().Int(FlagTo, 0, "last block height to reindex (inclusive)")
+	cmd.Flags().String(FlagMsg, "", "proto name of the msg to reindex, ex.: umee.leverage.v1.MsgLiquidate or MsgLiquidate")
+	cmd.Flags().Bool(FlagSync, false, "indexes the block heights right away, printing the progress")
+	cmd.Flags().Int(FlagWorkers, defaultWorkers, "amount of blocks fetched in parallel with --sync")
+	_ = cmd.MarkFlagRequired(FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagTo)
+	_ = cmd.MarkFlagRequired(FlagMsg)
+	addArchiveFlags(cmd)
+	return cmd
+}
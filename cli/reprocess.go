@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	FlagHandler = "handler"
+)
+
+// CmdReprocessFailed retries the txs and block handlers that failed to be indexed.
+func CmdReprocessFailed() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reprocess-failed [chain-id]",
+		Short: "Retries the dead letters of the chain of the node, the txs and block handlers that failed to be indexed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, 0)
+			if err != nil {
+				return err
+			}
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+
+			i, err := idx.NewIndexer(ctx, b, db, logger, 1)
+			if err != nil {
+				return err
+			}
+			defer i.Close(ctx)
+
+			flagHandler, err := cmd.Flags().GetString(FlagHandler)
+			if err != nil {
+				return err
+			}
+			var handler *string
+			if flagHandler != "" {
+				handler = &flagHandler
+			}
+
+			chainID := args[0]
+			reprocessed, failed, err := i.ReprocessDeadLetters(ctx, chainID, handler)
+			fmt.Printf("reprocessed %d dead letters, %d failed again\n", reprocessed, failed)
+			if err != nil {
+				return err
+			}
+
+			letters, err := db.GetDeadLetters(ctx, chainID, nil)
+			if err != nil {
+				return err
+			}
+			printDeadLetterCounts(types.DeadLetterCounts(letters))
+			if failed > 0 {
+				return fmt.Errorf("%d dead letters failed to be reprocessed", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagHandler, "", fmt.Sprintf("%s=%s to only reprocess the failures of that handler", FlagHandler, types.DeadLetterHandlerMsg))
+	addArchiveFlags(cmd)
+	return cmd
+}
+
+// CmdDeadLetters prints the amount of dead letters by handler and msg.
+func CmdDeadLetters() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dead-letters [chain-id]",
+		Short: "Connects to the database and prints the amount of dead letters by handler and msg.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			letters, err := db.GetDeadLetters(ctx, args[0], nil)
+			if err != nil {
+				return err
+			}
+			printDeadLetterCounts(types.DeadLetterCounts(letters))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func printDeadLetterCounts(counts []*types.DeadLetterCount) {
+	fmt.Printf("__DEAD LETTERS__\n")
+	total := 0
+	for _, count := range counts {
+		fmt.Printf("%s %s = %d\n", count.Handler, count.ProtoMsgName, count.Count)
+		total += count.Count
+	}
+	fmt.Printf("total = %d\n-----------------\n", total)
+}
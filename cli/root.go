@@ -2,26 +2,23 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/spf13/cobra"
-	"github.com/umee-network/umeed-indexer/chain"
 	"github.com/umee-network/umeed-indexer/database"
 	"github.com/umee-network/umeed-indexer/idx"
-	"github.com/umee-network/umeed-indexer/server"
-	"golang.org/x/sync/errgroup"
 )
 
-// TODO: check to remove env and receive as flags with default value...
 const (
-	EnvChainRPC            = "CHAIN_RPC"
-	EnvChainGRPC           = "CHAIN_GRPC"
 	FlagMinimumBlockHeight = "block"
 	FlagRunWithAPI         = "api"
-	defaultPort            = "8080"
+	FlagShutdownTimeout    = "shutdown-timeout"
+	defaultShutdownTimeout = 30 * time.Second
 )
 
 var (
@@ -32,16 +29,30 @@ var (
 	}
 )
 
-// Execute executes the root command.
+// Execute executes the root command, the context of the commands is done on SIGINT or SIGTERM.
 func Execute() error {
-	printEnv()
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 // init add the commands to the root.
 func init() {
+	addConfigFlags(rootCmd)
+	rootCmd.AddCommand(CmdConfig())
 	rootCmd.AddCommand(CmdStartIndex())
+	rootCmd.AddCommand(CmdServe())
 	rootCmd.AddCommand(CmdDeleteChainData())
+	rootCmd.AddCommand(CmdReprocessFailed())
+	rootCmd.AddCommand(CmdDeadLetters())
+	rootCmd.AddCommand(CmdReindex())
+	rootCmd.AddCommand(CmdBackfill())
+	rootCmd.AddCommand(CmdStatus())
+	rootCmd.AddCommand(CmdVerify())
+	rootCmd.AddCommand(CmdExport())
+	rootCmd.AddCommand(CmdImport())
+	rootCmd.AddCommand(CmdMigrateDB())
+	rootCmd.AddCommand(CmdMigrate())
 }
 
 // CmdStartIndex start command line for start to listen to events and store chain data.
@@ -51,24 +62,25 @@ func CmdStartIndex() *cobra.Command {
 		Short: "Runs the indexer, querying and listening to the chain and storing it on the database.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			b, err := chain.NewBlockchain(os.Getenv(EnvChainRPC), os.Getenv(EnvChainGRPC))
+			cfg, logger, err := loadConfig(cmd)
 			if err != nil {
 				return err
 			}
 
-			logger, err := server.LoadLogger()
+			fmt.Printf("__CONFIG used__\n%s-----------------\n", cfg)
+			minimumBlockHeight := cfg.Indexer.MinimumBlockHeight
+			msgStartHeights, err := cfg.MsgStartHeights()
 			if err != nil {
-				fmt.Printf("Error loading logger: %s", err.Error())
 				return err
 			}
 
-			ctx := context.Background()
-			db, err := database.NewDB(database.Firebase, ctx, logger)
+			ctx := cmd.Context()
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, minimumBlockHeight)
 			if err != nil {
 				return err
 			}
 
-			minimumBlockHeight, err := cmd.Flags().GetInt(FlagMinimumBlockHeight)
+			db, err := database.NewDB(cfg.DB, ctx, logger)
 			if err != nil {
 				return err
 			}
@@ -78,47 +90,60 @@ func CmdStartIndex() *cobra.Command {
 				return err
 			}
 
-			runAPI, err := cmd.Flags().GetBool(FlagRunWithAPI)
-			if err != nil {
+			if err := i.SkipBlocksBeforeMsgStart(ctx, msgStartHeights); err != nil {
 				return err
 			}
 
-			g, ctx := errgroup.WithContext(ctx)
+			shutdownTimeout, err := cmd.Flags().GetDuration(FlagShutdownTimeout)
+			if err != nil {
+				return err
+			}
 
-			if runAPI {
-				r, err := server.NewRouter(ctx, db, logger)
+			// the drain of the indexer, the shutdown of the API and the close of the connections share the
+			// deadline, set when the indexer stops.
+			deadline := shutdownDeadline(shutdownTimeout)
+
+			// a failure of the API stops the indexer, which stops the API only after the blocks in flight
+			// are stored, so it keeps answering while the indexer shuts down, as the metrics.
+			indexCtx, stopIndex := context.WithCancel(ctx)
+			defer stopIndex()
+			apiCtx, stopAPI := context.WithCancel(context.WithoutCancel(ctx))
+			defer stopAPI()
+			apiErr := make(chan error, 1)
+			if cfg.API.Enabled {
+				r, err := newAPIRouter(ctx, db, logger)
 				if err != nil {
 					return err
 				}
-
-				// Route handling
-				// Endpoint: http://localhost:8080/graphql
-				// Subscriptions endpoint: ws://localhost:8080/graphql
-				r.HandleFunc("/", playground.ApolloSandboxHandler("GraphQL Apollo playground", "/graphql"))
-				r.HandleFunc("/default", playground.Handler("GraphQL playground", "/graphql"))
-				r.HandleFunc("/altair", playground.AltairHandler("GraphQL Altair playground", "/graphql"))
-
-				port := os.Getenv("PORT")
-				if port == "" {
-					port = defaultPort
-				}
-				// Start the server
-				logger.Info().Msgf("connect to http://localhost:%s/ for GraphQL playground", port)
-				g.Go(func() error {
-					return http.ListenAndServe(":"+port, r)
-				})
+				go func() {
+					apiErr <- listenAPI(apiCtx, cfg.API, r, deadline, logger)
+					stopIndex()
+				}()
+			} else {
+				apiErr <- nil
 			}
+			go func() {
+				if err := listenMetrics(apiCtx, cfg.Metrics, deadline, logger); err != nil {
+					logger.Err(err).Msg("error serving the metrics")
+				}
+			}()
 
-			g.Go(func() error {
-				return i.Index(ctx)
-			})
+			err = i.Index(indexCtx)
+			logger.Info().Msg("shutting down the indexer")
 
-			return g.Wait()
+			shutdownCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline())
+			defer cancel()
+			err = errors.Join(err, i.Drain(shutdownCtx))
+			stopAPI()
+			err = errors.Join(err, <-apiErr)
+			return errors.Join(err, i.Close(shutdownCtx))
 		},
 	}
 
 	cmd.Flags().Int(FlagMinimumBlockHeight, 1, fmt.Sprintf("%s=100 to start indexing from block 100", FlagMinimumBlockHeight))
 	cmd.Flags().Bool(FlagRunWithAPI, false, fmt.Sprintf("%s=true to start by serving an API which can query the db by using graphql", FlagRunWithAPI))
+	cmd.Flags().Duration(FlagShutdownTimeout, defaultShutdownTimeout, "time to wait for the blocks being indexed and the API requests when the indexer is stopped")
+	addArchiveFlags(cmd)
 	return cmd
 }
 
@@ -128,16 +153,15 @@ func CmdDeleteChainData() *cobra.Command {
 		Use:   "delete [chain-id]",
 		Short: "Connects to the database and deletes the chain data.",
 		Args:  cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
-			ctx := context.Background()
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
 
-			logger, err := server.LoadLogger()
+			cfg, logger, err := loadConfig(cmd)
 			if err != nil {
-				fmt.Printf("Error loading logger: %s", err.Error())
 				return err
 			}
 
-			db, err := database.NewDB(database.Firebase, ctx, logger)
+			db, err := database.NewDB(cfg.DB, ctx, logger)
 			if err != nil {
 				return err
 			}
@@ -151,14 +175,3 @@ func CmdDeleteChainData() *cobra.Command {
 
 	return cmd
 }
-
-// just prints the env file.
-func printEnv() {
-	rpc := os.Getenv(EnvChainRPC)
-	grpc := os.Getenv(EnvChainGRPC)
-	fmt.Printf(
-		"__ENVS used__\n%s = %s\n%s = %s\n-----------------\n",
-		EnvChainRPC, rpc,
-		EnvChainGRPC, grpc,
-	)
-}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/migrations"
+)
+
+const (
+	FlagDryRun = "dry-run"
+)
+
+// CmdMigrate runs the pending schema migrations of the chain.
+func CmdMigrate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [chain-id]",
+		Short: "Upgrades the docs stored of the chain by older versions of the indexer to the latest schema version.",
+		Long: `Runs the migrations of the schema versions after the one stored of the chain in order, storing the version after each one.
+The indexer runs them as well when it starts, older versions of the indexer must be stopped while they run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			chainID := args[0]
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool(FlagDryRun)
+			if err != nil {
+				return err
+			}
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if dryRun {
+				pending, err := migrations.Pending(ctx, db, chainID)
+				if err != nil {
+					return err
+				}
+				for _, m := range pending {
+					fmt.Printf("%d: %s\n", m.Version, m.Description)
+				}
+				fmt.Printf("%d migrations pending for %s\n", len(pending), chainID)
+				return nil
+			}
+
+			ran, err := migrations.Run(ctx, db, chainID, logger)
+			for _, m := range ran {
+				fmt.Printf("%d: %s\n", m.Version, m.Description)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s migrated to the schema version %d\n", chainID, migrations.LatestVersion())
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(FlagDryRun, false, "only prints the migrations pending")
+	return cmd
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/config"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/server"
+)
+
+// CmdServe serves the API of the stored data without indexing.
+func CmdServe() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serves the GraphQL API of the data stored on the database, without connecting to the chain.",
+		Long: `Serves the GraphQL API with read-only access to the database, any write to it fails. Many API replicas
+can run with serve while a single indexer started with start writes to the database.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("__CONFIG used__\n%s-----------------\n", cfg)
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			r, err := newAPIRouter(ctx, database.NewReadOnly(db), logger)
+			if err != nil {
+				return err
+			}
+			shutdownTimeout, err := cmd.Flags().GetDuration(FlagShutdownTimeout)
+			if err != nil {
+				return err
+			}
+			return listenAPI(ctx, cfg.API, r, shutdownDeadline(shutdownTimeout), logger)
+		},
+	}
+
+	cmd.Flags().Duration(FlagShutdownTimeout, defaultShutdownTimeout, "time to wait for the API requests when the server is stopped")
+	return cmd
+}
+
+// newAPIRouter returns the router of the GraphQL API with its playgrounds.
+func newAPIRouter(ctx context.Context, db database.Database, logger zerolog.Logger) (*mux.Router, error) {
+	r, err := server.NewRouter(ctx, db, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Route handling
+	// Endpoint: http://localhost:8080/graphql
+	// Subscriptions endpoint: ws://localhost:8080/graphql
+	r.HandleFunc("/", playground.ApolloSandboxHandler("GraphQL Apollo playground", "/graphql"))
+	r.HandleFunc("/default", playground.Handler("GraphQL playground", "/graphql"))
+	r.HandleFunc("/altair", playground.AltairHandler("GraphQL Altair playground", "/graphql"))
+	return r, nil
+}
+
+// listenAPI serves the router on the port of the API config until the context is done, then it waits
+// for the requests being answered up to the shutdown deadline.
+func listenAPI(ctx context.Context, api config.API, r *mux.Router, deadline func() time.Time, logger zerolog.Logger) error {
+	logger.Info().Msgf("connect to http://localhost:%d/ for GraphQL playground", api.Port)
+	return listen(ctx, "API", api.Port, r, deadline, logger)
+}
+
+// listenMetrics serves the Prometheus metrics on their own port until the context is done, so the
+// commands indexing blocks expose them without the API.
+func listenMetrics(ctx context.Context, metrics config.Metrics, deadline func() time.Time, logger zerolog.Logger) error {
+	handler := http.NewServeMux()
+	handler.Handle("/metrics", promhttp.Handler())
+	logger.Info().Msgf("serving the metrics on http://localhost:%d/metrics", metrics.Port)
+	return listen(ctx, "metrics", metrics.Port, handler, deadline, logger)
+}
+
+// listen serves the handler on the port until the context is done, then it waits for the requests
+// being answered up to the shutdown deadline.
+func listen(ctx context.Context, name string, port int, h http.Handler, deadline func() time.Time, logger zerolog.Logger) error {
+	srv := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: h}
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-listenErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info().Msgf("shutting down the %s", name)
+	shutdownCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline())
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// shutdownDeadline returns the deadline of the shutdown, set by its first call to the timeout from then,
+// so the steps of a shutdown share it.
+func shutdownDeadline(timeout time.Duration) func() time.Time {
+	return sync.OnceValue(func() time.Time {
+		return time.Now().Add(timeout)
+	})
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	FlagJSON = "json"
+)
+
+// CmdStatus prints the indexing coverage of the chain.
+func CmdStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [chain-id]",
+		Short: "Prints the intervals indexed by msg, the blocks covered, the gaps and the lag behind the chain tip.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			asJSON, err := cmd.Flags().GetBool(FlagJSON)
+			if err != nil {
+				return err
+			}
+
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, 0)
+			if err != nil {
+				return err
+			}
+			defer b.Close(ctx)
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			status, err := idx.Status(ctx, b, db, args[0])
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(status)
+			}
+			printChainStatus(status)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(FlagJSON, false, "prints the status as JSON")
+	addArchiveFlags(cmd)
+	return cmd
+}
+
+func printChainStatus(status *types.ChainStatus) {
+	fmt.Printf("__STATUS %s__\n", status.ChainID)
+	fmt.Printf("chain height = %d\nlowest height on node = %d\nlast block received = %d\n",
+		status.ChainHeight, status.LowestHeightOnNode, status.LastBlockHeightReceived)
+	for _, msg := range status.Msgs {
+		fmt.Printf("-----------------\n%s\n", msg.ProtoMsgName)
+		fmt.Printf("blocks indexed = %d, lag = %d\n", msg.BlocksIndexed, msg.Lag)
+		fmt.Printf("intervals = %s\n", formatIntervals(msg.Intervals))
+		fmt.Printf("gaps = %s\n", formatIntervals(msg.Gaps))
+	}
+	fmt.Printf("-----------------\n")
+}
+
+func formatIntervals(intervals []*types.BlockIndexedInterval) string {
+	if len(intervals) == 0 {
+		return "none"
+	}
+	values := make([]string, len(intervals))
+	for i, interval := range intervals {
+		values[i] = fmt.Sprintf("%d ~ %d", interval.IdxFromBlockHeight, interval.IdxToBlockHeight)
+	}
+	return strings.Join(values, ", ")
+}
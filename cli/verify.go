@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+const (
+	FlagRepair = "repair"
+)
+
+// CmdVerify compares the records stored of a block range with the ones the handlers store again.
+func CmdVerify() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Fetches the blocks between the heights again and reports the differences of the stored records to a dry run of the handlers.",
+		Long: `Fetches the blocks between the heights again, runs the handlers against an in-memory database and compares it with the
+records stored: txs, oracle votes, balance changes, uibc events and registry changes made by txs. It reports the missing records, the
+extra or duplicated ones and the field mismatches, exiting with an error if there is any.
+--repair reindexes the block heights of the differences by msg, the indexer must be stopped while it runs as for reindex.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			cfg, logger, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetInt(FlagFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetInt(FlagTo)
+			if err != nil {
+				return err
+			}
+			repair, err := cmd.Flags().GetBool(FlagRepair)
+			if err != nil {
+				return err
+			}
+			asJSON, err := cmd.Flags().GetBool(FlagJSON)
+			if err != nil {
+				return err
+			}
+
+			b, err := loadBlockchain(ctx, cmd, cfg.Chain, logger, from)
+			if err != nil {
+				return err
+			}
+			defer b.Close(ctx)
+
+			db, err := database.NewDB(cfg.DB, ctx, logger)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			chainID, _, err := b.ChainHeader()
+			if err != nil {
+				return err
+			}
+
+			diffs, err := idx.Verify(ctx, b, db, logger, chainID, from, to, cfg.Backfill.Workers)
+			if err != nil {
+				return err
+			}
+			if repair && len(diffs) > 0 {
+				unrepaired, err := idx.Repair(ctx, b, db, logger, chainID, diffs, cfg.Backfill.Workers)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d differences repaired, %d can not be repaired\n", len(diffs)-len(unrepaired), len(unrepaired))
+
+				// verifies again to report what is left.
+				if diffs, err = idx.Verify(ctx, b, db, logger, chainID, from, to, cfg.Backfill.Workers); err != nil {
+					return err
+				}
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(diffs); err != nil {
+					return err
+				}
+			} else {
+				printRecordDiffs(diffs)
+			}
+			if len(diffs) > 0 {
+				return fmt.Errorf("%d differences found between %d and %d", len(diffs), from, to)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(FlagFrom, 0, "first block height to verify")
+	cmd.Flags().Int(FlagTo, 0, "last block height to verify (inclusive)")
+	cmd.Flags().Bool(FlagRepair, false, "reindexes the block heights of the differences found")
+	cmd.Flags().Int(FlagWorkers, defaultWorkers, "amount of blocks fetched in parallel")
+	cmd.Flags().Bool(FlagJSON, false, "prints the differences as JSON")
+	_ = cmd.MarkFlagRequired(FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagTo)
+	addArchiveFlags(cmd)
+	return cmd
+}
+
+func printRecordDiffs(diffs []*types.RecordDiff) {
+	for _, diff := range diffs {
+		fmt.Printf("%d %s %s %s (%s)\n", diff.BlockHeight, diff.Type, diff.Kind, diff.Key, diff.ProtoMsgName)
+		if diff.Expected != "" {
+			fmt.Printf("  expected: %s\n", diff.Expected)
+		}
+		if diff.Stored != "" {
+			fmt.Printf("  stored:   %s\n", diff.Stored)
+		}
+	}
+	fmt.Printf("%d differences found\n", len(diffs))
+}
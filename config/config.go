@@ -0,0 +1,271 @@
+// Package config loads the settings of the indexer from a YAML or TOML file and the env, the flags of
+// the commands override them after. Each source overrides the previous one: defaults, file, env, flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvChainRPC        = "CHAIN_RPC"
+	EnvChainGRPC       = "CHAIN_GRPC"
+	EnvLogFilePath     = "LOG_FILE_PATH"
+	EnvLogLevel        = "LOG_LEVEL"
+	EnvDBBackend       = "DB_BACKEND"
+	EnvAPIEnabled      = "API_ENABLED"
+	EnvPort            = "PORT"
+	EnvMetricsPort     = "METRICS_PORT"
+	EnvBackfillWorkers = "BACKFILL_WORKERS"
+
+	redacted = "[REDACTED]"
+)
+
+// Config is the settings of the indexer.
+type Config struct {
+	Chain    Chain           `yaml:"chain" toml:"chain"`
+	Log      Log             `yaml:"log" toml:"log"`
+	DB       database.Config `yaml:"db" toml:"db"`
+	API      API             `yaml:"api" toml:"api"`
+	Metrics  Metrics         `yaml:"metrics" toml:"metrics"`
+	Indexer  Indexer         `yaml:"indexer" toml:"indexer"`
+	Backfill Backfill        `yaml:"backfill" toml:"backfill"`
+}
+
+// Chain is the node queried for blocks and the chain state.
+type Chain struct {
+	RPC  string `yaml:"rpc" toml:"rpc"`
+	GRPC string `yaml:"grpc" toml:"grpc"`
+}
+
+// Log is where and from which level the logs are written, they go to Stdout if there is no file path.
+type Log struct {
+	FilePath string `yaml:"filePath" toml:"filePath"`
+	Level    string `yaml:"level" toml:"level"`
+}
+
+// API is the GraphQL server.
+type API struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	Port    int  `yaml:"port" toml:"port"`
+}
+
+// Metrics is the listener of the Prometheus metrics of the commands that index blocks.
+type Metrics struct {
+	Port int `yaml:"port" toml:"port"`
+}
+
+// Indexer is the range of block heights indexed.
+type Indexer struct {
+	MinimumBlockHeight int `yaml:"minimumBlockHeight" toml:"minimumBlockHeight"`
+	// MsgStartHeights are the block heights each msg starts to be indexed from, by its full or short
+	// proto name, ex.: MsgLeveragedLiquidate: 9000000 if the msg was added by the upgrade at that height.
+	MsgStartHeights map[string]int `yaml:"msgStartHeights" toml:"msgStartHeights"`
+}
+
+// Backfill is the tuning of the backfill of old blocks.
+type Backfill struct {
+	// Workers is the amount of blocks fetched in parallel.
+	Workers int `yaml:"workers" toml:"workers"`
+}
+
+// Default returns the config used when nothing is set.
+func Default() Config {
+	return Config{
+		Log: Log{Level: zerolog.ErrorLevel.String()},
+		DB: database.Config{
+			Backend: database.Firebase,
+			Firebase: firebase.Config{
+				ProjectID:       "umee-testnet-alpha",
+				CredentialsFile: "firebase.json",
+			},
+		},
+		API:      API{Port: 8080},
+		Metrics:  Metrics{Port: 9091},
+		Indexer:  Indexer{MinimumBlockHeight: 1},
+		Backfill: Backfill{Workers: 8},
+	}
+}
+
+// Load returns the default config overridden by the file, if the path is not empty, and then by the env.
+func Load(path string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := cfg.LoadFile(path); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, cfg.LoadEnv(getenv)
+}
+
+// LoadFile overrides the config by the values set in the YAML or TOML file, by its extension.
+func (c *Config) LoadFile(path string) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(bz))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+		if errors.Is(err, io.EOF) { // empty file
+			err = nil
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(bz))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(c)
+	default:
+		return fmt.Errorf("unknown config format %q, it must be .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("error decoding the config %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadEnv overrides the config by the env variables set.
+func (c *Config) LoadEnv(getenv func(string) string) error {
+	setString := func(env string, value *string) {
+		if v := getenv(env); v != "" {
+			*value = v
+		}
+	}
+	setString(EnvChainRPC, &c.Chain.RPC)
+	setString(EnvChainGRPC, &c.Chain.GRPC)
+	setString(EnvLogFilePath, &c.Log.FilePath)
+	setString(EnvLogLevel, &c.Log.Level)
+	setString(firebase.EnvFirebaseProjectID, &c.DB.Firebase.ProjectID)
+	setString(firebase.EnvFirebaseCredentialsJSON, &c.DB.Firebase.CredentialsJSON)
+	setString(firebase.EnvFirebaseEmulator, &c.DB.Firebase.EmulatorHost)
+
+	if v := getenv(EnvDBBackend); v != "" {
+		if err := c.DB.Backend.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("%s: %w", EnvDBBackend, err)
+		}
+	}
+	if v := getenv(EnvAPIEnabled); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvAPIEnabled, err)
+		}
+		c.API.Enabled = enabled
+	}
+	for env, value := range map[string]*int{
+		EnvPort:            &c.API.Port,
+		EnvMetricsPort:     &c.Metrics.Port,
+		EnvBackfillWorkers: &c.Backfill.Workers,
+	} {
+		v := getenv(env)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", env, err)
+		}
+		*value = n
+	}
+	return nil
+}
+
+// Validate returns an error describing every invalid setting of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if _, err := zerolog.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+
+	switch c.DB.Backend {
+	case database.Firebase:
+		if c.DB.Firebase.ProjectID == "" {
+			errs = append(errs, errors.New("db.firebase.projectID: it must be set to use firebase"))
+		}
+		if c.DB.Firebase.EmulatorHost == "" && c.DB.Firebase.CredentialsJSON == "" && c.DB.Firebase.CredentialsFile == "" {
+			errs = append(errs, errors.New("db.firebase: the credentials or the emulator host must be set"))
+		}
+	case database.Memory:
+	default:
+		errs = append(errs, fmt.Errorf("db.backend: unsupported database type %v", c.DB.Backend))
+	}
+
+	if c.API.Port < 1 || c.API.Port > 65535 {
+		errs = append(errs, fmt.Errorf("api.port: %d is not a valid port", c.API.Port))
+	}
+	if c.Metrics.Port < 1 || c.Metrics.Port > 65535 {
+		errs = append(errs, fmt.Errorf("metrics.port: %d is not a valid port", c.Metrics.Port))
+	} else if c.Metrics.Port == c.API.Port {
+		errs = append(errs, fmt.Errorf("metrics.port: %d is the port of the API", c.Metrics.Port))
+	}
+	if c.Indexer.MinimumBlockHeight < 0 {
+		errs = append(errs, fmt.Errorf("indexer.minimumBlockHeight: %d is negative", c.Indexer.MinimumBlockHeight))
+	}
+	if _, err := c.MsgStartHeights(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Backfill.Workers < 1 {
+		errs = append(errs, fmt.Errorf("backfill.workers: %d must be at least 1", c.Backfill.Workers))
+	}
+	return errors.Join(errs...)
+}
+
+// MsgStartHeights returns the start heights of the msgs by their full proto names.
+func (c Config) MsgStartHeights() (map[string]int, error) {
+	cosmosMsgs := types.DefaultChainInfo("").CosmosMsgs
+	startHeights := make(map[string]int, len(c.Indexer.MsgStartHeights))
+	for name, height := range c.Indexer.MsgStartHeights {
+		msgName, found := types.FindCosmosMsgName(cosmosMsgs, name)
+		if !found {
+			return nil, fmt.Errorf("indexer.msgStartHeights: msg %q is not indexed", name)
+		}
+		if height < 1 {
+			return nil, fmt.Errorf("indexer.msgStartHeights: the start height of %s must be at least 1", name)
+		}
+		startHeights[msgName] = height
+	}
+	return startHeights, nil
+}
+
+// Redacted returns a copy of the config without the secrets, to be printed.
+func (c Config) Redacted() Config {
+	if c.DB.Firebase.CredentialsJSON != "" {
+		c.DB.Firebase.CredentialsJSON = redacted
+	}
+	c.Chain.RPC = redactURL(c.Chain.RPC)
+	c.Chain.GRPC = redactURL(c.Chain.GRPC)
+	return c
+}
+
+// String returns the config without the secrets in YAML.
+func (c Config) String() string {
+	bz, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(bz)
+}
+
+// redactURL hides the password of the URL, the node URLs may have basic auth.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
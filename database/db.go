@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/rs/zerolog"
 	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/database/memory"
 	"github.com/umee-network/umeed-indexer/graph/types"
 )
 
@@ -17,7 +19,8 @@ type TypeDB uint8
 const (
 	// Firebase is the default DB for this indexer.
 	Firebase TypeDB = iota + 1
-	// TODO: add in memory for testing.
+	// Memory keeps the data in memory, for tests and offline runs.
+	Memory
 )
 
 // Database defines the exported functions of the database.
@@ -37,48 +40,242 @@ type Database interface {
 
 	// DeleteChainData delete the chain data and all of its structures inside.
 	DeleteChainData(ctx context.Context, chainID string) (err error)
+	// DeleteMsgRecords deletes the records stored by the handler of the msg and its dead letters between
+	// the block heights (inclusive), returning how many docs were deleted.
+	DeleteMsgRecords(ctx context.Context, chainID, protoMsgName string, fromHeight, toHeight int) (deleted int, err error)
+	// GetBlockRecords returns the records stored by block between the block heights (inclusive).
+	GetBlockRecords(ctx context.Context, chainID string, fromHeight, toHeight int) (records *types.BlockRecords, err error)
+	// ForEachDoc calls f with the docs of the collection of the chain in the id order, decoded into the
+	// values returned by newDoc. Only the docs after the id are read if it is not empty.
+	ForEachDoc(ctx context.Context, chainID, collName, afterID string, newDoc func() any, f func(id string, doc any) error) (err error)
+	// ImportDocs sets the docs of the collection of the chain by id, overwriting the stored ones.
+	ImportDocs(ctx context.Context, chainID, collName string, docs map[string]any) (err error)
+	// GetSchemaVersion returns the schema version of the docs stored of the chain, zero if it was never migrated.
+	GetSchemaVersion(ctx context.Context, chainID string) (version int, err error)
+	// SetSchemaVersion stores the schema version of the docs of the chain.
+	SetSchemaVersion(ctx context.Context, chainID string, version int) (err error)
 	// UpsertChainInfo updates or inserts a chain info structure.
 	UpsertChainInfo(ctx context.Context, chainInfo types.ChainInfo) (err error)
+	// UpdateChainHead updates the last block received of the chain info, keeping the intervals indexed stored.
+	UpdateChainHead(ctx context.Context, chainInfo types.ChainInfo) (err error)
+	// IndexBlockRange updates the last block received of the chain info and adds the block heights to the
+	// intervals indexed stored of the msgs, or of all the cosmos msgs if none is given.
+	IndexBlockRange(ctx context.Context, chainInfo types.ChainInfo, msgNames []string, fromHeight, toHeight int) (err error)
+	// UnindexBlockRange removes the block heights from the intervals indexed stored of the msg.
+	UnindexBlockRange(ctx context.Context, chainID, msgName string, fromHeight, toHeight int) (err error)
 	// GetChainInfo returns the last chainInfo.
 	GetChainInfo(ctx context.Context, chainID string) (info *types.ChainInfo, err error)
-	// StoreMsgLiquidate stores a new msgliquidate updating the CosmosMsgIndexed.
-	StoreMsgLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, msg types.MsgLiquidate) (err error)
-	// StoreMsgLeverageLiquidate stores a new MsgLeverageLiquidate updating the CosmosMsgIndexed.
-	StoreMsgLeverageLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, msg types.MsgLeverageLiquidate) (err error)
+	// StoreMsgLiquidate stores a new msgliquidate.
+	StoreMsgLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLiquidate) (err error)
+	// StoreMsgLeverageLiquidate stores a new MsgLeverageLiquidate.
+	StoreMsgLeverageLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLeverageLiquidate) (err error)
+	// StoreTx stores a new indexed tx.
+	StoreTx(ctx context.Context, chainInfo types.ChainInfo, tx types.IndexedTx) (err error)
 	// GetLiquidateMsgs returns all the msgs liquidate filtering by the borrower.
 	GetLiquidateMsgs(ctx context.Context, chainID string, borrower string) (txs []*types.IndexedTx, err error)
+	// GetGranteeMsgs returns the msgs executed by the grantee on behalf of other accounts.
+	GetGranteeMsgs(ctx context.Context, chainID, grantee string) (txs []*types.IndexedTx, err error)
+
+	/*
+		Oracle
+	*/
+
+	// StoreOracleVotes stores the oracle votes and misses, increasing the validators performance by slash window.
+	StoreOracleVotes(ctx context.Context, chainInfo types.ChainInfo, votes []types.OracleValidatorVote) (err error)
+	// StoreMsgDelegateFeedConsent stores a new MsgDelegateFeedConsent.
+	StoreMsgDelegateFeedConsent(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgDelegateFeedConsent) (err error)
+	// GetOracleValidatorPerformance returns the validator performance by slash window, if window is nil returns all of the windows.
+	GetOracleValidatorPerformance(ctx context.Context, chainID, valoper string, window *int) (perfs []*types.OracleValidatorPerformance, err error)
+	// GetFeederDelegations returns all the feeder delegations made by the validator operator.
+	GetFeederDelegations(ctx context.Context, chainID, valoper string) (txs []*types.IndexedTx, err error)
+
+	/*
+		Incentive
+	*/
+
+	// StoreIncentiveProgramSnapshots stores the state of the incentive programs at some block height.
+	StoreIncentiveProgramSnapshots(ctx context.Context, chainID string, snapshots []types.IncentiveProgramSnapshot) (err error)
+	// GetIncentiveAccountTxs returns all the incentive msgs sent by the account.
+	GetIncentiveAccountTxs(ctx context.Context, chainID, account string) (txs []*types.IndexedTx, err error)
+	// GetIncentiveProgramFundings returns the msgs sponsor of the program.
+	GetIncentiveProgramFundings(ctx context.Context, chainID string, programID int) (txs []*types.IndexedTx, err error)
+	// GetIncentiveProgramSnapshots returns the snapshots of the program ordered by block height.
+	GetIncentiveProgramSnapshots(ctx context.Context, chainID string, programID int) (snapshots []*types.IncentiveProgramSnapshot, err error)
+
+	/*
+		Metoken
+	*/
+
+	// StoreMetokenIndexSnapshots stores the balances of the meToken indexes at some block height.
+	StoreMetokenIndexSnapshots(ctx context.Context, chainID string, snapshots []types.MetokenIndexSnapshot) (err error)
+	// GetMetokenUserTxs returns the swaps and redemptions made by the user.
+	GetMetokenUserTxs(ctx context.Context, chainID, user string) (txs []*types.IndexedTx, err error)
+	// GetMetokenTxs returns the msgs (swap or redeem) of the asset in the meToken index, the time interval is optional.
+	GetMetokenTxs(ctx context.Context, chainID, protoMsgName, metokenDenom, assetDenom string, fromTimeUnix, toTimeUnix *int) (txs []*types.IndexedTx, err error)
+	// GetMetokenIndexSnapshots returns the snapshots of the meToken index ordered by block height, the time interval is optional.
+	GetMetokenIndexSnapshots(ctx context.Context, chainID, metokenDenom string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.MetokenIndexSnapshot, err error)
+
+	/*
+		UIBC
+	*/
+
+	// StoreUIBCEvents stores the uibc events.
+	StoreUIBCEvents(ctx context.Context, chainInfo types.ChainInfo, events []types.UIBCEvent) (err error)
+	// StoreUIBCOutflow adds the outflow to the quota window of the denom, only once by transfer.
+	StoreUIBCOutflow(ctx context.Context, chainInfo types.ChainInfo, outflow types.UIBCOutflowWindow, transfer types.UIBCOutflowTransfer) (err error)
+	// StoreUIBCQuotaSnapshot stores the uibc quota state at some block height.
+	StoreUIBCQuotaSnapshot(ctx context.Context, chainID string, snapshot types.UIBCQuotaSnapshot) (err error)
+	// GetUIBCGovTxs returns the governance msgs that changed the uibc quota and status.
+	GetUIBCGovTxs(ctx context.Context, chainID string) (txs []*types.IndexedTx, err error)
+	// GetUIBCEvents returns the uibc events, the event type and time interval are optional.
+	GetUIBCEvents(ctx context.Context, chainID string, eventType *string, fromTimeUnix, toTimeUnix *int) (events []*types.UIBCEvent, err error)
+	// GetUIBCOutflows returns the outflows by quota window, the denom and time interval are optional.
+	GetUIBCOutflows(ctx context.Context, chainID string, denom *string, fromTimeUnix, toTimeUnix *int) (outflows []*types.UIBCOutflowWindow, err error)
+	// GetUIBCQuotaSnapshots returns the quota snapshots ordered by block height, the time interval is optional.
+	GetUIBCQuotaSnapshots(ctx context.Context, chainID string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.UIBCQuotaSnapshot, err error)
+
+	/*
+		IBC
+	*/
+
+	// StoreIBCTransfer merges the transfer with the stored one of the same packet.
+	StoreIBCTransfer(ctx context.Context, chainInfo types.ChainInfo, transfer types.IBCTransfer) (err error)
+	// GetIBCTransfer returns the transfer of the packet, nil if it was not indexed.
+	GetIBCTransfer(ctx context.Context, chainID, direction, sourcePort, sourceChannel string, sequence int) (transfer *types.IBCTransfer, err error)
+	// GetIBCTransfers returns the transfers sent or received by the address, the status is optional.
+	GetIBCTransfers(ctx context.Context, chainID, address string, status *string) (transfers []*types.IBCTransfer, err error)
+
+	/*
+		Staking
+	*/
+
+	// GetStakingDelegatorTxs returns the staking and distribution msgs of the delegator.
+	GetStakingDelegatorTxs(ctx context.Context, chainID, delegator string) (txs []*types.IndexedTx, err error)
+	// GetStakingValidatorTxs returns the staking and distribution msgs that involve the validator.
+	GetStakingValidatorTxs(ctx context.Context, chainID, validator string) (txs []*types.IndexedTx, err error)
+	// GetStakingUnbondings returns the undelegations of the delegator, the completion time filter is optional.
+	GetStakingUnbondings(ctx context.Context, chainID, delegator string, completesAfterUnix *int) (txs []*types.IndexedTx, err error)
+
+	/*
+		Gov
+	*/
+
+	// StoreGovProposal merges the proposal update with the stored proposal and stores the tx if informed.
+	StoreGovProposal(ctx context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, proposal types.GovProposal) (err error)
+	// GetGovProposal returns the proposal, nil if it was not indexed.
+	GetGovProposal(ctx context.Context, chainID string, proposalID int) (proposal *types.GovProposal, err error)
+	// GetGovProposals returns the proposals ordered by id, the status is optional.
+	GetGovProposals(ctx context.Context, chainID string, status *string) (proposals []*types.GovProposal, err error)
+	// GetGovProposalTxs returns the msgs of the proposal filtered by the proto msg names.
+	GetGovProposalTxs(ctx context.Context, chainID string, proposalID int, protoMsgNames ...string) (txs []*types.IndexedTx, err error)
+	// GetGovVoterHistory returns the votes of the voter in all the proposals.
+	GetGovVoterHistory(ctx context.Context, chainID, voter string) (txs []*types.IndexedTx, err error)
+
+	/*
+		Leverage
+	*/
+
+	// StoreTokenRegistryChanges stores the token registry changes and the tx if informed.
+	StoreTokenRegistryChanges(ctx context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, changes []*types.TokenRegistryChange) (err error)
+	// GetTokenRegistryChanges returns the registry changes of the denom ordered by block height.
+	GetTokenRegistryChanges(ctx context.Context, chainID, denom string) (changes []*types.TokenRegistryChange, err error)
+
+	/*
+		Bank
+	*/
+
+	// GetBankSends returns the MsgSend and MsgMultiSend sent or received by the address.
+	GetBankSends(ctx context.Context, chainID, address string) (txs []*types.IndexedTx, err error)
+	// StoreBalanceChanges stores the balance changes of a block.
+	StoreBalanceChanges(ctx context.Context, chainInfo types.ChainInfo, changes []types.BalanceChange) (err error)
+	// GetBalanceChanges returns a page of the balance changes of the address ordered from the most recent,
+	// the denom, time interval and cursor are optional.
+	GetBalanceChanges(ctx context.Context, chainID, address string, denom *string, fromTimeUnix, toTimeUnix *int, limit int, cursor *string) (page *types.BalanceChangePage, err error)
+
+	/*
+		Dead Letters
+	*/
+
+	// StoreDeadLetter stores the failure to be reprocessed later, the attempts of the same failure are added up.
+	StoreDeadLetter(ctx context.Context, chainID string, letter types.DeadLetter) (err error)
+	// GetDeadLetters returns the dead letters ordered by block height, the handler is optional.
+	GetDeadLetters(ctx context.Context, chainID string, handler *string) (letters []*types.DeadLetter, err error)
+	// DeleteDeadLetter removes the dead letter after it was reprocessed.
+	DeleteDeadLetter(ctx context.Context, chainID, id string) (err error)
+}
+
+// Config selects the database backend and its settings.
+type Config struct {
+	Backend  TypeDB          `yaml:"backend" toml:"backend"`
+	Firebase firebase.Config `yaml:"firebase" toml:"firebase"`
 }
 
-// NewDB returns a new database instance based on the specified type.
+// NewDB returns a new database instance based on the backend of the config.
 // It returns an error if the database type is unsupported.
-func NewDB(typeDB TypeDB, ctx context.Context, logger zerolog.Logger) (Database, error) {
-	switch typeDB {
+func NewDB(cfg Config, ctx context.Context, logger zerolog.Logger) (Database, error) {
+	switch cfg.Backend {
 	case Firebase:
-		return loadFirebase(ctx, logger)
+		return loadFirebase(ctx, cfg.Firebase, logger)
+	case Memory:
+		return memory.New(logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %v", cfg.Backend)
+	}
+}
+
+// String returns the name of the database type.
+func (t TypeDB) String() string {
+	switch t {
+	case Firebase:
+		return "firebase"
+	case Memory:
+		return "memory"
+	default:
+		return fmt.Sprintf("TypeDB(%d)", uint8(t))
+	}
+}
+
+// MarshalText encodes the database type by its name.
+func (t TypeDB) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes the database type from its name.
+func (t *TypeDB) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseTypeDB(string(text))
+	return err
+}
+
+// ParseTypeDB returns the database type by its name.
+func ParseTypeDB(name string) (TypeDB, error) {
+	switch strings.ToLower(name) {
+	case "firebase":
+		return Firebase, nil
+	case "memory":
+		return Memory, nil
 	default:
-		return nil, fmt.Errorf("unsupported database type: %v", typeDB)
+		return 0, fmt.Errorf("unknown database %q, it must be firebase or memory", name)
 	}
 }
 
-// loadFirebase checks if there is env set for emulator, if it is loads firebase without credentials
+// loadFirebase loads firebase without credentials if the config has an emulator host,
 // otherwise it looks for credentials to run
-func loadFirebase(ctx context.Context, logger zerolog.Logger) (Database, error) {
-	firebaseEmulator := os.Getenv(firebase.EnvFirebaseEmulator)
-	if len(firebaseEmulator) > 0 {
-		fmt.Printf(
-			"\nEnv %s detected as %s, API running on firestore emulator",
-			firebase.EnvFirebaseEmulator,
-			firebaseEmulator,
-		)
-		return firebase.New(ctx, logger)
+func loadFirebase(ctx context.Context, cfg firebase.Config, logger zerolog.Logger) (Database, error) {
+	if cfg.EmulatorHost != "" {
+		// the firestore client only reads the emulator host from the env.
+		if err := os.Setenv(firebase.EnvFirebaseEmulator, cfg.EmulatorHost); err != nil {
+			return nil, err
+		}
+		logger.Info().Str("host", cfg.EmulatorHost).Msg("running on firestore emulator")
+		return firebase.New(ctx, cfg.ProjectID, logger)
 	}
 
-	opt, err := firebase.LoadCredential()
+	opt, err := firebase.LoadCredential(cfg)
 	if err != nil {
 		return nil, errors.Join(err, errors.New("Failed to load Firebase credentials"))
 	}
 
-	db, err := firebase.New(ctx, logger, opt)
+	db, err := firebase.New(ctx, cfg.ProjectID, logger, opt)
 	if err != nil {
 		return nil, errors.Join(err, errors.New("failed to initialize Firebase database"))
 	}
@@ -19,6 +19,43 @@ func (db *Database) UpsertChainInfo(ctx context.Context, info types.ChainInfo) (
 	return err
 }
 
+// UpdateChainHead updates the last block received of the chain info, keeping the intervals indexed stored.
+func (db *Database) UpdateChainHead(ctx context.Context, info types.ChainInfo) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return updateChainInfo(tctx, info.ChainID, func(stored *types.ChainInfo) error {
+				stored.UpdateLastBlockReceived(info.LastBlockHeightReceived, info.LastBlockTimeUnixReceived)
+				return nil
+			})
+		},
+	)
+	return err
+}
+
+// IndexBlockRange updates the last block received of the chain info and adds the block heights to the
+// intervals indexed stored of the msgs, or of all the cosmos msgs if none is given.
+func (db *Database) IndexBlockRange(ctx context.Context, info types.ChainInfo, msgNames []string, fromHeight, toHeight int) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return indexBlockRange(tctx, info, msgNames, fromHeight, toHeight)
+		},
+	)
+	return err
+}
+
+// UnindexBlockRange removes the block heights from the intervals indexed stored of the msg.
+func (db *Database) UnindexBlockRange(ctx context.Context, chainID, msgName string, fromHeight, toHeight int) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return unindexBlockRange(tctx, chainID, msgName, fromHeight, toHeight)
+		},
+	)
+	return err
+}
+
 // GetChainInfo returns the last chainInfo.
 func (db *Database) GetChainInfo(ctx context.Context, chainID string) (info *types.ChainInfo, err error) {
 	err = db.RunTransaction(
@@ -31,8 +68,30 @@ func (db *Database) GetChainInfo(ctx context.Context, chainID string) (info *typ
 	return info, err
 }
 
-// StoreMsgLiquidate stores a new msgliquidate updating the CosmosMsgIndexed.
-func (db *Database) StoreMsgLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, msg types.MsgLiquidate) (err error) {
+// GetSchemaVersion returns the schema version of the docs stored of the chain, zero if it was never migrated.
+func (db *Database) GetSchemaVersion(ctx context.Context, chainID string) (version int, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			version, err = getSchemaVersion(tctx, chainID)
+			return err
+		},
+	)
+	return version, err
+}
+
+// SetSchemaVersion stores the schema version of the docs of the chain.
+func (db *Database) SetSchemaVersion(ctx context.Context, chainID string, version int) (err error) {
+	return db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return setSchemaVersion(tctx, chainID, version)
+		},
+	)
+}
+
+// StoreMsgLiquidate stores a new msgliquidate.
+func (db *Database) StoreMsgLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLiquidate) (err error) {
 	err = db.RunTransaction(
 		ctx, func(ctx context.Context, t *firestore.Transaction) error {
 			tctx := txctx.Now(ctx, t, db.Fs)
@@ -41,35 +100,40 @@ func (db *Database) StoreMsgLiquidate(ctx context.Context, chainInfo types.Chain
 				ProtoMsgName:  types.MsgNameLiquidate,
 				BlockHeight:   blockHeight,
 				BlockTimeUnix: blockTimeUnix,
+				Execution:     exec,
 				MsgLiquidate:  &msg,
 			})
-			if err != nil {
-				return err
-			}
-
-			return upsertChainInfo(tctx, chainInfo)
+			return err
 		},
 	)
 	return err
 }
 
-// StoreMsgLeverageLiquidate stores a new MsgLeverageLiquidate updating the CosmosMsgIndexed.
-func (db *Database) StoreMsgLeverageLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, msg types.MsgLeverageLiquidate) (err error) {
+// StoreMsgLeverageLiquidate stores a new MsgLeverageLiquidate.
+func (db *Database) StoreMsgLeverageLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLeverageLiquidate) (err error) {
 	err = db.RunTransaction(
 		ctx, func(ctx context.Context, t *firestore.Transaction) error {
 			tctx := txctx.Now(ctx, t, db.Fs)
 			err = addTx(tctx, chainInfo.ChainID, types.IndexedTx{
 				TxHash:               txHash,
-				ProtoMsgName:         types.MsgNameLiquidate,
+				ProtoMsgName:         types.MsgNameLeveragedLiquidate,
 				BlockHeight:          blockHeight,
 				BlockTimeUnix:        blockTimeUnix,
+				Execution:            exec,
 				MsgLeverageLiquidate: &msg,
 			})
-			if err != nil {
-				return err
-			}
+			return err
+		},
+	)
+	return err
+}
 
-			return upsertChainInfo(tctx, chainInfo)
+// StoreTx stores a new indexed tx.
+func (db *Database) StoreTx(ctx context.Context, chainInfo types.ChainInfo, tx types.IndexedTx) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addTx(tctx, chainInfo.ChainID, tx)
 		},
 	)
 	return err
@@ -87,3 +151,533 @@ func (db *Database) GetLiquidateMsgs(ctx context.Context, chainID string, borrow
 	)
 	return txs, err
 }
+
+// GetGranteeMsgs returns the msgs executed by the grantee on behalf of other accounts.
+func (db *Database) GetGranteeMsgs(ctx context.Context, chainID, grantee string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, grantee, []string{"execution", "grantee"})
+			return err
+		},
+	)
+	return txs, err
+}
+
+// StoreOracleVotes stores the oracle votes and misses, increasing the validators performance by slash window.
+func (db *Database) StoreOracleVotes(ctx context.Context, chainInfo types.ChainInfo, votes []types.OracleValidatorVote) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addOracleVotes(tctx, chainInfo.ChainID, votes)
+		},
+	)
+	return err
+}
+
+// StoreMsgDelegateFeedConsent stores a new MsgDelegateFeedConsent.
+func (db *Database) StoreMsgDelegateFeedConsent(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgDelegateFeedConsent) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			err = addTx(tctx, chainInfo.ChainID, types.IndexedTx{
+				TxHash:                 txHash,
+				ProtoMsgName:           types.MsgNameDelegateFeedConsent,
+				BlockHeight:            blockHeight,
+				BlockTimeUnix:          blockTimeUnix,
+				Execution:              exec,
+				MsgDelegateFeedConsent: &msg,
+			})
+			return err
+		},
+	)
+	return err
+}
+
+// GetOracleValidatorPerformance returns the validator performance by slash window, if window is nil returns all of the windows.
+func (db *Database) GetOracleValidatorPerformance(ctx context.Context, chainID, valoper string, window *int) (perfs []*types.OracleValidatorPerformance, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			perfs, err = getOraclePerformances(tctx, chainID, valoper, window)
+			return err
+		},
+	)
+	return perfs, err
+}
+
+// GetFeederDelegations returns all the feeder delegations made by the validator operator.
+func (db *Database) GetFeederDelegations(ctx context.Context, chainID, valoper string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, valoper, []string{"msgDelegateFeedConsent", "operator"})
+			return err
+		},
+	)
+	return txs, err
+}
+
+// StoreIncentiveProgramSnapshots stores the state of the incentive programs at some block height.
+func (db *Database) StoreIncentiveProgramSnapshots(ctx context.Context, chainID string, snapshots []types.IncentiveProgramSnapshot) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addIncentiveProgramSnapshots(tctx, chainID, snapshots)
+		},
+	)
+	return err
+}
+
+// GetIncentiveAccountTxs returns all the incentive msgs sent by the account.
+func (db *Database) GetIncentiveAccountTxs(ctx context.Context, chainID, account string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, account,
+				[]string{"msgBond", "account"},
+				[]string{"msgBeginUnbonding", "account"},
+				[]string{"msgEmergencyUnbond", "account"},
+				[]string{"msgClaim", "account"},
+			)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetIncentiveProgramFundings returns the msgs sponsor of the program.
+func (db *Database) GetIncentiveProgramFundings(ctx context.Context, chainID string, programID int) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, programID, []string{"msgSponsor", "program"})
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetIncentiveProgramSnapshots returns the snapshots of the program ordered by block height.
+func (db *Database) GetIncentiveProgramSnapshots(ctx context.Context, chainID string, programID int) (snapshots []*types.IncentiveProgramSnapshot, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			snapshots, err = getIncentiveProgramSnapshots(tctx, chainID, programID)
+			return err
+		},
+	)
+	return snapshots, err
+}
+
+// StoreMetokenIndexSnapshots stores the balances of the meToken indexes at some block height.
+func (db *Database) StoreMetokenIndexSnapshots(ctx context.Context, chainID string, snapshots []types.MetokenIndexSnapshot) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addMetokenIndexSnapshots(tctx, chainID, snapshots)
+		},
+	)
+	return err
+}
+
+// GetMetokenUserTxs returns the swaps and redemptions made by the user.
+func (db *Database) GetMetokenUserTxs(ctx context.Context, chainID, user string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, user,
+				[]string{"msgSwap", "user"},
+				[]string{"msgRedeem", "user"},
+			)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetMetokenTxs returns the msgs (swap or redeem) of the asset in the meToken index, the time interval is optional.
+func (db *Database) GetMetokenTxs(ctx context.Context, chainID, protoMsgName, metokenDenom, assetDenom string, fromTimeUnix, toTimeUnix *int) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getMetokenTxs(tctx, chainID, protoMsgName, metokenDenom, assetDenom, fromTimeUnix, toTimeUnix)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetMetokenIndexSnapshots returns the snapshots of the meToken index ordered by block height, the time interval is optional.
+func (db *Database) GetMetokenIndexSnapshots(ctx context.Context, chainID, metokenDenom string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.MetokenIndexSnapshot, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			snapshots, err = getMetokenIndexSnapshots(tctx, chainID, metokenDenom, fromTimeUnix, toTimeUnix)
+			return err
+		},
+	)
+	return snapshots, err
+}
+
+// StoreUIBCEvents stores the uibc events.
+func (db *Database) StoreUIBCEvents(ctx context.Context, chainInfo types.ChainInfo, events []types.UIBCEvent) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addUIBCEvents(tctx, chainInfo.ChainID, events)
+		},
+	)
+	return err
+}
+
+// StoreUIBCOutflow adds the outflow to the quota window of the denom, only once by transfer.
+func (db *Database) StoreUIBCOutflow(ctx context.Context, chainInfo types.ChainInfo, outflow types.UIBCOutflowWindow, transfer types.UIBCOutflowTransfer) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addUIBCOutflow(tctx, chainInfo.ChainID, outflow, transfer)
+		},
+	)
+	return err
+}
+
+// StoreUIBCQuotaSnapshot stores the uibc quota state at some block height.
+func (db *Database) StoreUIBCQuotaSnapshot(ctx context.Context, chainID string, snapshot types.UIBCQuotaSnapshot) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return addUIBCQuotaSnapshot(tctx, chainID, snapshot)
+		},
+	)
+	return err
+}
+
+// GetUIBCGovTxs returns the governance msgs that changed the uibc quota and status.
+func (db *Database) GetUIBCGovTxs(ctx context.Context, chainID string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = queryTxs(tctx, collTxs(tctx, chainID).Where("protoMsgName", "in", []string{
+				types.MsgNameGovUpdateQuota, types.MsgNameGovSetIBCStatus,
+			}))
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetUIBCEvents returns the uibc events, the event type and time interval are optional.
+func (db *Database) GetUIBCEvents(ctx context.Context, chainID string, eventType *string, fromTimeUnix, toTimeUnix *int) (events []*types.UIBCEvent, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			events, err = getUIBCEvents(tctx, chainID, eventType, fromTimeUnix, toTimeUnix)
+			return err
+		},
+	)
+	return events, err
+}
+
+// GetUIBCOutflows returns the outflows by quota window, the denom and time interval are optional.
+func (db *Database) GetUIBCOutflows(ctx context.Context, chainID string, denom *string, fromTimeUnix, toTimeUnix *int) (outflows []*types.UIBCOutflowWindow, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			outflows, err = getUIBCOutflows(tctx, chainID, denom, fromTimeUnix, toTimeUnix)
+			return err
+		},
+	)
+	return outflows, err
+}
+
+// GetUIBCQuotaSnapshots returns the quota snapshots ordered by block height, the time interval is optional.
+func (db *Database) GetUIBCQuotaSnapshots(ctx context.Context, chainID string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.UIBCQuotaSnapshot, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			snapshots, err = getUIBCQuotaSnapshots(tctx, chainID, fromTimeUnix, toTimeUnix)
+			return err
+		},
+	)
+	return snapshots, err
+}
+
+// StoreIBCTransfer merges the transfer with the stored one of the same packet.
+func (db *Database) StoreIBCTransfer(ctx context.Context, chainInfo types.ChainInfo, transfer types.IBCTransfer) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return upsertIBCTransfer(tctx, chainInfo.ChainID, transfer)
+		},
+	)
+	return err
+}
+
+// GetIBCTransfer returns the transfer of the packet, nil if it was not indexed.
+func (db *Database) GetIBCTransfer(ctx context.Context, chainID, direction, sourcePort, sourceChannel string, sequence int) (transfer *types.IBCTransfer, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			transfer, err = getIBCTransfer(tctx, chainID, types.IBCTransferDocID(direction, sourcePort, sourceChannel, sequence))
+			return err
+		},
+	)
+	return transfer, err
+}
+
+// GetIBCTransfers returns the transfers sent or received by the address, the status is optional.
+func (db *Database) GetIBCTransfers(ctx context.Context, chainID, address string, status *string) (transfers []*types.IBCTransfer, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			transfers, err = getIBCTransfersByAddress(tctx, chainID, address, status)
+			return err
+		},
+	)
+	return transfers, err
+}
+
+// GetStakingDelegatorTxs returns the staking and distribution msgs of the delegator.
+func (db *Database) GetStakingDelegatorTxs(ctx context.Context, chainID, delegator string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, delegator,
+				[]string{"msgDelegate", "delegator"},
+				[]string{"msgUndelegate", "delegator"},
+				[]string{"msgBeginRedelegate", "delegator"},
+				[]string{"msgCancelUnbondingDelegation", "delegator"},
+				[]string{"msgWithdrawDelegatorReward", "delegator"},
+			)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetStakingValidatorTxs returns the staking and distribution msgs that involve the validator.
+func (db *Database) GetStakingValidatorTxs(ctx context.Context, chainID, validator string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, validator,
+				[]string{"msgDelegate", "validator"},
+				[]string{"msgUndelegate", "validator"},
+				[]string{"msgBeginRedelegate", "validatorSrc"},
+				[]string{"msgBeginRedelegate", "validatorDst"},
+				[]string{"msgCancelUnbondingDelegation", "validator"},
+				[]string{"msgWithdrawDelegatorReward", "validator"},
+				[]string{"msgWithdrawValidatorCommission", "validator"},
+			)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetStakingUnbondings returns the undelegations of the delegator, the completion time filter is optional.
+func (db *Database) GetStakingUnbondings(ctx context.Context, chainID, delegator string, completesAfterUnix *int) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getStakingUnbondings(tctx, chainID, delegator, completesAfterUnix)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// StoreGovProposal merges the proposal update with the stored proposal and stores the tx if informed.
+func (db *Database) StoreGovProposal(ctx context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, proposal types.GovProposal) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			// the proposal is read before any write as firestore transactions require.
+			if err := upsertGovProposal(tctx, chainInfo.ChainID, proposal); err != nil {
+				return err
+			}
+			if tx != nil {
+				if err := addTx(tctx, chainInfo.ChainID, *tx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+	return err
+}
+
+// GetGovProposal returns the proposal, nil if it was not indexed.
+func (db *Database) GetGovProposal(ctx context.Context, chainID string, proposalID int) (proposal *types.GovProposal, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			proposal, err = getGovProposal(tctx, chainID, proposalID)
+			return err
+		},
+	)
+	return proposal, err
+}
+
+// GetGovProposals returns the proposals ordered by id, the status is optional.
+func (db *Database) GetGovProposals(ctx context.Context, chainID string, status *string) (proposals []*types.GovProposal, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			proposals, err = getGovProposals(tctx, chainID, status)
+			return err
+		},
+	)
+	return proposals, err
+}
+
+// GetGovProposalTxs returns the msgs of the proposal filtered by the proto msg names.
+func (db *Database) GetGovProposalTxs(ctx context.Context, chainID string, proposalID int, protoMsgNames ...string) (txs []*types.IndexedTx, err error) {
+	paths := make([][]string, 0, len(protoMsgNames))
+	for _, msgName := range protoMsgNames {
+		switch msgName {
+		case types.MsgNameDeposit:
+			paths = append(paths, []string{"msgDeposit", "proposalID"})
+		case types.MsgNameVote:
+			paths = append(paths, []string{"msgVote", "proposalID"})
+		case types.MsgNameVoteWeighted:
+			paths = append(paths, []string{"msgVoteWeighted", "proposalID"})
+		}
+	}
+
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, proposalID, paths...)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// GetGovVoterHistory returns the votes of the voter in all the proposals.
+func (db *Database) GetGovVoterHistory(ctx context.Context, chainID, voter string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getTxsByFields(tctx, chainID, voter,
+				[]string{"msgVote", "voter"},
+				[]string{"msgVoteWeighted", "voter"},
+			)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// StoreTokenRegistryChanges stores the token registry changes and the tx if informed.
+func (db *Database) StoreTokenRegistryChanges(ctx context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, changes []*types.TokenRegistryChange) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			for _, change := range changes {
+				if err := addTokenRegistryChange(tctx, chainInfo.ChainID, *change); err != nil {
+					return err
+				}
+			}
+			if tx != nil {
+				if err := addTx(tctx, chainInfo.ChainID, *tx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+	return err
+}
+
+// GetTokenRegistryChanges returns the registry changes of the denom ordered by block height.
+func (db *Database) GetTokenRegistryChanges(ctx context.Context, chainID, denom string) (changes []*types.TokenRegistryChange, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			changes, err = getTokenRegistryChanges(tctx, chainID, denom)
+			return err
+		},
+	)
+	return changes, err
+}
+
+// GetBankSends returns the MsgSend and MsgMultiSend sent or received by the address.
+func (db *Database) GetBankSends(ctx context.Context, chainID, address string) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			txs, err = getBankSends(tctx, chainID, address)
+			return err
+		},
+	)
+	return txs, err
+}
+
+// StoreBalanceChanges stores the balance changes of a block.
+func (db *Database) StoreBalanceChanges(ctx context.Context, chainInfo types.ChainInfo, changes []types.BalanceChange) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			for _, change := range changes {
+				if err := addBalanceChange(tctx, chainInfo.ChainID, change); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+	return err
+}
+
+// GetBalanceChanges returns a page of the balance changes of the address ordered from the most recent,
+// the denom, time interval and cursor are optional.
+func (db *Database) GetBalanceChanges(ctx context.Context, chainID, address string, denom *string, fromTimeUnix, toTimeUnix *int, limit int, cursor *string) (page *types.BalanceChangePage, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			page, err = getBalanceChanges(tctx, chainID, address, denom, fromTimeUnix, toTimeUnix, limit, cursor)
+			return err
+		},
+	)
+	return page, err
+}
+
+// StoreDeadLetter stores the failure to be reprocessed later, the attempts of the same failure are added up.
+func (db *Database) StoreDeadLetter(ctx context.Context, chainID string, letter types.DeadLetter) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return upsertDeadLetter(tctx, chainID, letter)
+		},
+	)
+	return err
+}
+
+// GetDeadLetters returns the dead letters ordered by block height, the handler is optional.
+func (db *Database) GetDeadLetters(ctx context.Context, chainID string, handler *string) (letters []*types.DeadLetter, err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			letters, err = getDeadLetters(tctx, chainID, handler)
+			return err
+		},
+	)
+	return letters, err
+}
+
+// DeleteDeadLetter removes the dead letter after it was reprocessed.
+func (db *Database) DeleteDeadLetter(ctx context.Context, chainID, id string) (err error) {
+	err = db.RunTransaction(
+		ctx, func(ctx context.Context, t *firestore.Transaction) error {
+			tctx := txctx.Now(ctx, t, db.Fs)
+			return deleteDeadLetter(tctx, chainID, id)
+		},
+	)
+	return err
+}
@@ -0,0 +1,86 @@
+package firebase
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollBalanceChanges = "balance-changes"
+)
+
+// addBalanceChange sets the change, storing it again overwrites the same doc.
+func addBalanceChange(ctx txctx.TxContext, chainID string, change types.BalanceChange) (err error) {
+	return ctx.Set(collBalanceChanges(ctx, chainID).Doc(types.BalanceChangeDocID(change)), change)
+}
+
+// getBankSends returns the send msgs where the address is the sender or one of the receivers.
+func getBankSends(ctx txctx.TxContext, chainID, address string) (txs []*types.IndexedTx, err error) {
+	return queryTxs(ctx, collTxs(ctx, chainID).Query.WhereEntity(firestore.OrFilter{
+		Filters: []firestore.EntityFilter{
+			firestore.PropertyPathFilter{Path: []string{"msgSend", "fromAddress"}, Operator: "==", Value: address},
+			firestore.PropertyPathFilter{Path: []string{"msgSend", "toAddress"}, Operator: "==", Value: address},
+			firestore.PropertyPathFilter{Path: []string{"msgMultiSend", "addresses"}, Operator: "array-contains", Value: address},
+		},
+	}))
+}
+
+// getBalanceChanges returns a page of the balance changes of the address ordered from the most recent.
+// The cursor is the doc id of the last change of the previous page.
+func getBalanceChanges(ctx txctx.TxContext, chainID, address string, denom *string, fromTimeUnix, toTimeUnix *int, limit int, cursor *string) (page *types.BalanceChangePage, err error) {
+	coll := collBalanceChanges(ctx, chainID)
+	query := coll.Where("address", "==", address)
+	if denom != nil {
+		query = query.Where("denom", "==", *denom)
+	}
+	query = whereTimeUnix(query, "blockTimeUnix", fromTimeUnix, toTimeUnix).
+		OrderBy("blockTimeUnix", firestore.Desc).
+		OrderBy(firestore.DocumentID, firestore.Desc)
+
+	if cursor != nil {
+		last, err := ctx.Get(coll.Doc(*cursor))
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, fmt.Errorf("invalid balance changes cursor: %s", *cursor)
+			}
+			return nil, err
+		}
+		query = query.StartAfter(last)
+	}
+
+	page = &types.BalanceChangePage{Changes: make([]*types.BalanceChange, 0, limit)}
+	// one more change is read to know if there is a next page.
+	iter := query.Limit(limit + 1).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page.Changes) == limit {
+			nextCursor := types.BalanceChangeDocID(*page.Changes[limit-1])
+			page.NextCursor = &nextCursor
+			break
+		}
+
+		var change types.BalanceChange
+		if err := doc.DataTo(&change); err != nil {
+			return nil, err
+		}
+		page.Changes = append(page.Changes, &change)
+	}
+	return page, nil
+}
+
+func collBalanceChanges(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollBalanceChanges)
+}
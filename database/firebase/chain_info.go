@@ -1,6 +1,8 @@
 package firebase
 
 import (
+	"fmt"
+
 	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
 	"github.com/umee-network/umeed-indexer/graph/types"
 	"google.golang.org/grpc/codes"
@@ -32,3 +34,38 @@ func getChainInfo(ctx txctx.TxContext, chainID string) (info *types.ChainInfo, e
 	}
 	return info, nil
 }
+
+// updateChainInfo reads the chain info stored and writes it back updated inside of the same transaction,
+// so the intervals indexed by the other indexers of the chain are kept.
+func updateChainInfo(ctx txctx.TxContext, chainID string, update func(info *types.ChainInfo) error) (err error) {
+	info, err := getChainInfo(ctx, chainID)
+	if err != nil {
+		return err
+	}
+	info.MergeWithDefaults()
+	if err := update(info); err != nil {
+		return err
+	}
+	return upsertChainInfo(ctx, *info)
+}
+
+// indexBlockRange updates the last block received and adds the block heights to the intervals indexed of the msgs.
+func indexBlockRange(ctx txctx.TxContext, head types.ChainInfo, msgNames []string, fromHeight, toHeight int) (err error) {
+	return updateChainInfo(ctx, head.ChainID, func(info *types.ChainInfo) error {
+		info.UpdateLastBlockReceived(head.LastBlockHeightReceived, head.LastBlockTimeUnixReceived)
+		if !info.IndexBlockRange(msgNames, fromHeight, toHeight) {
+			return fmt.Errorf("msgs %v are not indexed", msgNames)
+		}
+		return nil
+	})
+}
+
+// unindexBlockRange removes the block heights from the intervals indexed of the msg.
+func unindexBlockRange(ctx txctx.TxContext, chainID, msgName string, fromHeight, toHeight int) (err error) {
+	return updateChainInfo(ctx, chainID, func(info *types.ChainInfo) error {
+		if !info.UnindexBlockRangeForMsg(msgName, fromHeight, toHeight) {
+			return fmt.Errorf("msg %s is not indexed", msgName)
+		}
+		return nil
+	})
+}
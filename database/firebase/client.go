@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -27,6 +28,16 @@ const (
 	EnvFirebaseProjectID       = "FIREBASE_PROJECT_ID"
 )
 
+// Config is the firebase project and its credentials.
+type Config struct {
+	ProjectID string `yaml:"projectID" toml:"projectID"`
+	// CredentialsJSON is the service account key, it is read from the CredentialsFile if empty.
+	CredentialsJSON string `yaml:"credentialsJSON" toml:"credentialsJSON"`
+	CredentialsFile string `yaml:"credentialsFile" toml:"credentialsFile"`
+	// EmulatorHost is the host of the firestore emulator, it does not need credentials.
+	EmulatorHost string `yaml:"emulatorHost" toml:"emulatorHost"`
+}
+
 // Database stores the information needed to call getters and setters on firebase.
 type Database struct {
 	// App is the primary Firebase app instance.
@@ -40,11 +51,9 @@ type Database struct {
 }
 
 // New returns a new firebase struture.
-func New(ctx context.Context, logger zerolog.Logger, opts ...option.ClientOption) (db *Database, err error) {
-	cfg := loadConfig()
-
+func New(ctx context.Context, projectID string, logger zerolog.Logger, opts ...option.ClientOption) (db *Database, err error) {
 	// Use a service account
-	app, err := firebase.NewApp(ctx, cfg, opts...)
+	app, err := firebase.NewApp(ctx, &firebase.Config{ProjectID: projectID}, opts...)
 	if err != nil {
 		return nil, errors.Join(err, errors.New("Failed to initialize Firebase app"))
 	}
@@ -77,12 +86,22 @@ func (db *Database) Close() error {
 }
 
 // RunTransaction run a transaction, all reads must come first than any write in db.
+// Its latency and retries are recorded by the name of the method that runs it.
 func (db *Database) RunTransaction(
 	ctx context.Context,
 	f func(context.Context, *firestore.Transaction) error,
 	opts ...firestore.TransactionOption,
 ) (err error) {
-	return db.Fs.RunTransaction(ctx, f, opts...)
+	operation, attempts := callerOperation(), 0
+	defer func(start time.Time) { observeOperation(operation, start, err) }(time.Now())
+
+	return db.Fs.RunTransaction(ctx, func(ctx context.Context, t *firestore.Transaction) error {
+		// firestore runs the function again if the transaction conflicts with another one.
+		if attempts++; attempts > 1 {
+			transactionRetries.WithLabelValues(operation).Inc()
+		}
+		return f(ctx, t)
+	}, opts...)
 }
 
 // DeleteAll inside the database.
@@ -193,42 +212,16 @@ func (db *Database) DeleteCollection(ctx context.Context, coll *firestore.Collec
 	return nil
 }
 
-// LoadCredential checks if there is the env of an config full path or
-// if it doesn't exists, tries to load from an json base64 encoded.
-func LoadCredential() (option.ClientOption, error) {
-	var (
-		credentials []byte
-		err         error
-	)
-
-	// Try to get credentials from environment variable first
-	credentialsFromEnv := os.Getenv(EnvFirebaseCredentialsJSON)
-	if credentialsFromEnv != "" {
-		credentials = []byte(credentialsFromEnv)
-	} else {
-		// If not found in environment, read from firebase.json
-		credentials, err = os.ReadFile("firebase.json")
-		if err != nil {
-			fmt.Printf("Failed to read firebase.json and no %s found: %v", EnvFirebaseCredentialsJSON, err)
-			return nil, errors.Join(err, errors.New("Failed to read firebase.json"))
-		}
+// LoadCredential returns the credentials JSON of the config or, if it is empty,
+// the ones read from its credentials file.
+func LoadCredential(cfg Config) (option.ClientOption, error) {
+	if cfg.CredentialsJSON != "" {
+		return option.WithCredentialsJSON([]byte(cfg.CredentialsJSON)), nil
 	}
 
-	return option.WithCredentialsJSON(credentials), nil
-}
-
-// loadConfig returns the firebase config
-func loadConfig() *firebase.Config {
-	return &firebase.Config{
-		ProjectID: getEnvOrDefault(EnvFirebaseProjectID, "umee-testnet-alpha"),
-	}
-}
-
-// getEnvOrDefault loads the env variable, if it is empty, returns the default.
-func getEnvOrDefault(envVarName, defaultForEnv string) string {
-	evnValue := os.Getenv(envVarName)
-	if len(evnValue) == 0 {
-		return defaultForEnv
+	credentials, err := os.ReadFile(cfg.CredentialsFile)
+	if err != nil {
+		return nil, errors.Join(err, fmt.Errorf("Failed to read %s and no %s found", cfg.CredentialsFile, EnvFirebaseCredentialsJSON))
 	}
-	return evnValue
+	return option.WithCredentialsJSON(credentials), nil
 }
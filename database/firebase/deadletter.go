@@ -0,0 +1,67 @@
+package firebase
+
+import (
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollDeadLetters = "dead-letters"
+)
+
+// upsertDeadLetter stores the failure, adding up the attempts of the same failure already stored.
+func upsertDeadLetter(ctx txctx.TxContext, chainID string, letter types.DeadLetter) (err error) {
+	docRef := collDeadLetters(ctx, chainID).Doc(letter.ID)
+	var stored *types.DeadLetter
+	doc, err := ctx.Get(docRef)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	if err == nil {
+		if err := doc.DataTo(&stored); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Set(docRef, types.MergeDeadLetter(stored, letter))
+}
+
+// getDeadLetters returns the dead letters ordered by block height, filtered by the handler if informed.
+func getDeadLetters(ctx txctx.TxContext, chainID string, handler *string) (letters []*types.DeadLetter, err error) {
+	query := collDeadLetters(ctx, chainID).Query
+	if handler != nil {
+		query = query.Where("handler", "==", *handler)
+	}
+
+	letters = make([]*types.DeadLetter, 0)
+	iter := query.OrderBy("blockHeight", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return letters, err
+		}
+
+		var letter types.DeadLetter
+		if err := doc.DataTo(&letter); err != nil {
+			return nil, err
+		}
+		letters = append(letters, &letter)
+	}
+	return letters, nil
+}
+
+// deleteDeadLetter removes the dead letter after it was reprocessed.
+func deleteDeadLetter(ctx txctx.TxContext, chainID, id string) (err error) {
+	return ctx.Delete(collDeadLetters(ctx, chainID).Doc(id))
+}
+
+func collDeadLetters(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollDeadLetters)
+}
@@ -0,0 +1,54 @@
+package firebase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// ForEachDoc calls f with the docs of the collection of the chain in the id order, decoded into the
+// values returned by newDoc. Only the docs after the id are read if it is not empty. The docs are read
+// outside of a transaction as they can be too many for one.
+func (db *Database) ForEachDoc(ctx context.Context, chainID, collName, afterID string, newDoc func() any, f func(id string, doc any) error) (err error) {
+	coll := db.Fs.Collection(CollChain).Doc(chainID).Collection(collName)
+	query := coll.OrderBy(firestore.DocumentID, firestore.Asc)
+	if afterID != "" {
+		query = query.StartAfter(coll.Doc(afterID))
+	}
+	return forEachDoc(ctx, query, func(doc *firestore.DocumentSnapshot) error {
+		v := newDoc()
+		if err := doc.DataTo(v); err != nil {
+			return err
+		}
+		return f(doc.Ref.ID, v)
+	})
+}
+
+// ImportDocs sets the docs of the collection of the chain by id, overwriting the stored ones.
+func (db *Database) ImportDocs(ctx context.Context, chainID, collName string, docs map[string]any) (err error) {
+	defer func(start time.Time) { observeOperation("ImportDocs", start, err) }(time.Now())
+	if len(docs) == 0 {
+		return nil
+	}
+
+	coll := db.Fs.Collection(CollChain).Doc(chainID).Collection(collName)
+	bulkWriter := db.Fs.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(docs))
+	for id, doc := range docs {
+		job, err := bulkWriter.Set(coll.Doc(id), doc)
+		if err != nil {
+			bulkWriter.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	bulkWriter.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package firebase
+
+import (
+	"strconv"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollGovProposals = "gov-proposals"
+)
+
+// upsertGovProposal merges the proposal update with the one already stored.
+func upsertGovProposal(ctx txctx.TxContext, chainID string, update types.GovProposal) (err error) {
+	stored, err := getGovProposal(ctx, chainID, update.ProposalID)
+	if err != nil {
+		return err
+	}
+
+	merged, err := types.MergeGovProposal(stored, update)
+	if err != nil {
+		return err
+	}
+	return ctx.Set(collGovProposals(ctx, chainID).Doc(strconv.Itoa(update.ProposalID)), merged)
+}
+
+// getGovProposal returns nil if the proposal doc doesn't exist.
+func getGovProposal(ctx txctx.TxContext, chainID string, proposalID int) (proposal *types.GovProposal, err error) {
+	doc, err := ctx.Get(collGovProposals(ctx, chainID).Doc(strconv.Itoa(proposalID)))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err = doc.DataTo(&proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// getGovProposals returns the proposals ordered by id.
+func getGovProposals(ctx txctx.TxContext, chainID string, proposalStatus *string) (proposals []*types.GovProposal, err error) {
+	query := collGovProposals(ctx, chainID).Query
+	if proposalStatus != nil {
+		query = query.Where("status", "==", *proposalStatus)
+	}
+
+	proposals = make([]*types.GovProposal, 0)
+	iter := query.OrderBy("proposalID", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return proposals, err
+		}
+
+		var proposal types.GovProposal
+		if err := doc.DataTo(&proposal); err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, &proposal)
+	}
+	return proposals, nil
+}
+
+func collGovProposals(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollGovProposals)
+}
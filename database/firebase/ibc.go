@@ -0,0 +1,78 @@
+package firebase
+
+import (
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollIBCTransfers = "ibc-transfers"
+)
+
+// upsertIBCTransfer merges the transfer with the one already stored for the same packet.
+func upsertIBCTransfer(ctx txctx.TxContext, chainID string, transfer types.IBCTransfer) (err error) {
+	docID := types.IBCTransferDocID(transfer.Direction, transfer.SourcePort, transfer.SourceChannel, transfer.Sequence)
+	stored, err := getIBCTransfer(ctx, chainID, docID)
+	if err != nil {
+		return err
+	}
+
+	merged := types.MergeIBCTransfer(stored, transfer)
+	return ctx.Set(collIBCTransfers(ctx, chainID).Doc(docID), merged)
+}
+
+// getIBCTransfer returns nil if the transfer doc doesn't exist.
+func getIBCTransfer(ctx txctx.TxContext, chainID, docID string) (transfer *types.IBCTransfer, err error) {
+	doc, err := ctx.Get(collIBCTransfers(ctx, chainID).Doc(docID))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err = doc.DataTo(&transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// getIBCTransfersByAddress returns the transfers sent or received by the address ordered by the last update.
+func getIBCTransfersByAddress(ctx txctx.TxContext, chainID, address string, transferStatus *string) (transfers []*types.IBCTransfer, err error) {
+	var filter firestore.EntityFilter = firestore.OrFilter{Filters: []firestore.EntityFilter{
+		firestore.PropertyFilter{Path: "sender", Operator: "==", Value: address},
+		firestore.PropertyFilter{Path: "receiver", Operator: "==", Value: address},
+	}}
+	if transferStatus != nil {
+		filter = firestore.AndFilter{Filters: []firestore.EntityFilter{
+			filter,
+			firestore.PropertyFilter{Path: "status", Operator: "==", Value: *transferStatus},
+		}}
+	}
+
+	transfers = make([]*types.IBCTransfer, 0)
+	iter := collIBCTransfers(ctx, chainID).WhereEntity(filter).OrderBy("lastBlockTimeUnix", firestore.Desc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return transfers, err
+		}
+
+		var transfer types.IBCTransfer
+		if err := doc.DataTo(&transfer); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &transfer)
+	}
+	return transfers, nil
+}
+
+func collIBCTransfers(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollIBCTransfers)
+}
@@ -0,0 +1,55 @@
+package firebase
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	CollIncentiveProgramSnapshots = "incentive-program-snapshots"
+)
+
+// addIncentiveProgramSnapshots sets the snapshots, one doc by program and block height.
+func addIncentiveProgramSnapshots(ctx txctx.TxContext, chainID string, snapshots []types.IncentiveProgramSnapshot) (err error) {
+	for _, snapshot := range snapshots {
+		docID := fmt.Sprintf("%d-%d", snapshot.ProgramID, snapshot.BlockHeight)
+		if err := ctx.Set(collIncentiveProgramSnapshots(ctx, chainID).Doc(docID), snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getIncentiveProgramSnapshots returns the snapshots of the program ordered by block height.
+func getIncentiveProgramSnapshots(ctx txctx.TxContext, chainID string, programID int) (snapshots []*types.IncentiveProgramSnapshot, err error) {
+	query := collIncentiveProgramSnapshots(ctx, chainID).
+		Where("programID", "==", programID).
+		OrderBy("blockHeight", firestore.Asc)
+
+	snapshots = make([]*types.IncentiveProgramSnapshot, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return snapshots, err
+		}
+
+		var snapshot types.IncentiveProgramSnapshot
+		if err := doc.DataTo(&snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots, nil
+}
+
+func collIncentiveProgramSnapshots(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollIncentiveProgramSnapshots)
+}
@@ -0,0 +1,45 @@
+package firebase
+
+import (
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	CollLeverageRegistryChanges = "leverage-registry-changes"
+)
+
+// addTokenRegistryChange sets the change, storing it again overwrites the same doc.
+func addTokenRegistryChange(ctx txctx.TxContext, chainID string, change types.TokenRegistryChange) (err error) {
+	return ctx.Set(collLeverageRegistryChanges(ctx, chainID).Doc(types.TokenRegistryChangeDocID(change)), change)
+}
+
+// getTokenRegistryChanges returns the registry changes of the denom ordered by block height.
+func getTokenRegistryChanges(ctx txctx.TxContext, chainID, denom string) (changes []*types.TokenRegistryChange, err error) {
+	query := collLeverageRegistryChanges(ctx, chainID).Where("denom", "==", denom).OrderBy("blockHeight", firestore.Asc)
+
+	changes = make([]*types.TokenRegistryChange, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return changes, err
+		}
+
+		var change types.TokenRegistryChange
+		if err := doc.DataTo(&change); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+	return changes, nil
+}
+
+func collLeverageRegistryChanges(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollLeverageRegistryChanges)
+}
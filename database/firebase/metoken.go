@@ -0,0 +1,76 @@
+package firebase
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	CollMetokenIndexSnapshots = "metoken-index-snapshots"
+)
+
+// addMetokenIndexSnapshots sets the snapshots, one doc by meToken denom and block height.
+func addMetokenIndexSnapshots(ctx txctx.TxContext, chainID string, snapshots []types.MetokenIndexSnapshot) (err error) {
+	for _, snapshot := range snapshots {
+		docID := fmt.Sprintf("%s-%d", snapshot.MetokenDenom, snapshot.BlockHeight)
+		if err := ctx.Set(collMetokenIndexSnapshots(ctx, chainID).Doc(docID), snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getMetokenTxs returns the swaps or redemptions of the asset in the meToken index.
+func getMetokenTxs(ctx txctx.TxContext, chainID, protoMsgName, metokenDenom, assetDenom string, fromTimeUnix, toTimeUnix *int) (txs []*types.IndexedTx, err error) {
+	msgField := "msgSwap"
+	if protoMsgName == types.MsgNameRedeem {
+		msgField = "msgRedeem"
+	}
+
+	query := collTxs(ctx, chainID).
+		Where("protoMsgName", "==", protoMsgName).
+		WherePath([]string{msgField, "metokenDenom"}, "==", metokenDenom).
+		WherePath([]string{msgField, "assetDenom"}, "==", assetDenom)
+	return queryTxs(ctx, whereTimeUnix(query, "blockTimeUnix", fromTimeUnix, toTimeUnix))
+}
+
+// getMetokenIndexSnapshots returns the snapshots of the meToken index ordered by block height.
+func getMetokenIndexSnapshots(ctx txctx.TxContext, chainID, metokenDenom string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.MetokenIndexSnapshot, err error) {
+	query := collMetokenIndexSnapshots(ctx, chainID).Where("metokenDenom", "==", metokenDenom)
+	query = whereTimeUnix(query, "blockTimeUnix", fromTimeUnix, toTimeUnix).OrderBy("blockTimeUnix", firestore.Asc)
+
+	snapshots = make([]*types.MetokenIndexSnapshot, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return snapshots, err
+		}
+
+		var snapshot types.MetokenIndexSnapshot
+		if err := doc.DataTo(&snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots, nil
+}
+
+// whereTimeUnix filters the query by the time interval, nil values are not filtered.
+func whereTimeUnix(query firestore.Query, field string, fromTimeUnix, toTimeUnix *int) firestore.Query {
+	for _, cond := range types.TimeUnixConditions(fromTimeUnix, toTimeUnix) {
+		query = query.Where(field, cond.Op, cond.Value)
+	}
+	return query
+}
+
+func collMetokenIndexSnapshots(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollMetokenIndexSnapshots)
+}
@@ -0,0 +1,57 @@
+package firebase
+
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "firebase",
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of the database operations by the method that runs them.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"operation"})
+	operationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "firebase",
+		Name:      "operation_errors_total",
+		Help:      "Amount of database operations that failed by the method that runs them.",
+	}, []string{"operation"})
+	transactionRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "firebase",
+		Name:      "transaction_retries_total",
+		Help:      "Amount of times the transactions were retried after a contention by the method that runs them.",
+	}, []string{"operation"})
+)
+
+// observeOperation records the latency of the operation since the start and if it failed.
+func observeOperation(operation string, start time.Time, err error) {
+	operationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		operationErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// callerOperation returns the name of the method or function that called the caller of callerOperation,
+// ex.: StoreTx for the transactions run by (*Database).StoreTx, including the ones run by its closures.
+func callerOperation() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	// github.com/umee-network/umeed-indexer/database/firebase.(*Database).StoreTx.func1
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name[strings.Index(name, ".")+1:], "(*Database).")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
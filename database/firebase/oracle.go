@@ -0,0 +1,142 @@
+package firebase
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollOracleVotes       = "oracle-votes"
+	CollOraclePerformance = "oracle-performance"
+)
+
+// addOracleVotes adds the votes and increases the performance counters of each validator, the votes
+// already stored for the validator in the vote period are skipped so they are counted once.
+// All the reads happen before the writes as firestore transactions require.
+func addOracleVotes(ctx txctx.TxContext, chainID string, votes []types.OracleValidatorVote) (err error) {
+	newVotes := make([]types.OracleValidatorVote, 0, len(votes))
+	seen := make(map[string]struct{}, len(votes))
+	for _, vote := range votes {
+		voteID := types.OracleVoteDocID(vote)
+		if _, ok := seen[voteID]; ok {
+			continue
+		}
+		seen[voteID] = struct{}{}
+
+		_, err := ctx.Get(collOracleVotes(ctx, chainID).Doc(voteID))
+		if err == nil { // already added
+			continue
+		}
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		newVotes = append(newVotes, vote)
+	}
+
+	perfByDoc := make(map[string]*types.OracleValidatorPerformance)
+	for _, vote := range newVotes {
+		docID := oraclePerformanceDocID(vote.Validator, vote.SlashWindow)
+		if _, ok := perfByDoc[docID]; ok {
+			continue
+		}
+
+		perf, err := getOraclePerformance(ctx, chainID, docID)
+		if err != nil {
+			return err
+		}
+		if perf == nil {
+			perf = &types.OracleValidatorPerformance{
+				Validator:   vote.Validator,
+				SlashWindow: vote.SlashWindow,
+			}
+		}
+		perfByDoc[docID] = perf
+	}
+
+	for _, vote := range newVotes {
+		perf := perfByDoc[oraclePerformanceDocID(vote.Validator, vote.SlashWindow)]
+		if vote.Voted {
+			perf.Votes++
+		} else {
+			perf.Misses++
+		}
+		// keeps track of the lowest and highest block height seen inside the slash window.
+		if perf.WindowFromBlockHeight == 0 || vote.BlockHeight < perf.WindowFromBlockHeight {
+			perf.WindowFromBlockHeight = vote.BlockHeight
+		}
+		if vote.BlockHeight > perf.WindowToBlockHeight {
+			perf.WindowToBlockHeight = vote.BlockHeight
+		}
+
+		if err := ctx.Set(collOracleVotes(ctx, chainID).Doc(types.OracleVoteDocID(vote)), vote); err != nil {
+			return err
+		}
+	}
+
+	for docID, perf := range perfByDoc {
+		if err := ctx.Set(collOraclePerformance(ctx, chainID).Doc(docID), perf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOraclePerformance returns nil if the performance doc doesn't exist yet.
+func getOraclePerformance(ctx txctx.TxContext, chainID, docID string) (perf *types.OracleValidatorPerformance, err error) {
+	doc, err := ctx.Get(collOraclePerformance(ctx, chainID).Doc(docID))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err = doc.DataTo(&perf); err != nil {
+		return nil, err
+	}
+	return perf, nil
+}
+
+// getOraclePerformances returns the performance of the validator, filtered by the window if it is set.
+func getOraclePerformances(ctx txctx.TxContext, chainID, valoper string, window *int) (perfs []*types.OracleValidatorPerformance, err error) {
+	query := collOraclePerformance(ctx, chainID).Where("validator", "==", valoper)
+	if window != nil {
+		query = query.Where("slashWindow", "==", *window)
+	}
+
+	perfs = make([]*types.OracleValidatorPerformance, 0)
+	iter := query.OrderBy("slashWindow", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return perfs, err
+		}
+
+		var perf types.OracleValidatorPerformance
+		if err := doc.DataTo(&perf); err != nil {
+			return nil, err
+		}
+		perfs = append(perfs, &perf)
+	}
+	return perfs, nil
+}
+
+func oraclePerformanceDocID(valoper string, slashWindow int) string {
+	return fmt.Sprintf("%s-%d", valoper, slashWindow)
+}
+
+func collOracleVotes(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollOracleVotes)
+}
+
+func collOraclePerformance(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollOraclePerformance)
+}
@@ -0,0 +1,211 @@
+package firebase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+)
+
+// DeleteMsgRecords deletes the records stored by the handler of the msg and its dead letters between
+// the block heights (inclusive). The records can be too many for a single transaction, so it must not
+// run while the indexer is indexing the same chain.
+func (db *Database) DeleteMsgRecords(ctx context.Context, chainID, protoMsgName string, fromHeight, toHeight int) (deleted int, err error) {
+	defer func(start time.Time) { observeOperation("DeleteMsgRecords", start, err) }(time.Now())
+	chainDoc := db.Fs.Collection(CollChain).Doc(chainID)
+	inRange := func(query firestore.Query) firestore.Query {
+		return query.Where("blockHeight", ">=", fromHeight).Where("blockHeight", "<=", toHeight)
+	}
+
+	var refs []*firestore.DocumentRef
+	txsQuery := inRange(chainDoc.Collection(CollTransactions).Query)
+	if name := types.StoredTxMsgName(protoMsgName); name != "" {
+		txsQuery = txsQuery.Where("protoMsgName", "==", name)
+	}
+	err = forEachDoc(ctx, txsQuery, func(doc *firestore.DocumentSnapshot) error {
+		var tx types.IndexedTx
+		if err := doc.DataTo(&tx); err != nil {
+			return err
+		}
+		if types.IsTxOfMsg(tx, protoMsgName) {
+			refs = append(refs, doc.Ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = forEachDoc(ctx, inRange(chainDoc.Collection(CollDeadLetters).Query), func(doc *firestore.DocumentSnapshot) error {
+		var letter types.DeadLetter
+		if err := doc.DataTo(&letter); err != nil {
+			return err
+		}
+		if types.IsDeadLetterOfMsg(letter, protoMsgName) {
+			refs = append(refs, doc.Ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	switch protoMsgName {
+	case types.MsgNameAggregateExchangeRateVote:
+		voteRefs, err := db.revertOracleVotes(ctx, chainID, inRange(chainDoc.Collection(CollOracleVotes).Query))
+		if err != nil {
+			return 0, err
+		}
+		refs = append(refs, voteRefs...)
+	case types.MsgNameBalanceChanges:
+		err = forEachDoc(ctx, inRange(chainDoc.Collection(CollBalanceChanges).Query), func(doc *firestore.DocumentSnapshot) error {
+			refs = append(refs, doc.Ref)
+			return nil
+		})
+	case types.MsgNameGovUpdateRegistry, types.MsgNameGovUpdateSpecialAssets:
+		err = forEachDoc(ctx, inRange(chainDoc.Collection(CollLeverageRegistryChanges).Query), func(doc *firestore.DocumentSnapshot) error {
+			var change types.TokenRegistryChange
+			if err := doc.DataTo(&change); err != nil {
+				return err
+			}
+			if types.IsRegistryChangeOfMsg(change, protoMsgName) {
+				refs = append(refs, doc.Ref)
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return db.deleteDocs(ctx, refs)
+}
+
+// GetBlockRecords returns the records stored by block between the block heights (inclusive), read
+// outside of a transaction as the range can be too large for one.
+func (db *Database) GetBlockRecords(ctx context.Context, chainID string, fromHeight, toHeight int) (records *types.BlockRecords, err error) {
+	defer func(start time.Time) { observeOperation("GetBlockRecords", start, err) }(time.Now())
+	chainDoc := db.Fs.Collection(CollChain).Doc(chainID)
+	inRange := func(collName string) firestore.Query {
+		return chainDoc.Collection(collName).Where("blockHeight", ">=", fromHeight).Where("blockHeight", "<=", toHeight)
+	}
+
+	records = &types.BlockRecords{}
+	if records.Txs, err = decodeDocs[types.IndexedTx](ctx, inRange(CollTransactions)); err != nil {
+		return nil, err
+	}
+	if records.OracleVotes, err = decodeDocs[types.OracleValidatorVote](ctx, inRange(CollOracleVotes)); err != nil {
+		return nil, err
+	}
+	if records.BalanceChanges, err = decodeDocs[types.BalanceChange](ctx, inRange(CollBalanceChanges)); err != nil {
+		return nil, err
+	}
+	if records.UIBCEvents, err = decodeDocs[types.UIBCEvent](ctx, inRange(CollUIBCEvents)); err != nil {
+		return nil, err
+	}
+	if records.TokenRegistryChanges, err = decodeDocs[types.TokenRegistryChange](ctx, inRange(CollLeverageRegistryChanges)); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// revertOracleVotes decreases the validators performance by the votes found by the query, returning
+// the votes to be deleted.
+func (db *Database) revertOracleVotes(ctx context.Context, chainID string, query firestore.Query) (refs []*firestore.DocumentRef, err error) {
+	votesByPerf := make(map[string][]types.OracleValidatorVote)
+	err = forEachDoc(ctx, query, func(doc *firestore.DocumentSnapshot) error {
+		var vote types.OracleValidatorVote
+		if err := doc.DataTo(&vote); err != nil {
+			return err
+		}
+		docID := oraclePerformanceDocID(vote.Validator, vote.SlashWindow)
+		votesByPerf[docID] = append(votesByPerf[docID], vote)
+		refs = append(refs, doc.Ref)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for docID, votes := range votesByPerf {
+		err = db.RunTransaction(
+			ctx, func(ctx context.Context, t *firestore.Transaction) error {
+				tctx := txctx.Now(ctx, t, db.Fs)
+				perf, err := getOraclePerformance(tctx, chainID, docID)
+				if err != nil || perf == nil {
+					return err
+				}
+				for _, vote := range votes {
+					types.RevertOracleVote(perf, vote)
+				}
+				return tctx.Set(collOraclePerformance(tctx, chainID).Doc(docID), perf)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// deleteDocs deletes the docs in bulk, returning how many were deleted.
+func (db *Database) deleteDocs(ctx context.Context, refs []*firestore.DocumentRef) (deleted int, err error) {
+	if len(refs) == 0 {
+		return 0, nil
+	}
+
+	bulkWriter := db.Fs.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(refs))
+	for _, ref := range refs {
+		job, err := bulkWriter.Delete(ref)
+		if err != nil {
+			bulkWriter.End()
+			return deleted, err
+		}
+		jobs = append(jobs, job)
+	}
+	bulkWriter.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// decodeDocs returns all the docs found by the query.
+func decodeDocs[T any](ctx context.Context, query firestore.Query) (values []*T, err error) {
+	values = make([]*T, 0)
+	err = forEachDoc(ctx, query, func(doc *firestore.DocumentSnapshot) error {
+		var v T
+		if err := doc.DataTo(&v); err != nil {
+			return err
+		}
+		values = append(values, &v)
+		return nil
+	})
+	return values, err
+}
+
+// forEachDoc calls f for every doc found by the query.
+func forEachDoc(ctx context.Context, query firestore.Query, f func(doc *firestore.DocumentSnapshot) error) error {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(doc); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package firebase
+
+import (
+	"cloud.google.com/go/firestore"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollSchema = "schema"
+	// DocSchemaVersion is the id of the doc that keeps the schema version of the chain.
+	DocSchemaVersion = "version"
+)
+
+// getSchemaVersion returns zero if the chain was never migrated.
+func getSchemaVersion(ctx txctx.TxContext, chainID string) (version int, err error) {
+	doc, err := ctx.Get(collSchema(ctx, chainID).Doc(DocSchemaVersion))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var schema types.SchemaVersion
+	if err := doc.DataTo(&schema); err != nil {
+		return 0, err
+	}
+	return schema.Version, nil
+}
+
+func setSchemaVersion(ctx txctx.TxContext, chainID string, version int) error {
+	return ctx.Set(collSchema(ctx, chainID).Doc(DocSchemaVersion), types.SchemaVersion{
+		Version:          version,
+		MigratedTimeUnix: ctx.UnixTime(),
+	})
+}
+
+func collSchema(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollSchema)
+}
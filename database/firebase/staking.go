@@ -0,0 +1,17 @@
+package firebase
+
+import (
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// getStakingUnbondings returns the undelegations of the delegator that complete after the given time.
+func getStakingUnbondings(ctx txctx.TxContext, chainID, delegator string, completesAfterUnix *int) (txs []*types.IndexedTx, err error) {
+	query := collTxs(ctx, chainID).
+		Where("protoMsgName", "==", types.MsgNameUndelegate).
+		WherePath([]string{"msgUndelegate", "delegator"}, "==", delegator)
+	if completesAfterUnix != nil {
+		query = query.WherePath([]string{"msgUndelegate", "completionTimeUnix"}, ">", *completesAfterUnix)
+	}
+	return queryTxs(ctx, query)
+}
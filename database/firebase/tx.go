@@ -52,3 +52,40 @@ func getTxLiquidade(ctx txctx.TxContext, chainID, borrower string) (txs []*types
 func collTxs(ctx txctx.TxContext, chainID string) (collTxs *firestore.CollectionRef) {
 	return ctx.Collection(CollChain).Doc(chainID).Collection(CollTransactions)
 }
+
+// getTxsByFields returns the txs that have any of the fields in the given paths equal to the value.
+func getTxsByFields(ctx txctx.TxContext, chainID string, value any, paths ...[]string) (txs []*types.IndexedTx, err error) {
+	filters := make([]firestore.EntityFilter, len(paths))
+	for i, path := range paths {
+		filters[i] = firestore.PropertyPathFilter{Path: path, Operator: "==", Value: value}
+	}
+
+	var filter firestore.EntityFilter = firestore.OrFilter{Filters: filters}
+	if len(filters) == 1 {
+		filter = filters[0]
+	}
+
+	return queryTxs(ctx, collTxs(ctx, chainID).Query.WhereEntity(filter))
+}
+
+// queryTxs returns all the txs found by the query.
+func queryTxs(ctx txctx.TxContext, query firestore.Query) (txs []*types.IndexedTx, err error) {
+	txs = make([]*types.IndexedTx, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return txs, err
+		}
+
+		var tx types.IndexedTx
+		if err := doc.DataTo(&tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, &tx)
+	}
+	return txs, nil
+}
@@ -0,0 +1,175 @@
+package firebase
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	sdkmath "cosmossdk.io/math"
+	txctx "github.com/umee-network/umeed-indexer/database/firebase/context"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollUIBCEvents           = "uibc-events"
+	CollUIBCOutflows         = "uibc-outflows"
+	CollUIBCOutflowTransfers = "uibc-outflow-transfers"
+	CollUIBCQuotaSnapshots   = "uibc-quota-snapshots"
+)
+
+// addUIBCEvents sets the events, one doc by tx hash, event type and position in the tx.
+func addUIBCEvents(ctx txctx.TxContext, chainID string, events []types.UIBCEvent) (err error) {
+	for idx, evt := range events {
+		docID := fmt.Sprintf("%s-%s-%d", evt.TxHash, evt.EventType, idx)
+		if err := ctx.Set(collUIBCEvents(ctx, chainID).Doc(docID), evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addUIBCOutflow increases the amount and the number of transfers of the denom in the quota window, the
+// transfers already added are skipped so handling the same tx again does not count it twice.
+func addUIBCOutflow(ctx txctx.TxContext, chainID string, outflow types.UIBCOutflowWindow, transfer types.UIBCOutflowTransfer) (err error) {
+	transferRef := collUIBCOutflowTransfers(ctx, chainID).Doc(types.UIBCOutflowTransferDocID(transfer))
+	_, err = ctx.Get(transferRef)
+	if err == nil { // already added
+		return nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return err
+	}
+
+	docRef := collUIBCOutflows(ctx, chainID).Doc(fmt.Sprintf("%s-%d", outflow.Denom, outflow.Window))
+	doc, err := ctx.Get(docRef)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+
+	if err == nil {
+		var stored types.UIBCOutflowWindow
+		if err := doc.DataTo(&stored); err != nil {
+			return err
+		}
+
+		storedAmount, ok := sdkmath.NewIntFromString(stored.Amount)
+		if !ok {
+			return fmt.Errorf("invalid stored outflow amount %q", stored.Amount)
+		}
+		amount, ok := sdkmath.NewIntFromString(outflow.Amount)
+		if !ok {
+			return fmt.Errorf("invalid outflow amount %q", outflow.Amount)
+		}
+		outflow.Amount = storedAmount.Add(amount).String()
+		outflow.Transfers += stored.Transfers
+	}
+
+	if err := ctx.Set(docRef, outflow); err != nil {
+		return err
+	}
+	return ctx.Set(transferRef, transfer)
+}
+
+// addUIBCQuotaSnapshot sets the snapshot, one doc by block height.
+func addUIBCQuotaSnapshot(ctx txctx.TxContext, chainID string, snapshot types.UIBCQuotaSnapshot) (err error) {
+	return ctx.Set(collUIBCQuotaSnapshots(ctx, chainID).Doc(fmt.Sprintf("%d", snapshot.BlockHeight)), snapshot)
+}
+
+// getUIBCEvents returns the uibc events ordered by block time.
+func getUIBCEvents(ctx txctx.TxContext, chainID string, eventType *string, fromTimeUnix, toTimeUnix *int) (events []*types.UIBCEvent, err error) {
+	query := collUIBCEvents(ctx, chainID).Query
+	if eventType != nil {
+		query = query.Where("eventType", "==", *eventType)
+	}
+	query = whereTimeUnix(query, "blockTimeUnix", fromTimeUnix, toTimeUnix).OrderBy("blockTimeUnix", firestore.Asc)
+
+	events = make([]*types.UIBCEvent, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return events, err
+		}
+
+		var evt types.UIBCEvent
+		if err := doc.DataTo(&evt); err != nil {
+			return nil, err
+		}
+		events = append(events, &evt)
+	}
+	return events, nil
+}
+
+// getUIBCOutflows returns the outflows by quota window ordered by the window start.
+func getUIBCOutflows(ctx txctx.TxContext, chainID string, denom *string, fromTimeUnix, toTimeUnix *int) (outflows []*types.UIBCOutflowWindow, err error) {
+	query := collUIBCOutflows(ctx, chainID).Query
+	if denom != nil {
+		query = query.Where("denom", "==", *denom)
+	}
+	query = whereTimeUnix(query, "windowFromTimeUnix", fromTimeUnix, toTimeUnix).OrderBy("windowFromTimeUnix", firestore.Asc)
+
+	outflows = make([]*types.UIBCOutflowWindow, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return outflows, err
+		}
+
+		var outflow types.UIBCOutflowWindow
+		if err := doc.DataTo(&outflow); err != nil {
+			return nil, err
+		}
+		outflows = append(outflows, &outflow)
+	}
+	return outflows, nil
+}
+
+// getUIBCQuotaSnapshots returns the quota snapshots ordered by block time.
+func getUIBCQuotaSnapshots(ctx txctx.TxContext, chainID string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.UIBCQuotaSnapshot, err error) {
+	query := whereTimeUnix(collUIBCQuotaSnapshots(ctx, chainID).Query, "blockTimeUnix", fromTimeUnix, toTimeUnix).
+		OrderBy("blockTimeUnix", firestore.Asc)
+
+	snapshots = make([]*types.UIBCQuotaSnapshot, 0)
+	iter := query.Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return snapshots, err
+		}
+
+		var snapshot types.UIBCQuotaSnapshot
+		if err := doc.DataTo(&snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots, nil
+}
+
+func collUIBCEvents(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollUIBCEvents)
+}
+
+func collUIBCOutflows(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollUIBCOutflows)
+}
+
+func collUIBCOutflowTransfers(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollUIBCOutflowTransfers)
+}
+
+func collUIBCQuotaSnapshots(ctx txctx.TxContext, chainID string) *firestore.CollectionRef {
+	return ctx.Collection(CollChain).Doc(chainID).Collection(CollUIBCQuotaSnapshots)
+}
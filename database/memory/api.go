@@ -0,0 +1,801 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// UpsertChainInfo updates or inserts a chain info structure.
+func (db *Database) UpsertChainInfo(_ context.Context, info types.ChainInfo) (err error) {
+	return db.RunTransaction(func() error {
+		return db.chains.set(info.ChainID, info)
+	})
+}
+
+// UpdateChainHead updates the last block received of the chain info, keeping the intervals indexed stored.
+func (db *Database) UpdateChainHead(_ context.Context, info types.ChainInfo) (err error) {
+	return db.RunTransaction(func() error {
+		return db.updateChainInfo(info.ChainID, func(stored *types.ChainInfo) error {
+			stored.UpdateLastBlockReceived(info.LastBlockHeightReceived, info.LastBlockTimeUnixReceived)
+			return nil
+		})
+	})
+}
+
+// IndexBlockRange updates the last block received of the chain info and adds the block heights to the
+// intervals indexed stored of the msgs, or of all the cosmos msgs if none is given.
+func (db *Database) IndexBlockRange(_ context.Context, info types.ChainInfo, msgNames []string, fromHeight, toHeight int) (err error) {
+	return db.RunTransaction(func() error {
+		return db.updateChainInfo(info.ChainID, func(stored *types.ChainInfo) error {
+			stored.UpdateLastBlockReceived(info.LastBlockHeightReceived, info.LastBlockTimeUnixReceived)
+			if !stored.IndexBlockRange(msgNames, fromHeight, toHeight) {
+				return fmt.Errorf("msgs %v are not indexed", msgNames)
+			}
+			return nil
+		})
+	})
+}
+
+// UnindexBlockRange removes the block heights from the intervals indexed stored of the msg.
+func (db *Database) UnindexBlockRange(_ context.Context, chainID, msgName string, fromHeight, toHeight int) (err error) {
+	return db.RunTransaction(func() error {
+		return db.updateChainInfo(chainID, func(stored *types.ChainInfo) error {
+			if !stored.UnindexBlockRangeForMsg(msgName, fromHeight, toHeight) {
+				return fmt.Errorf("msg %s is not indexed", msgName)
+			}
+			return nil
+		})
+	})
+}
+
+// GetChainInfo returns the last chainInfo.
+func (db *Database) GetChainInfo(_ context.Context, chainID string) (info *types.ChainInfo, err error) {
+	err = db.RunTransaction(func() error {
+		info, err = db.getChainInfo(chainID)
+		return err
+	})
+	return info, err
+}
+
+// GetSchemaVersion returns the schema version of the docs stored of the chain, zero if it was never migrated.
+func (db *Database) GetSchemaVersion(_ context.Context, chainID string) (version int, err error) {
+	err = db.RunTransaction(func() error {
+		var schema types.SchemaVersion
+		_, err := db.coll(chainID, firebase.CollSchema).get(firebase.DocSchemaVersion, &schema)
+		version = schema.Version
+		return err
+	})
+	return version, err
+}
+
+// SetSchemaVersion stores the schema version of the docs of the chain.
+func (db *Database) SetSchemaVersion(_ context.Context, chainID string, version int) (err error) {
+	return db.RunTransaction(func() error {
+		return db.coll(chainID, firebase.CollSchema).set(firebase.DocSchemaVersion, types.SchemaVersion{
+			Version:          version,
+			MigratedTimeUnix: int(time.Now().Unix()),
+		})
+	})
+}
+
+// StoreMsgLiquidate stores a new msgliquidate.
+func (db *Database) StoreMsgLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLiquidate) (err error) {
+	return db.StoreTx(ctx, chainInfo, types.IndexedTx{
+		TxHash:        txHash,
+		ProtoMsgName:  types.MsgNameLiquidate,
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+		Execution:     exec,
+		MsgLiquidate:  &msg,
+	})
+}
+
+// StoreMsgLeverageLiquidate stores a new MsgLeverageLiquidate.
+func (db *Database) StoreMsgLeverageLiquidate(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgLeverageLiquidate) (err error) {
+	return db.StoreTx(ctx, chainInfo, types.IndexedTx{
+		TxHash:               txHash,
+		ProtoMsgName:         types.MsgNameLeveragedLiquidate,
+		BlockHeight:          blockHeight,
+		BlockTimeUnix:        blockTimeUnix,
+		Execution:            exec,
+		MsgLeverageLiquidate: &msg,
+	})
+}
+
+// StoreTx stores a new indexed tx.
+func (db *Database) StoreTx(_ context.Context, chainInfo types.ChainInfo, tx types.IndexedTx) (err error) {
+	return db.RunTransaction(func() error {
+		return db.coll(chainInfo.ChainID, firebase.CollTransactions).add(tx)
+	})
+}
+
+// GetLiquidateMsgs returns all the msgs liquidate filtering by the borrower.
+func (db *Database) GetLiquidateMsgs(_ context.Context, chainID string, borrower string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgLiquidate.borrower", "==", borrower),
+		where("msgLeverageLiquidate.borrower", "==", borrower),
+	))
+}
+
+// GetGranteeMsgs returns the msgs executed by the grantee on behalf of other accounts.
+func (db *Database) GetGranteeMsgs(_ context.Context, chainID, grantee string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, where("execution.grantee", "==", grantee))
+}
+
+// StoreOracleVotes stores the oracle votes and misses, increasing the validators performance by slash window.
+// The votes already stored for the validator in the vote period are skipped.
+func (db *Database) StoreOracleVotes(_ context.Context, chainInfo types.ChainInfo, votes []types.OracleValidatorVote) (err error) {
+	return db.RunTransaction(func() error {
+		collVotes := db.coll(chainInfo.ChainID, firebase.CollOracleVotes)
+		collPerf := db.coll(chainInfo.ChainID, firebase.CollOraclePerformance)
+		for _, vote := range votes {
+			voteID := types.OracleVoteDocID(vote)
+			var stored types.OracleValidatorVote
+			found, err := collVotes.get(voteID, &stored)
+			if err != nil {
+				return err
+			}
+			if found {
+				continue
+			}
+
+			docID := fmt.Sprintf("%s-%d", vote.Validator, vote.SlashWindow)
+			perf := types.OracleValidatorPerformance{
+				Validator:   vote.Validator,
+				SlashWindow: vote.SlashWindow,
+			}
+			if _, err := collPerf.get(docID, &perf); err != nil {
+				return err
+			}
+
+			if vote.Voted {
+				perf.Votes++
+			} else {
+				perf.Misses++
+			}
+			if perf.WindowFromBlockHeight == 0 || vote.BlockHeight < perf.WindowFromBlockHeight {
+				perf.WindowFromBlockHeight = vote.BlockHeight
+			}
+			if vote.BlockHeight > perf.WindowToBlockHeight {
+				perf.WindowToBlockHeight = vote.BlockHeight
+			}
+
+			if err := collVotes.set(voteID, vote); err != nil {
+				return err
+			}
+			if err := collPerf.set(docID, perf); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StoreMsgDelegateFeedConsent stores a new MsgDelegateFeedConsent.
+func (db *Database) StoreMsgDelegateFeedConsent(ctx context.Context, chainInfo types.ChainInfo, blockHeight, blockTimeUnix int, txHash string, exec *types.MsgExecution, msg types.MsgDelegateFeedConsent) (err error) {
+	return db.StoreTx(ctx, chainInfo, types.IndexedTx{
+		TxHash:                 txHash,
+		ProtoMsgName:           types.MsgNameDelegateFeedConsent,
+		BlockHeight:            blockHeight,
+		BlockTimeUnix:          blockTimeUnix,
+		Execution:              exec,
+		MsgDelegateFeedConsent: &msg,
+	})
+}
+
+// GetOracleValidatorPerformance returns the validator performance by slash window, if window is nil returns all of the windows.
+func (db *Database) GetOracleValidatorPerformance(_ context.Context, chainID, valoper string, window *int) (perfs []*types.OracleValidatorPerformance, err error) {
+	filters := []filter{where("validator", "==", valoper)}
+	if window != nil {
+		filters = append(filters, where("slashWindow", "==", *window))
+	}
+	return query[types.OracleValidatorPerformance](db, chainID, firebase.CollOraclePerformance, "slashWindow", false, filters...)
+}
+
+// GetFeederDelegations returns all the feeder delegations made by the validator operator.
+func (db *Database) GetFeederDelegations(_ context.Context, chainID, valoper string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, where("msgDelegateFeedConsent.operator", "==", valoper))
+}
+
+// StoreIncentiveProgramSnapshots stores the state of the incentive programs at some block height.
+func (db *Database) StoreIncentiveProgramSnapshots(_ context.Context, chainID string, snapshots []types.IncentiveProgramSnapshot) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainID, firebase.CollIncentiveProgramSnapshots)
+		for _, snapshot := range snapshots {
+			if err := coll.set(fmt.Sprintf("%d-%d", snapshot.ProgramID, snapshot.BlockHeight), snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetIncentiveAccountTxs returns all the incentive msgs sent by the account.
+func (db *Database) GetIncentiveAccountTxs(_ context.Context, chainID, account string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgBond.account", "==", account),
+		where("msgBeginUnbonding.account", "==", account),
+		where("msgEmergencyUnbond.account", "==", account),
+		where("msgClaim.account", "==", account),
+	))
+}
+
+// GetIncentiveProgramFundings returns the msgs sponsor of the program.
+func (db *Database) GetIncentiveProgramFundings(_ context.Context, chainID string, programID int) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, where("msgSponsor.program", "==", programID))
+}
+
+// GetIncentiveProgramSnapshots returns the snapshots of the program ordered by block height.
+func (db *Database) GetIncentiveProgramSnapshots(_ context.Context, chainID string, programID int) (snapshots []*types.IncentiveProgramSnapshot, err error) {
+	return query[types.IncentiveProgramSnapshot](db, chainID, firebase.CollIncentiveProgramSnapshots, "blockHeight", false,
+		where("programID", "==", programID))
+}
+
+// StoreMetokenIndexSnapshots stores the balances of the meToken indexes at some block height.
+func (db *Database) StoreMetokenIndexSnapshots(_ context.Context, chainID string, snapshots []types.MetokenIndexSnapshot) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainID, firebase.CollMetokenIndexSnapshots)
+		for _, snapshot := range snapshots {
+			if err := coll.set(fmt.Sprintf("%s-%d", snapshot.MetokenDenom, snapshot.BlockHeight), snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetMetokenUserTxs returns the swaps and redemptions made by the user.
+func (db *Database) GetMetokenUserTxs(_ context.Context, chainID, user string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgSwap.user", "==", user),
+		where("msgRedeem.user", "==", user),
+	))
+}
+
+// GetMetokenTxs returns the msgs (swap or redeem) of the asset in the meToken index, the time interval is optional.
+func (db *Database) GetMetokenTxs(_ context.Context, chainID, protoMsgName, metokenDenom, assetDenom string, fromTimeUnix, toTimeUnix *int) (txs []*types.IndexedTx, err error) {
+	msgField := "msgSwap"
+	if protoMsgName == types.MsgNameRedeem {
+		msgField = "msgRedeem"
+	}
+
+	filters := append([]filter{
+		where("protoMsgName", "==", protoMsgName),
+		where(msgField+".metokenDenom", "==", metokenDenom),
+		where(msgField+".assetDenom", "==", assetDenom),
+	}, whereTimeUnix("blockTimeUnix", fromTimeUnix, toTimeUnix)...)
+	return db.getTxs(chainID, filters...)
+}
+
+// GetMetokenIndexSnapshots returns the snapshots of the meToken index ordered by block height, the time interval is optional.
+func (db *Database) GetMetokenIndexSnapshots(_ context.Context, chainID, metokenDenom string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.MetokenIndexSnapshot, err error) {
+	filters := append([]filter{where("metokenDenom", "==", metokenDenom)}, whereTimeUnix("blockTimeUnix", fromTimeUnix, toTimeUnix)...)
+	return query[types.MetokenIndexSnapshot](db, chainID, firebase.CollMetokenIndexSnapshots, "blockTimeUnix", false, filters...)
+}
+
+// StoreUIBCEvents stores the uibc events.
+func (db *Database) StoreUIBCEvents(_ context.Context, chainInfo types.ChainInfo, events []types.UIBCEvent) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainInfo.ChainID, firebase.CollUIBCEvents)
+		for idx, evt := range events {
+			if err := coll.set(fmt.Sprintf("%s-%s-%d", evt.TxHash, evt.EventType, idx), evt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StoreUIBCOutflow adds the outflow to the quota window of the denom, only once by transfer.
+func (db *Database) StoreUIBCOutflow(_ context.Context, chainInfo types.ChainInfo, outflow types.UIBCOutflowWindow, transfer types.UIBCOutflowTransfer) (err error) {
+	return db.RunTransaction(func() error {
+		transfers := db.coll(chainInfo.ChainID, firebase.CollUIBCOutflowTransfers)
+		transferID := types.UIBCOutflowTransferDocID(transfer)
+		if found, err := transfers.get(transferID, &types.UIBCOutflowTransfer{}); err != nil || found {
+			return err
+		}
+
+		coll := db.coll(chainInfo.ChainID, firebase.CollUIBCOutflows)
+		docID := fmt.Sprintf("%s-%d", outflow.Denom, outflow.Window)
+
+		var stored types.UIBCOutflowWindow
+		found, err := coll.get(docID, &stored)
+		if err != nil {
+			return err
+		}
+		if found {
+			storedAmount, ok := sdkmath.NewIntFromString(stored.Amount)
+			if !ok {
+				return fmt.Errorf("invalid stored outflow amount %q", stored.Amount)
+			}
+			amount, ok := sdkmath.NewIntFromString(outflow.Amount)
+			if !ok {
+				return fmt.Errorf("invalid outflow amount %q", outflow.Amount)
+			}
+			outflow.Amount = storedAmount.Add(amount).String()
+			outflow.Transfers += stored.Transfers
+		}
+
+		if err := coll.set(docID, outflow); err != nil {
+			return err
+		}
+		return transfers.set(transferID, transfer)
+	})
+}
+
+// StoreUIBCQuotaSnapshot stores the uibc quota state at some block height.
+func (db *Database) StoreUIBCQuotaSnapshot(_ context.Context, chainID string, snapshot types.UIBCQuotaSnapshot) (err error) {
+	return db.RunTransaction(func() error {
+		return db.coll(chainID, firebase.CollUIBCQuotaSnapshots).set(strconv.Itoa(snapshot.BlockHeight), snapshot)
+	})
+}
+
+// GetUIBCGovTxs returns the governance msgs that changed the uibc quota and status.
+func (db *Database) GetUIBCGovTxs(_ context.Context, chainID string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, where("protoMsgName", "in", []string{
+		types.MsgNameGovUpdateQuota, types.MsgNameGovSetIBCStatus,
+	}))
+}
+
+// GetUIBCEvents returns the uibc events, the event type and time interval are optional.
+func (db *Database) GetUIBCEvents(_ context.Context, chainID string, eventType *string, fromTimeUnix, toTimeUnix *int) (events []*types.UIBCEvent, err error) {
+	filters := whereTimeUnix("blockTimeUnix", fromTimeUnix, toTimeUnix)
+	if eventType != nil {
+		filters = append(filters, where("eventType", "==", *eventType))
+	}
+	return query[types.UIBCEvent](db, chainID, firebase.CollUIBCEvents, "blockTimeUnix", false, filters...)
+}
+
+// GetUIBCOutflows returns the outflows by quota window, the denom and time interval are optional.
+func (db *Database) GetUIBCOutflows(_ context.Context, chainID string, denom *string, fromTimeUnix, toTimeUnix *int) (outflows []*types.UIBCOutflowWindow, err error) {
+	filters := whereTimeUnix("windowFromTimeUnix", fromTimeUnix, toTimeUnix)
+	if denom != nil {
+		filters = append(filters, where("denom", "==", *denom))
+	}
+	return query[types.UIBCOutflowWindow](db, chainID, firebase.CollUIBCOutflows, "windowFromTimeUnix", false, filters...)
+}
+
+// GetUIBCQuotaSnapshots returns the quota snapshots ordered by block height, the time interval is optional.
+func (db *Database) GetUIBCQuotaSnapshots(_ context.Context, chainID string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.UIBCQuotaSnapshot, err error) {
+	return query[types.UIBCQuotaSnapshot](db, chainID, firebase.CollUIBCQuotaSnapshots, "blockTimeUnix", false,
+		whereTimeUnix("blockTimeUnix", fromTimeUnix, toTimeUnix)...)
+}
+
+// StoreIBCTransfer merges the transfer with the stored one of the same packet.
+func (db *Database) StoreIBCTransfer(_ context.Context, chainInfo types.ChainInfo, transfer types.IBCTransfer) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainInfo.ChainID, firebase.CollIBCTransfers)
+		docID := types.IBCTransferDocID(transfer.Direction, transfer.SourcePort, transfer.SourceChannel, transfer.Sequence)
+
+		var stored *types.IBCTransfer
+		if _, err := coll.get(docID, &stored); err != nil {
+			return err
+		}
+		return coll.set(docID, types.MergeIBCTransfer(stored, transfer))
+	})
+}
+
+// GetIBCTransfer returns the transfer of the packet, nil if it was not indexed.
+func (db *Database) GetIBCTransfer(_ context.Context, chainID, direction, sourcePort, sourceChannel string, sequence int) (transfer *types.IBCTransfer, err error) {
+	err = db.RunTransaction(func() error {
+		_, err := db.coll(chainID, firebase.CollIBCTransfers).get(types.IBCTransferDocID(direction, sourcePort, sourceChannel, sequence), &transfer)
+		return err
+	})
+	return transfer, err
+}
+
+// GetIBCTransfers returns the transfers sent or received by the address, the status is optional.
+func (db *Database) GetIBCTransfers(_ context.Context, chainID, address string, status *string) (transfers []*types.IBCTransfer, err error) {
+	filters := []filter{or(where("sender", "==", address), where("receiver", "==", address))}
+	if status != nil {
+		filters = append(filters, where("status", "==", *status))
+	}
+	return query[types.IBCTransfer](db, chainID, firebase.CollIBCTransfers, "lastBlockTimeUnix", true, filters...)
+}
+
+// GetStakingDelegatorTxs returns the staking and distribution msgs of the delegator.
+func (db *Database) GetStakingDelegatorTxs(_ context.Context, chainID, delegator string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgDelegate.delegator", "==", delegator),
+		where("msgUndelegate.delegator", "==", delegator),
+		where("msgBeginRedelegate.delegator", "==", delegator),
+		where("msgCancelUnbondingDelegation.delegator", "==", delegator),
+		where("msgWithdrawDelegatorReward.delegator", "==", delegator),
+	))
+}
+
+// GetStakingValidatorTxs returns the staking and distribution msgs that involve the validator.
+func (db *Database) GetStakingValidatorTxs(_ context.Context, chainID, validator string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgDelegate.validator", "==", validator),
+		where("msgUndelegate.validator", "==", validator),
+		where("msgBeginRedelegate.validatorSrc", "==", validator),
+		where("msgBeginRedelegate.validatorDst", "==", validator),
+		where("msgCancelUnbondingDelegation.validator", "==", validator),
+		where("msgWithdrawDelegatorReward.validator", "==", validator),
+		where("msgWithdrawValidatorCommission.validator", "==", validator),
+	))
+}
+
+// GetStakingUnbondings returns the undelegations of the delegator, the completion time filter is optional.
+func (db *Database) GetStakingUnbondings(_ context.Context, chainID, delegator string, completesAfterUnix *int) (txs []*types.IndexedTx, err error) {
+	filters := []filter{
+		where("protoMsgName", "==", types.MsgNameUndelegate),
+		where("msgUndelegate.delegator", "==", delegator),
+	}
+	if completesAfterUnix != nil {
+		filters = append(filters, where("msgUndelegate.completionTimeUnix", ">", *completesAfterUnix))
+	}
+	return db.getTxs(chainID, filters...)
+}
+
+// StoreGovProposal merges the proposal update with the stored proposal and stores the tx if informed.
+func (db *Database) StoreGovProposal(_ context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, proposal types.GovProposal) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainInfo.ChainID, firebase.CollGovProposals)
+		docID := strconv.Itoa(proposal.ProposalID)
+
+		var stored *types.GovProposal
+		if _, err := coll.get(docID, &stored); err != nil {
+			return err
+		}
+		merged, err := types.MergeGovProposal(stored, proposal)
+		if err != nil {
+			return err
+		}
+		if err := coll.set(docID, merged); err != nil {
+			return err
+		}
+
+		if tx != nil {
+			if err := db.coll(chainInfo.ChainID, firebase.CollTransactions).add(*tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetGovProposal returns the proposal, nil if it was not indexed.
+func (db *Database) GetGovProposal(_ context.Context, chainID string, proposalID int) (proposal *types.GovProposal, err error) {
+	err = db.RunTransaction(func() error {
+		_, err := db.coll(chainID, firebase.CollGovProposals).get(strconv.Itoa(proposalID), &proposal)
+		return err
+	})
+	return proposal, err
+}
+
+// GetGovProposals returns the proposals ordered by id, the status is optional.
+func (db *Database) GetGovProposals(_ context.Context, chainID string, status *string) (proposals []*types.GovProposal, err error) {
+	var filters []filter
+	if status != nil {
+		filters = append(filters, where("status", "==", *status))
+	}
+	return query[types.GovProposal](db, chainID, firebase.CollGovProposals, "proposalID", false, filters...)
+}
+
+// GetGovProposalTxs returns the msgs of the proposal filtered by the proto msg names.
+func (db *Database) GetGovProposalTxs(_ context.Context, chainID string, proposalID int, protoMsgNames ...string) (txs []*types.IndexedTx, err error) {
+	filters := make([]filter, 0, len(protoMsgNames))
+	for _, msgName := range protoMsgNames {
+		switch msgName {
+		case types.MsgNameDeposit:
+			filters = append(filters, where("msgDeposit.proposalID", "==", proposalID))
+		case types.MsgNameVote:
+			filters = append(filters, where("msgVote.proposalID", "==", proposalID))
+		case types.MsgNameVoteWeighted:
+			filters = append(filters, where("msgVoteWeighted.proposalID", "==", proposalID))
+		}
+	}
+	return db.getTxs(chainID, or(filters...))
+}
+
+// GetGovVoterHistory returns the votes of the voter in all the proposals.
+func (db *Database) GetGovVoterHistory(_ context.Context, chainID, voter string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgVote.voter", "==", voter),
+		where("msgVoteWeighted.voter", "==", voter),
+	))
+}
+
+// StoreTokenRegistryChanges stores the token registry changes and the tx if informed.
+func (db *Database) StoreTokenRegistryChanges(_ context.Context, chainInfo types.ChainInfo, tx *types.IndexedTx, changes []*types.TokenRegistryChange) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainInfo.ChainID, firebase.CollLeverageRegistryChanges)
+		for _, change := range changes {
+			if err := coll.set(types.TokenRegistryChangeDocID(*change), change); err != nil {
+				return err
+			}
+		}
+		if tx != nil {
+			if err := db.coll(chainInfo.ChainID, firebase.CollTransactions).add(*tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetTokenRegistryChanges returns the registry changes of the denom ordered by block height.
+func (db *Database) GetTokenRegistryChanges(_ context.Context, chainID, denom string) (changes []*types.TokenRegistryChange, err error) {
+	return query[types.TokenRegistryChange](db, chainID, firebase.CollLeverageRegistryChanges, "blockHeight", false,
+		where("denom", "==", denom))
+}
+
+// GetBankSends returns the MsgSend and MsgMultiSend sent or received by the address.
+func (db *Database) GetBankSends(_ context.Context, chainID, address string) (txs []*types.IndexedTx, err error) {
+	return db.getTxs(chainID, or(
+		where("msgSend.fromAddress", "==", address),
+		where("msgSend.toAddress", "==", address),
+		where("msgMultiSend.addresses", "array-contains", address),
+	))
+}
+
+// StoreBalanceChanges stores the balance changes of a block.
+func (db *Database) StoreBalanceChanges(_ context.Context, chainInfo types.ChainInfo, changes []types.BalanceChange) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainInfo.ChainID, firebase.CollBalanceChanges)
+		for _, change := range changes {
+			if err := coll.set(types.BalanceChangeDocID(change), change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetBalanceChanges returns a page of the balance changes of the address ordered from the most recent,
+// the denom, time interval and cursor are optional.
+func (db *Database) GetBalanceChanges(_ context.Context, chainID, address string, denom *string, fromTimeUnix, toTimeUnix *int, limit int, cursor *string) (page *types.BalanceChangePage, err error) {
+	err = db.RunTransaction(func() error {
+		coll := db.coll(chainID, firebase.CollBalanceChanges)
+		filters := append([]filter{where("address", "==", address)}, whereTimeUnix("blockTimeUnix", fromTimeUnix, toTimeUnix)...)
+		if denom != nil {
+			filters = append(filters, where("denom", "==", *denom))
+		}
+
+		// ordered by the time and then by the doc id, both from the most recent.
+		entries := coll.query(filters...)
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].id > entries[j].id
+		})
+		entries = orderBy(entries, "blockTimeUnix", true)
+
+		if cursor != nil {
+			var last types.BalanceChange
+			found, err := coll.get(*cursor, &last)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("invalid balance changes cursor: %s", *cursor)
+			}
+
+			after := make([]entry, 0, len(entries))
+			for _, e := range entries {
+				var change types.BalanceChange
+				if err := fromDoc(e.doc, &change); err != nil {
+					return err
+				}
+				if change.BlockTimeUnix < last.BlockTimeUnix || (change.BlockTimeUnix == last.BlockTimeUnix && e.id < *cursor) {
+					after = append(after, e)
+				}
+			}
+			entries = after
+		}
+
+		page = &types.BalanceChangePage{Changes: make([]*types.BalanceChange, 0, limit)}
+		if limit > 0 && len(entries) > limit {
+			entries = entries[:limit]
+			nextCursor := entries[limit-1].id
+			page.NextCursor = &nextCursor
+		}
+		page.Changes, err = decodeAll[types.BalanceChange](entries)
+		return err
+	})
+	return page, err
+}
+
+// StoreDeadLetter stores the failure to be reprocessed later, the attempts of the same failure are added up.
+func (db *Database) StoreDeadLetter(_ context.Context, chainID string, letter types.DeadLetter) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainID, firebase.CollDeadLetters)
+		var stored *types.DeadLetter
+		if _, err := coll.get(letter.ID, &stored); err != nil {
+			return err
+		}
+		return coll.set(letter.ID, types.MergeDeadLetter(stored, letter))
+	})
+}
+
+// GetDeadLetters returns the dead letters ordered by block height, the handler is optional.
+func (db *Database) GetDeadLetters(_ context.Context, chainID string, handler *string) (letters []*types.DeadLetter, err error) {
+	var filters []filter
+	if handler != nil {
+		filters = append(filters, where("handler", "==", *handler))
+	}
+	return query[types.DeadLetter](db, chainID, firebase.CollDeadLetters, "blockHeight", false, filters...)
+}
+
+// DeleteDeadLetter removes the dead letter after it was reprocessed.
+func (db *Database) DeleteDeadLetter(_ context.Context, chainID, id string) (err error) {
+	return db.RunTransaction(func() error {
+		delete(db.coll(chainID, firebase.CollDeadLetters).docs, id)
+		return nil
+	})
+}
+
+// GetBlockRecords returns the records stored by block between the block heights (inclusive).
+func (db *Database) GetBlockRecords(_ context.Context, chainID string, fromHeight, toHeight int) (records *types.BlockRecords, err error) {
+	err = db.RunTransaction(func() error {
+		inRange := []filter{where("blockHeight", ">=", fromHeight), where("blockHeight", "<=", toHeight)}
+		records = &types.BlockRecords{}
+		if records.Txs, err = decodeAll[types.IndexedTx](db.coll(chainID, firebase.CollTransactions).query(inRange...)); err != nil {
+			return err
+		}
+		if records.OracleVotes, err = decodeAll[types.OracleValidatorVote](db.coll(chainID, firebase.CollOracleVotes).query(inRange...)); err != nil {
+			return err
+		}
+		if records.BalanceChanges, err = decodeAll[types.BalanceChange](db.coll(chainID, firebase.CollBalanceChanges).query(inRange...)); err != nil {
+			return err
+		}
+		if records.UIBCEvents, err = decodeAll[types.UIBCEvent](db.coll(chainID, firebase.CollUIBCEvents).query(inRange...)); err != nil {
+			return err
+		}
+		records.TokenRegistryChanges, err = decodeAll[types.TokenRegistryChange](db.coll(chainID, firebase.CollLeverageRegistryChanges).query(inRange...))
+		return err
+	})
+	return records, err
+}
+
+// ForEachDoc calls f with the docs of the collection of the chain in the id order, decoded into the
+// values returned by newDoc. Only the docs after the id are read if it is not empty.
+func (db *Database) ForEachDoc(_ context.Context, chainID, collName, afterID string, newDoc func() any, f func(id string, doc any) error) (err error) {
+	var entries []entry
+	_ = db.RunTransaction(func() error {
+		entries = db.coll(chainID, collName).query()
+		return nil
+	})
+	if afterID != "" {
+		entries = entries[sort.Search(len(entries), func(i int) bool { return entries[i].id > afterID }):]
+	}
+
+	// f is called outside of the transaction, it can use the database.
+	for _, e := range entries {
+		v := newDoc()
+		if err := fromDoc(e.doc, v); err != nil {
+			return err
+		}
+		if err := f(e.id, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportDocs sets the docs of the collection of the chain by id, overwriting the stored ones.
+func (db *Database) ImportDocs(_ context.Context, chainID, collName string, docs map[string]any) (err error) {
+	return db.RunTransaction(func() error {
+		coll := db.coll(chainID, collName)
+		for id, doc := range docs {
+			if err := coll.setImported(id, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMsgRecords deletes the records stored by the handler of the msg and its dead letters between
+// the block heights (inclusive), returning how many docs were deleted.
+func (db *Database) DeleteMsgRecords(_ context.Context, chainID, protoMsgName string, fromHeight, toHeight int) (deleted int, err error) {
+	err = db.RunTransaction(func() error {
+		inRange := []filter{where("blockHeight", ">=", fromHeight), where("blockHeight", "<=", toHeight)}
+		deleteWhere := func(collName string, match func(e entry) (bool, error)) error {
+			n, err := db.coll(chainID, collName).deleteWhere(match, inRange...)
+			deleted += n
+			return err
+		}
+
+		err := deleteWhere(firebase.CollTransactions, func(e entry) (bool, error) {
+			var tx types.IndexedTx
+			err := fromDoc(e.doc, &tx)
+			return err == nil && types.IsTxOfMsg(tx, protoMsgName), err
+		})
+		if err != nil {
+			return err
+		}
+		err = deleteWhere(firebase.CollDeadLetters, func(e entry) (bool, error) {
+			var letter types.DeadLetter
+			err := fromDoc(e.doc, &letter)
+			return err == nil && types.IsDeadLetterOfMsg(letter, protoMsgName), err
+		})
+		if err != nil {
+			return err
+		}
+
+		switch protoMsgName {
+		case types.MsgNameAggregateExchangeRateVote:
+			collPerf := db.coll(chainID, firebase.CollOraclePerformance)
+			return deleteWhere(firebase.CollOracleVotes, func(e entry) (bool, error) {
+				var vote types.OracleValidatorVote
+				if err := fromDoc(e.doc, &vote); err != nil {
+					return false, err
+				}
+				docID := fmt.Sprintf("%s-%d", vote.Validator, vote.SlashWindow)
+				var perf types.OracleValidatorPerformance
+				if found, err := collPerf.get(docID, &perf); err != nil || !found {
+					return true, err
+				}
+				types.RevertOracleVote(&perf, vote)
+				return true, collPerf.set(docID, perf)
+			})
+		case types.MsgNameBalanceChanges:
+			return deleteWhere(firebase.CollBalanceChanges, func(entry) (bool, error) {
+				return true, nil
+			})
+		case types.MsgNameGovUpdateRegistry, types.MsgNameGovUpdateSpecialAssets:
+			return deleteWhere(firebase.CollLeverageRegistryChanges, func(e entry) (bool, error) {
+				var change types.TokenRegistryChange
+				err := fromDoc(e.doc, &change)
+				return err == nil && types.IsRegistryChangeOfMsg(change, protoMsgName), err
+			})
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// getChainInfo returns a copy of the default chain info if the chain was not indexed yet, so the
+// indexers of different databases do not share it.
+func (db *Database) getChainInfo(chainID string) (info *types.ChainInfo, err error) {
+	found, err := db.chains.get(chainID, &info)
+	if err != nil || found {
+		return info, err
+	}
+
+	dft, err := toDoc(types.DefaultChainInfo(chainID))
+	if err != nil {
+		return nil, err
+	}
+	return info, fromDoc(dft, &info)
+}
+
+// updateChainInfo reads the chain info stored and writes it back updated, so the intervals indexed by
+// the other indexers of the chain are kept.
+func (db *Database) updateChainInfo(chainID string, update func(info *types.ChainInfo) error) error {
+	info, err := db.getChainInfo(chainID)
+	if err != nil {
+		return err
+	}
+	info.MergeWithDefaults()
+	if err := update(info); err != nil {
+		return err
+	}
+	return db.chains.set(chainID, info)
+}
+
+// getTxs returns the txs that match all the filters in the order they were stored.
+func (db *Database) getTxs(chainID string, filters ...filter) (txs []*types.IndexedTx, err error) {
+	err = db.RunTransaction(func() error {
+		txs, err = decodeAll[types.IndexedTx](db.coll(chainID, firebase.CollTransactions).query(filters...))
+		return err
+	})
+	return txs, err
+}
+
+// query returns the docs of the collection that match all the filters ordered by the field.
+func query[T any](db *Database, chainID, collName, field string, desc bool, filters ...filter) (values []*T, err error) {
+	err = db.RunTransaction(func() error {
+		values, err = decodeAll[T](orderBy(db.coll(chainID, collName).query(filters...), field, desc))
+		return err
+	})
+	return values, err
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Database keeps the indexed data in memory, it is meant for tests and offline runs of the indexer.
+// The collections and the queries are the same as the firebase database.
+type Database struct {
+	mu sync.Mutex
+	// chains are the chain infos by chain id.
+	chains *collection
+	// colls are the collections by chain id and collection name.
+	colls map[string]map[string]*collection
+
+	logger zerolog.Logger
+}
+
+// New returns a new empty in memory database.
+func New(logger zerolog.Logger) *Database {
+	return &Database{
+		chains: newCollection(),
+		colls:  make(map[string]map[string]*collection),
+		logger: logger.With().Str("database", "memory").Logger(),
+	}
+}
+
+// Close does nothing, the data is kept until the database is garbage collected.
+func (db *Database) Close() error {
+	return nil
+}
+
+// RunTransaction runs the function holding the database lock, so every call is atomic.
+func (db *Database) RunTransaction(f func() error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return f()
+}
+
+// DeleteAll inside the database.
+func (db *Database) DeleteAll(_ context.Context) error {
+	return db.RunTransaction(func() error {
+		db.chains = newCollection()
+		db.colls = make(map[string]map[string]*collection)
+		return nil
+	})
+}
+
+// DeleteChainData delete the chain data and all of its structures inside.
+func (db *Database) DeleteChainData(_ context.Context, chainID string) error {
+	return db.RunTransaction(func() error {
+		delete(db.chains.docs, chainID)
+		delete(db.colls, chainID)
+		return nil
+	})
+}
+
+// coll returns the collection of the chain, creating it if needed. It must be called inside a transaction.
+func (db *Database) coll(chainID, name string) *collection {
+	colls, ok := db.colls[chainID]
+	if !ok {
+		colls = make(map[string]*collection)
+		db.colls[chainID] = colls
+	}
+
+	c, ok := colls[name]
+	if !ok {
+		c = newCollection()
+		colls[name] = c
+	}
+	return c
+}
@@ -0,0 +1,286 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// doc is a stored document, encoded by the json field names which are the same as the firestore ones,
+// so the queries use the same paths as the firebase database.
+type doc map[string]any
+
+// entry is a document with its id.
+type entry struct {
+	id  string
+	doc doc
+}
+
+// collection keeps the documents by id, documents without id are added with sequential ids, so
+// the documents not ordered by any field are returned in the order they were added.
+type collection struct {
+	docs   map[string]doc
+	nextID int
+}
+
+func newCollection() *collection {
+	return &collection{docs: make(map[string]doc)}
+}
+
+// set stores the value in the doc, overwriting it.
+func (c *collection) set(id string, v any) error {
+	d, err := toDoc(v)
+	if err != nil {
+		return err
+	}
+	c.docs[id] = d
+	return nil
+}
+
+// add stores the value in a new doc.
+func (c *collection) add(v any) error {
+	c.nextID++
+	return c.set(fmt.Sprintf("%020d", c.nextID), v)
+}
+
+// setImported stores the value in the doc as set, the ids of the docs added afterwards come after the
+// sequential ids imported.
+func (c *collection) setImported(id string, v any) error {
+	if n, err := strconv.Atoi(id); err == nil && n > c.nextID {
+		c.nextID = n
+	}
+	return c.set(id, v)
+}
+
+// get decodes the doc into v, it returns false if the doc doesn't exist.
+func (c *collection) get(id string, v any) (bool, error) {
+	d, ok := c.docs[id]
+	if !ok {
+		return false, nil
+	}
+	return true, fromDoc(d, v)
+}
+
+// deleteWhere deletes the docs that match all the filters and the match func, returning how many
+// were deleted.
+func (c *collection) deleteWhere(match func(e entry) (bool, error), filters ...filter) (deleted int, err error) {
+	for _, e := range c.query(filters...) {
+		ok, err := match(e)
+		if err != nil {
+			return deleted, err
+		}
+		if ok {
+			delete(c.docs, e.id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// query returns the docs that match all the filters ordered by id.
+func (c *collection) query(filters ...filter) []entry {
+	entries := make([]entry, 0)
+	for id, d := range c.docs {
+		if and(filters...)(d) {
+			entries = append(entries, entry{id: id, doc: d})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+	return entries
+}
+
+// filter matches a document.
+type filter func(d doc) bool
+
+// where compares the field in the path with the value, the path is dot separated. Supported operators
+// are the ones used by the firebase queries: ==, in, array-contains, <, <=, > and >=.
+// Like firestore, documents without the field do not match.
+func where(path, op string, value any) filter {
+	value = normalize(value)
+	return func(d doc) bool {
+		field, ok := lookup(d, path)
+		if !ok {
+			return false
+		}
+
+		switch op {
+		case "==":
+			return reflect.DeepEqual(field, value)
+		case "in":
+			values, _ := value.([]any)
+			for _, v := range values {
+				if reflect.DeepEqual(field, v) {
+					return true
+				}
+			}
+			return false
+		case "array-contains":
+			items, _ := field.([]any)
+			for _, item := range items {
+				if reflect.DeepEqual(item, value) {
+					return true
+				}
+			}
+			return false
+		}
+
+		cmp, ok := compare(field, value)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		}
+		return false
+	}
+}
+
+// whereTimeUnix filters by the time interval, nil values are not filtered.
+func whereTimeUnix(field string, fromTimeUnix, toTimeUnix *int) []filter {
+	conditions := types.TimeUnixConditions(fromTimeUnix, toTimeUnix)
+	filters := make([]filter, len(conditions))
+	for i, cond := range conditions {
+		filters[i] = where(field, cond.Op, cond.Value)
+	}
+	return filters
+}
+
+func and(filters ...filter) filter {
+	return func(d doc) bool {
+		for _, f := range filters {
+			if !f(d) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func or(filters ...filter) filter {
+	return func(d doc) bool {
+		for _, f := range filters {
+			if f(d) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// orderBy sorts the entries by the field keeping the id order for equal values, entries without
+// the field are removed as firestore does.
+func orderBy(entries []entry, field string, desc bool) []entry {
+	sorted := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		if _, ok := lookup(e.doc, field); ok {
+			sorted = append(sorted, e)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := lookup(sorted[i].doc, field)
+		b, _ := lookup(sorted[j].doc, field)
+		cmp, _ := compare(a, b)
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return sorted
+}
+
+// decodeAll decodes the docs of the entries in order.
+func decodeAll[T any](entries []entry) ([]*T, error) {
+	values := make([]*T, 0, len(entries))
+	for _, e := range entries {
+		var v T
+		if err := fromDoc(e.doc, &v); err != nil {
+			return nil, err
+		}
+		values = append(values, &v)
+	}
+	return values, nil
+}
+
+func lookup(d doc, path string) (any, bool) {
+	var value any = map[string]any(d)
+	for _, key := range strings.Split(path, ".") {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = fields[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// compare returns the order of two numbers or two strings, false if they are not comparable.
+func compare(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(a, b), true
+	}
+	return 0, false
+}
+
+// normalize encodes the value as it is found inside of a doc, numbers become float64.
+func normalize(v any) any {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized any
+	if err := json.Unmarshal(bz, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func toDoc(v any) (doc, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var d doc
+	return d, json.Unmarshal(bz, &d)
+}
+
+func fromDoc(d doc, v any) error {
+	bz, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -0,0 +1,159 @@
+// Package migrations upgrades the docs stored of a chain by older versions of the indexer, running the
+// migrations of the versions after the schema version stored of the chain in order.
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// batchSize is the amount of docs written at once by the migrations.
+const batchSize = 500
+
+// Migration upgrades the docs of a chain to the schema version. Migrations must be idempotent, a
+// migration that fails is run again from the start.
+type Migration struct {
+	Version     int
+	Description string
+	// Run upgrades the docs of the chain and returns the amount of docs changed.
+	Run func(ctx context.Context, db database.Database, chainID string) (migrated int, err error)
+}
+
+// migrations are ordered by version, the version of the latest is the schema version of new chains.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "stores the leveraged liquidations with the MsgLeveragedLiquidate proto msg name",
+		Run:         relabelLeveragedLiquidations,
+	},
+	{
+		Version:     2,
+		Description: "indexes the balance changes at the block heights indexed of MsgSend, which stored them before",
+		Run:         indexBalanceChangesOfSend,
+	},
+}
+
+// LatestVersion returns the schema version of the docs stored by this version of the indexer.
+func LatestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// Pending returns the migrations that did not run for the chain yet.
+func Pending(ctx context.Context, db database.Database, chainID string) ([]Migration, error) {
+	version, err := db.GetSchemaVersion(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Run runs the pending migrations of the chain in order, storing the schema version after each one,
+// and returns the migrations that ran. It must not run while an older indexer writes to the chain.
+func Run(ctx context.Context, db database.Database, chainID string, logger zerolog.Logger) (ran []Migration, err error) {
+	pending, err := Pending(ctx, db, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	ran = make([]Migration, 0, len(pending))
+	for _, m := range pending {
+		migrated, err := m.Run(ctx, db, chainID)
+		if err != nil {
+			return ran, fmt.Errorf("error running the migration %d of %s: %w", m.Version, chainID, err)
+		}
+		if err := db.SetSchemaVersion(ctx, chainID, m.Version); err != nil {
+			return ran, err
+		}
+		logger.Info().Str("chainID", chainID).Int("version", m.Version).Int("migrated", migrated).Msg(m.Description)
+		ran = append(ran, m)
+	}
+	return ran, nil
+}
+
+// RequireLatest returns an error if the chain has migrations that did not run.
+func RequireLatest(ctx context.Context, db database.Database, chainID string) error {
+	version, err := db.GetSchemaVersion(ctx, chainID)
+	if err != nil {
+		return err
+	}
+	if version < LatestVersion() {
+		return fmt.Errorf("the schema version of %s is %d, run the migrations up to %d first", chainID, version, LatestVersion())
+	}
+	return nil
+}
+
+// relabelLeveragedLiquidations fixes the leveraged liquidations stored with the MsgLiquidate proto msg name.
+func relabelLeveragedLiquidations(ctx context.Context, db database.Database, chainID string) (migrated int, err error) {
+	return updateDocs(ctx, db, chainID, firebase.CollTransactions, func(tx *types.IndexedTx) bool {
+		if tx.MsgLeverageLiquidate == nil || tx.ProtoMsgName == types.MsgNameLeveragedLiquidate {
+			return false
+		}
+		tx.ProtoMsgName = types.MsgNameLeveragedLiquidate
+		return true
+	})
+}
+
+// indexBalanceChangesOfSend adds the intervals indexed of MsgSend to the ones of the balance changes,
+// they were stored in the blocks indexed for MsgSend before having their own intervals.
+func indexBalanceChangesOfSend(ctx context.Context, db database.Database, chainID string) (migrated int, err error) {
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return 0, err
+	}
+	for _, cosmosMsg := range info.CosmosMsgs {
+		if cosmosMsg.ProtoMsgName != types.MsgNameSend {
+			continue
+		}
+		for _, interval := range cosmosMsg.BlocksIndexed {
+			msgNames := []string{types.MsgNameBalanceChanges}
+			if err := db.IndexBlockRange(ctx, *info, msgNames, interval.IdxFromBlockHeight, interval.IdxToBlockHeight); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// updateDocs writes the docs of the collection changed by update in batches, returning how many changed.
+func updateDocs[T any](ctx context.Context, db database.Database, chainID, collName string, update func(doc *T) (changed bool)) (updated int, err error) {
+	batch := make(map[string]any)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := db.ImportDocs(ctx, chainID, collName, batch); err != nil {
+			return err
+		}
+		updated += len(batch)
+		batch = make(map[string]any)
+		return nil
+	}
+
+	err = db.ForEachDoc(ctx, chainID, collName, "", func() any { return new(T) }, func(id string, doc any) error {
+		if !update(doc.(*T)) {
+			return nil
+		}
+		batch[id] = doc
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return updated, err
+	}
+	return updated, flush()
+}
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// ErrReadOnly is returned by the write methods of a read-only database.
+var ErrReadOnly = errors.New("the database is read-only")
+
+// ReadOnly wraps a database refusing all of its write methods, for the API replicas that only serve
+// the data stored by the indexer. It implements every method of the interface without embedding the
+// database, so a new method does not build until it is either refused or passed to the database.
+type ReadOnly struct {
+	db Database
+}
+
+var _ Database = ReadOnly{}
+
+// NewReadOnly returns the database that refuses to write to the db.
+func NewReadOnly(db Database) *ReadOnly {
+	return &ReadOnly{db: db}
+}
+
+func (r ReadOnly) Close() (err error) {
+	return r.db.Close()
+}
+
+func (ReadOnly) DeleteAll(context.Context) error { return ErrReadOnly }
+
+func (ReadOnly) DeleteChainData(context.Context, string) error { return ErrReadOnly }
+
+func (ReadOnly) DeleteMsgRecords(context.Context, string, string, int, int) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (r ReadOnly) GetBlockRecords(ctx context.Context, chainID string, fromHeight, toHeight int) (records *types.BlockRecords, err error) {
+	return r.db.GetBlockRecords(ctx, chainID, fromHeight, toHeight)
+}
+
+func (r ReadOnly) ForEachDoc(ctx context.Context, chainID, collName, afterID string, newDoc func() any, f func(id string, doc any) error) (err error) {
+	return r.db.ForEachDoc(ctx, chainID, collName, afterID, newDoc, f)
+}
+
+func (ReadOnly) ImportDocs(context.Context, string, string, map[string]any) error { return ErrReadOnly }
+
+func (r ReadOnly) GetSchemaVersion(ctx context.Context, chainID string) (version int, err error) {
+	return r.db.GetSchemaVersion(ctx, chainID)
+}
+
+func (ReadOnly) SetSchemaVersion(context.Context, string, int) error { return ErrReadOnly }
+
+func (ReadOnly) UpsertChainInfo(context.Context, types.ChainInfo) error { return ErrReadOnly }
+
+func (ReadOnly) UpdateChainHead(context.Context, types.ChainInfo) error { return ErrReadOnly }
+
+func (ReadOnly) IndexBlockRange(context.Context, types.ChainInfo, []string, int, int) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) UnindexBlockRange(context.Context, string, string, int, int) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetChainInfo(ctx context.Context, chainID string) (info *types.ChainInfo, err error) {
+	return r.db.GetChainInfo(ctx, chainID)
+}
+
+func (ReadOnly) StoreMsgLiquidate(context.Context, types.ChainInfo, int, int, string, *types.MsgExecution, types.MsgLiquidate) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) StoreMsgLeverageLiquidate(context.Context, types.ChainInfo, int, int, string, *types.MsgExecution, types.MsgLeverageLiquidate) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) StoreTx(context.Context, types.ChainInfo, types.IndexedTx) error { return ErrReadOnly }
+
+func (r ReadOnly) GetLiquidateMsgs(ctx context.Context, chainID string, borrower string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetLiquidateMsgs(ctx, chainID, borrower)
+}
+
+func (r ReadOnly) GetGranteeMsgs(ctx context.Context, chainID, grantee string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetGranteeMsgs(ctx, chainID, grantee)
+}
+
+func (ReadOnly) StoreOracleVotes(context.Context, types.ChainInfo, []types.OracleValidatorVote) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) StoreMsgDelegateFeedConsent(context.Context, types.ChainInfo, int, int, string, *types.MsgExecution, types.MsgDelegateFeedConsent) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetOracleValidatorPerformance(ctx context.Context, chainID, valoper string, window *int) (perfs []*types.OracleValidatorPerformance, err error) {
+	return r.db.GetOracleValidatorPerformance(ctx, chainID, valoper, window)
+}
+
+func (r ReadOnly) GetFeederDelegations(ctx context.Context, chainID, valoper string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetFeederDelegations(ctx, chainID, valoper)
+}
+
+func (ReadOnly) StoreIncentiveProgramSnapshots(context.Context, string, []types.IncentiveProgramSnapshot) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetIncentiveAccountTxs(ctx context.Context, chainID, account string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetIncentiveAccountTxs(ctx, chainID, account)
+}
+
+func (r ReadOnly) GetIncentiveProgramFundings(ctx context.Context, chainID string, programID int) (txs []*types.IndexedTx, err error) {
+	return r.db.GetIncentiveProgramFundings(ctx, chainID, programID)
+}
+
+func (r ReadOnly) GetIncentiveProgramSnapshots(ctx context.Context, chainID string, programID int) (snapshots []*types.IncentiveProgramSnapshot, err error) {
+	return r.db.GetIncentiveProgramSnapshots(ctx, chainID, programID)
+}
+
+func (ReadOnly) StoreMetokenIndexSnapshots(context.Context, string, []types.MetokenIndexSnapshot) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetMetokenUserTxs(ctx context.Context, chainID, user string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetMetokenUserTxs(ctx, chainID, user)
+}
+
+func (r ReadOnly) GetMetokenTxs(ctx context.Context, chainID, protoMsgName, metokenDenom, assetDenom string, fromTimeUnix, toTimeUnix *int) (txs []*types.IndexedTx, err error) {
+	return r.db.GetMetokenTxs(ctx, chainID, protoMsgName, metokenDenom, assetDenom, fromTimeUnix, toTimeUnix)
+}
+
+func (r ReadOnly) GetMetokenIndexSnapshots(ctx context.Context, chainID, metokenDenom string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.MetokenIndexSnapshot, err error) {
+	return r.db.GetMetokenIndexSnapshots(ctx, chainID, metokenDenom, fromTimeUnix, toTimeUnix)
+}
+
+func (ReadOnly) StoreUIBCEvents(context.Context, types.ChainInfo, []types.UIBCEvent) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) StoreUIBCOutflow(context.Context, types.ChainInfo, types.UIBCOutflowWindow, types.UIBCOutflowTransfer) error {
+	return ErrReadOnly
+}
+
+func (ReadOnly) StoreUIBCQuotaSnapshot(context.Context, string, types.UIBCQuotaSnapshot) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetUIBCGovTxs(ctx context.Context, chainID string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetUIBCGovTxs(ctx, chainID)
+}
+
+func (r ReadOnly) GetUIBCEvents(ctx context.Context, chainID string, eventType *string, fromTimeUnix, toTimeUnix *int) (events []*types.UIBCEvent, err error) {
+	return r.db.GetUIBCEvents(ctx, chainID, eventType, fromTimeUnix, toTimeUnix)
+}
+
+func (r ReadOnly) GetUIBCOutflows(ctx context.Context, chainID string, denom *string, fromTimeUnix, toTimeUnix *int) (outflows []*types.UIBCOutflowWindow, err error) {
+	return r.db.GetUIBCOutflows(ctx, chainID, denom, fromTimeUnix, toTimeUnix)
+}
+
+func (r ReadOnly) GetUIBCQuotaSnapshots(ctx context.Context, chainID string, fromTimeUnix, toTimeUnix *int) (snapshots []*types.UIBCQuotaSnapshot, err error) {
+	return r.db.GetUIBCQuotaSnapshots(ctx, chainID, fromTimeUnix, toTimeUnix)
+}
+
+func (ReadOnly) StoreIBCTransfer(context.Context, types.ChainInfo, types.IBCTransfer) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetIBCTransfer(ctx context.Context, chainID, direction, sourcePort, sourceChannel string, sequence int) (transfer *types.IBCTransfer, err error) {
+	return r.db.GetIBCTransfer(ctx, chainID, direction, sourcePort, sourceChannel, sequence)
+}
+
+func (r ReadOnly) GetIBCTransfers(ctx context.Context, chainID, address string, status *string) (transfers []*types.IBCTransfer, err error) {
+	return r.db.GetIBCTransfers(ctx, chainID, address, status)
+}
+
+func (r ReadOnly) GetStakingDelegatorTxs(ctx context.Context, chainID, delegator string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetStakingDelegatorTxs(ctx, chainID, delegator)
+}
+
+func (r ReadOnly) GetStakingValidatorTxs(ctx context.Context, chainID, validator string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetStakingValidatorTxs(ctx, chainID, validator)
+}
+
+func (r ReadOnly) GetStakingUnbondings(ctx context.Context, chainID, delegator string, completesAfterUnix *int) (txs []*types.IndexedTx, err error) {
+	return r.db.GetStakingUnbondings(ctx, chainID, delegator, completesAfterUnix)
+}
+
+func (ReadOnly) StoreGovProposal(context.Context, types.ChainInfo, *types.IndexedTx, types.GovProposal) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetGovProposal(ctx context.Context, chainID string, proposalID int) (proposal *types.GovProposal, err error) {
+	return r.db.GetGovProposal(ctx, chainID, proposalID)
+}
+
+func (r ReadOnly) GetGovProposals(ctx context.Context, chainID string, status *string) (proposals []*types.GovProposal, err error) {
+	return r.db.GetGovProposals(ctx, chainID, status)
+}
+
+func (r ReadOnly) GetGovProposalTxs(ctx context.Context, chainID string, proposalID int, protoMsgNames ...string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetGovProposalTxs(ctx, chainID, proposalID, protoMsgNames...)
+}
+
+func (r ReadOnly) GetGovVoterHistory(ctx context.Context, chainID, voter string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetGovVoterHistory(ctx, chainID, voter)
+}
+
+func (ReadOnly) StoreTokenRegistryChanges(context.Context, types.ChainInfo, *types.IndexedTx, []*types.TokenRegistryChange) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetTokenRegistryChanges(ctx context.Context, chainID, denom string) (changes []*types.TokenRegistryChange, err error) {
+	return r.db.GetTokenRegistryChanges(ctx, chainID, denom)
+}
+
+func (r ReadOnly) GetBankSends(ctx context.Context, chainID, address string) (txs []*types.IndexedTx, err error) {
+	return r.db.GetBankSends(ctx, chainID, address)
+}
+
+func (ReadOnly) StoreBalanceChanges(context.Context, types.ChainInfo, []types.BalanceChange) error {
+	return ErrReadOnly
+}
+
+func (r ReadOnly) GetBalanceChanges(ctx context.Context, chainID, address string, denom *string, fromTimeUnix, toTimeUnix *int, limit int, cursor *string) (page *types.BalanceChangePage, err error) {
+	return r.db.GetBalanceChanges(ctx, chainID, address, denom, fromTimeUnix, toTimeUnix, limit, cursor)
+}
+
+func (ReadOnly) StoreDeadLetter(context.Context, string, types.DeadLetter) error { return ErrReadOnly }
+
+func (r ReadOnly) GetDeadLetters(ctx context.Context, chainID string, handler *string) (letters []*types.DeadLetter, err error) {
+	return r.db.GetDeadLetters(ctx, chainID, handler)
+}
+
+func (ReadOnly) DeleteDeadLetter(context.Context, string, string) error { return ErrReadOnly }
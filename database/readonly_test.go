@@ -0,0 +1,100 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// backend fails the test on any call other than the read it stubs, its embedded database is nil.
+type backend struct {
+	database.Database
+	reads int
+}
+
+func (b *backend) GetChainInfo(context.Context, string) (*types.ChainInfo, error) {
+	b.reads++
+	return &types.ChainInfo{ChainID: "umee-1"}, nil
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	b := &backend{}
+	db := database.NewReadOnly(b)
+	info := types.ChainInfo{ChainID: "umee-1"}
+
+	tcs := []struct {
+		title string
+		write func() error
+	}{
+		{title: "DeleteAll", write: func() error { return db.DeleteAll(ctx) }},
+		{title: "DeleteChainData", write: func() error { return db.DeleteChainData(ctx, "umee-1") }},
+		{title: "DeleteMsgRecords", write: func() error {
+			_, err := db.DeleteMsgRecords(ctx, "umee-1", types.MsgNameSend, 1, 10)
+			return err
+		}},
+		{title: "ImportDocs", write: func() error { return db.ImportDocs(ctx, "umee-1", "coll", nil) }},
+		{title: "SetSchemaVersion", write: func() error { return db.SetSchemaVersion(ctx, "umee-1", 2) }},
+		{title: "UpsertChainInfo", write: func() error { return db.UpsertChainInfo(ctx, info) }},
+		{title: "UpdateChainHead", write: func() error { return db.UpdateChainHead(ctx, info) }},
+		{title: "IndexBlockRange", write: func() error { return db.IndexBlockRange(ctx, info, nil, 1, 10) }},
+		{title: "UnindexBlockRange", write: func() error {
+			return db.UnindexBlockRange(ctx, "umee-1", types.MsgNameSend, 1, 10)
+		}},
+		{title: "StoreMsgLiquidate", write: func() error {
+			return db.StoreMsgLiquidate(ctx, info, 1, 0, "hash", nil, types.MsgLiquidate{})
+		}},
+		{title: "StoreMsgLeverageLiquidate", write: func() error {
+			return db.StoreMsgLeverageLiquidate(ctx, info, 1, 0, "hash", nil, types.MsgLeverageLiquidate{})
+		}},
+		{title: "StoreTx", write: func() error { return db.StoreTx(ctx, info, types.IndexedTx{}) }},
+		{title: "StoreOracleVotes", write: func() error { return db.StoreOracleVotes(ctx, info, nil) }},
+		{title: "StoreMsgDelegateFeedConsent", write: func() error {
+			return db.StoreMsgDelegateFeedConsent(ctx, info, 1, 0, "hash", nil, types.MsgDelegateFeedConsent{})
+		}},
+		{title: "StoreIncentiveProgramSnapshots", write: func() error {
+			return db.StoreIncentiveProgramSnapshots(ctx, "umee-1", nil)
+		}},
+		{title: "StoreMetokenIndexSnapshots", write: func() error {
+			return db.StoreMetokenIndexSnapshots(ctx, "umee-1", nil)
+		}},
+		{title: "StoreUIBCEvents", write: func() error { return db.StoreUIBCEvents(ctx, info, nil) }},
+		{title: "StoreUIBCOutflow", write: func() error {
+			return db.StoreUIBCOutflow(ctx, info, types.UIBCOutflowWindow{}, types.UIBCOutflowTransfer{})
+		}},
+		{title: "StoreUIBCQuotaSnapshot", write: func() error {
+			return db.StoreUIBCQuotaSnapshot(ctx, "umee-1", types.UIBCQuotaSnapshot{})
+		}},
+		{title: "StoreIBCTransfer", write: func() error { return db.StoreIBCTransfer(ctx, info, types.IBCTransfer{}) }},
+		{title: "StoreGovProposal", write: func() error {
+			return db.StoreGovProposal(ctx, info, nil, types.GovProposal{})
+		}},
+		{title: "StoreTokenRegistryChanges", write: func() error {
+			return db.StoreTokenRegistryChanges(ctx, info, nil, nil)
+		}},
+		{title: "StoreBalanceChanges", write: func() error { return db.StoreBalanceChanges(ctx, info, nil) }},
+		{title: "StoreDeadLetter", write: func() error {
+			return db.StoreDeadLetter(ctx, "umee-1", types.DeadLetter{})
+		}},
+		{title: "DeleteDeadLetter", write: func() error { return db.DeleteDeadLetter(ctx, "umee-1", "id") }},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			// a write reaching the backend panics on its nil database.
+			var err error
+			require.NotPanics(t, func() { err = tc.write() })
+			require.ErrorIs(t, err, database.ErrReadOnly)
+		})
+	}
+
+	// the reads are passed to the backend.
+	got, err := db.GetChainInfo(ctx, "umee-1")
+	require.NoError(t, err)
+	require.Equal(t, "umee-1", got.ChainID)
+	require.Equal(t, 1, b.reads)
+}
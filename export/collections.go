@@ -0,0 +1,109 @@
+package export
+
+import (
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// collection is a collection of the chain exported with the type of its docs.
+type collection struct {
+	name   string
+	newDoc func() any
+	// heights returns the block heights the doc was stored from ~ to, false if it has none.
+	heights func(doc any) (from, to int, ok bool)
+	// msgNames returns the msgs whose handlers store the doc, none if it is stored by the block handlers.
+	msgNames func(doc any) []string
+}
+
+// collections are the collections exported of the chain, the chain info is exported apart.
+var collections = []collection{
+	newCollection(firebase.CollTransactions, func(tx *types.IndexedTx) (int, int, bool) {
+		return tx.BlockHeight, tx.BlockHeight, true
+	}, func(tx *types.IndexedTx) []string {
+		return []string{types.TxMsgName(*tx)}
+	}),
+	newCollection(firebase.CollOracleVotes, func(vote *types.OracleValidatorVote) (int, int, bool) {
+		return vote.BlockHeight, vote.BlockHeight, true
+	}, msgs[types.OracleValidatorVote](types.MsgNameAggregateExchangeRateVote)),
+	newCollection(firebase.CollOraclePerformance, func(perf *types.OracleValidatorPerformance) (int, int, bool) {
+		return perf.WindowFromBlockHeight, perf.WindowToBlockHeight, true
+	}, msgs[types.OracleValidatorPerformance](types.MsgNameAggregateExchangeRateVote)),
+	newCollection(firebase.CollBalanceChanges, func(change *types.BalanceChange) (int, int, bool) {
+		return change.BlockHeight, change.BlockHeight, true
+	}, msgs[types.BalanceChange](types.MsgNameBalanceChanges)),
+	newCollection(firebase.CollGovProposals, func(proposal *types.GovProposal) (int, int, bool) {
+		return proposal.SubmitBlockHeight, proposal.SubmitBlockHeight, true
+	}, msgs[types.GovProposal](types.MsgNameSubmitProposal, types.MsgNameDeposit)),
+	newCollection(firebase.CollIBCTransfers, func(transfer *types.IBCTransfer) (from int, to int, ok bool) {
+		for _, step := range transfer.Steps {
+			if !ok || step.BlockHeight < from {
+				from = step.BlockHeight
+			}
+			to, ok = max(to, step.BlockHeight), true
+		}
+		return from, to, ok
+	}, msgs[types.IBCTransfer](types.MsgNameTransfer, types.MsgNameRecvPacket, types.MsgNameAcknowledgement, types.MsgNameTimeout)),
+	newCollection(firebase.CollUIBCEvents, func(evt *types.UIBCEvent) (int, int, bool) {
+		return evt.BlockHeight, evt.BlockHeight, true
+	}, func(evt *types.UIBCEvent) []string {
+		if evt.EventType == types.UIBCEventQuotaExceeded {
+			return []string{types.MsgNameTransfer}
+		}
+		return nil
+	}),
+	newCollection(firebase.CollUIBCOutflows, func(*types.UIBCOutflowWindow) (int, int, bool) {
+		return 0, 0, false
+	}, msgs[types.UIBCOutflowWindow](types.MsgNameTransfer)),
+	newCollection(firebase.CollUIBCOutflowTransfers, func(transfer *types.UIBCOutflowTransfer) (int, int, bool) {
+		return transfer.BlockHeight, transfer.BlockHeight, true
+	}, msgs[types.UIBCOutflowTransfer](types.MsgNameTransfer)),
+	newCollection(firebase.CollUIBCQuotaSnapshots, func(snapshot *types.UIBCQuotaSnapshot) (int, int, bool) {
+		return snapshot.BlockHeight, snapshot.BlockHeight, true
+	}, msgs[types.UIBCQuotaSnapshot]()),
+	newCollection(firebase.CollIncentiveProgramSnapshots, func(snapshot *types.IncentiveProgramSnapshot) (int, int, bool) {
+		return snapshot.BlockHeight, snapshot.BlockHeight, true
+	}, msgs[types.IncentiveProgramSnapshot]()),
+	newCollection(firebase.CollMetokenIndexSnapshots, func(snapshot *types.MetokenIndexSnapshot) (int, int, bool) {
+		return snapshot.BlockHeight, snapshot.BlockHeight, true
+	}, msgs[types.MetokenIndexSnapshot]()),
+	newCollection(firebase.CollLeverageRegistryChanges, func(change *types.TokenRegistryChange) (int, int, bool) {
+		return change.BlockHeight, change.BlockHeight, true
+	}, func(change *types.TokenRegistryChange) []string {
+		return []string{change.ProtoMsgName}
+	}),
+	newCollection(firebase.CollDeadLetters, func(letter *types.DeadLetter) (int, int, bool) {
+		return letter.BlockHeight, letter.BlockHeight, true
+	}, func(letter *types.DeadLetter) []string {
+		msgNames := make([]string, 0, 1)
+		for _, cosmosMsg := range types.DefaultChainInfo("").CosmosMsgs {
+			if types.IsDeadLetterOfMsg(*letter, cosmosMsg.ProtoMsgName) {
+				msgNames = append(msgNames, cosmosMsg.ProtoMsgName)
+			}
+		}
+		return msgNames
+	}),
+}
+
+func newCollection[T any](name string, heights func(doc *T) (from, to int, ok bool), msgNames func(doc *T) []string) collection {
+	return collection{
+		name:     name,
+		newDoc:   func() any { return new(T) },
+		heights:  func(doc any) (int, int, bool) { return heights(doc.(*T)) },
+		msgNames: func(doc any) []string { return msgNames(doc.(*T)) },
+	}
+}
+
+// msgs returns the same msgs for all the docs of the collection.
+func msgs[T any](msgNames ...string) func(doc *T) []string {
+	return func(*T) []string { return msgNames }
+}
+
+// findCollection returns the collection by name, false if it is not exported.
+func findCollection(name string) (collection, bool) {
+	for _, coll := range collections {
+		if coll.name == name {
+			return coll, true
+		}
+	}
+	return collection{}, false
+}
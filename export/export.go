@@ -0,0 +1,210 @@
+// Package export streams the chain info and the records indexed of a chain to JSONL or Parquet files
+// and loads them into any database.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// importBatchSize is the amount of docs of a collection imported at once.
+const importBatchSize = 500
+
+// Record is a doc of a collection of the chain, encoded as JSON with the same field names as the database.
+type Record struct {
+	ChainID    string `json:"chainID" parquet:"chainID"`
+	Collection string `json:"collection" parquet:"collection"`
+	ID         string `json:"id" parquet:"id"`
+	// BlockHeight is the first block height the doc was stored at, zero if it has none.
+	BlockHeight int             `json:"blockHeight,omitempty" parquet:"blockHeight"`
+	Doc         json.RawMessage `json:"doc" parquet:"doc,json"`
+}
+
+// Writer writes the records exported.
+type Writer interface {
+	Write(r Record) error
+	Close() error
+}
+
+// Reader reads the records to import, io.EOF is returned after the last one.
+type Reader interface {
+	Read() (Record, error)
+}
+
+// Filter selects the records exported.
+type Filter struct {
+	// MsgNames are the msgs whose records are exported, all the records if empty. The records stored by
+	// the block handlers, as the snapshots, are not stored by any msg.
+	MsgNames []string
+	// FromHeight and ToHeight are the block heights (inclusive) of the records exported, they are not
+	// bounded if zero. The records without block heights are only exported if both are zero.
+	FromHeight, ToHeight int
+}
+
+// Export writes the chain info and the records of the chain that match the filter, returning the amount
+// of records written. The intervals indexed of the chain info are restricted to the filter, so importing
+// it does not mark as indexed the blocks that were not exported.
+func Export(ctx context.Context, db database.Database, chainID string, filter Filter, w Writer) (exported int, err error) {
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return 0, err
+	}
+	if err := write(w, chainID, firebase.CollChain, chainID, 0, filter.restrict(*info)); err != nil {
+		return 0, err
+	}
+	exported++
+
+	for _, coll := range collections {
+		err := db.ForEachDoc(ctx, chainID, coll.name, "", coll.newDoc, func(id string, doc any) error {
+			from, to, hasHeights := coll.heights(doc)
+			if !filter.matches(coll.msgNames(doc), from, to, hasHeights) {
+				return nil
+			}
+			if !hasHeights {
+				from = 0
+			}
+			exported++
+			return write(w, chainID, coll.name, id, from, doc)
+		})
+		if err != nil {
+			return exported, fmt.Errorf("error exporting %s: %w", coll.name, err)
+		}
+	}
+	return exported, nil
+}
+
+// Import loads the records into the database by collection, overwriting the docs with the same id, and
+// returns the amount of records imported. The intervals indexed of the chain infos are merged with the
+// stored ones.
+func Import(ctx context.Context, db database.Database, r Reader) (imported int, err error) {
+	var (
+		chainID, collName string
+		batch             = make(map[string]any)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := db.ImportDocs(ctx, chainID, collName, batch); err != nil {
+			return fmt.Errorf("error importing %s: %w", collName, err)
+		}
+		imported += len(batch)
+		batch = make(map[string]any)
+		return nil
+	}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		if record.ChainID != chainID || record.Collection != collName || len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+			chainID, collName = record.ChainID, record.Collection
+		}
+
+		if record.Collection == firebase.CollChain {
+			if err := importChainInfo(ctx, db, record); err != nil {
+				return imported, err
+			}
+			imported++
+			continue
+		}
+
+		coll, ok := findCollection(record.Collection)
+		if !ok {
+			return imported, fmt.Errorf("unknown collection %s", record.Collection)
+		}
+		doc := coll.newDoc()
+		if err := json.Unmarshal(record.Doc, doc); err != nil {
+			return imported, fmt.Errorf("error decoding %s %s: %w", record.Collection, record.ID, err)
+		}
+		batch[record.ID] = doc
+	}
+	return imported, flush()
+}
+
+// importChainInfo merges the intervals indexed of the chain info with the stored ones.
+func importChainInfo(ctx context.Context, db database.Database, record Record) error {
+	var info types.ChainInfo
+	if err := json.Unmarshal(record.Doc, &info); err != nil {
+		return fmt.Errorf("error decoding the chain info %s: %w", record.ID, err)
+	}
+
+	stored, err := db.GetChainInfo(ctx, record.ChainID)
+	if err != nil {
+		return err
+	}
+	stored.MergeWithDefaults()
+	info.MergeWithDefaults()
+	stored.MergeBlocksIndexed(&info)
+	stored.LastBlockHeightReceived = max(stored.LastBlockHeightReceived, info.LastBlockHeightReceived)
+	stored.LastBlockTimeUnixReceived = max(stored.LastBlockTimeUnixReceived, info.LastBlockTimeUnixReceived)
+	return db.UpsertChainInfo(ctx, *stored)
+}
+
+func write(w Writer, chainID, collName, id string, blockHeight int, doc any) error {
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return w.Write(Record{
+		ChainID:     chainID,
+		Collection:  collName,
+		ID:          id,
+		BlockHeight: blockHeight,
+		Doc:         bz,
+	})
+}
+
+// matches returns true if the doc stored by the msgs at the block heights is exported.
+func (f Filter) matches(msgNames []string, from, to int, hasHeights bool) bool {
+	if len(f.MsgNames) > 0 && !slices.ContainsFunc(msgNames, func(msgName string) bool {
+		return slices.Contains(f.MsgNames, msgName)
+	}) {
+		return false
+	}
+	if f.FromHeight == 0 && f.ToHeight == 0 {
+		return true
+	}
+	if !hasHeights {
+		return false
+	}
+	return to >= f.FromHeight && (f.ToHeight == 0 || from <= f.ToHeight)
+}
+
+// restrict returns a copy of the chain info with only the intervals indexed of the msgs and block
+// heights of the filter.
+func (f Filter) restrict(info types.ChainInfo) types.ChainInfo {
+	cosmosMsgs := make([]*types.CosmosMsgIndexed, 0, len(info.CosmosMsgs))
+	for _, cosmosMsg := range info.CosmosMsgs {
+		intervals := types.MergeBlockIndexedIntervals(cosmosMsg.BlocksIndexed, nil)
+		if len(f.MsgNames) > 0 && !slices.Contains(f.MsgNames, cosmosMsg.ProtoMsgName) {
+			intervals = []*types.BlockIndexedInterval{}
+		}
+		if f.FromHeight > 1 {
+			intervals = types.UnindexBlockRangeFromIntervals(intervals, 1, f.FromHeight-1)
+		}
+		if f.ToHeight > 0 {
+			intervals = types.UnindexBlockRangeFromIntervals(intervals, f.ToHeight+1, math.MaxInt)
+		}
+		cosmosMsgs = append(cosmosMsgs, &types.CosmosMsgIndexed{ProtoMsgName: cosmosMsg.ProtoMsgName, BlocksIndexed: intervals})
+	}
+	info.CosmosMsgs = cosmosMsgs
+	return info
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+
+	// maxJSONLLineSize is the size of the largest record read from JSONL files.
+	maxJSONLLineSize = 64 * 1024 * 1024
+)
+
+// FormatOfFile returns the format by the extension of the file, JSONL if it is not .parquet.
+func FormatOfFile(path string) string {
+	if strings.EqualFold(filepath.Ext(path), "."+FormatParquet) {
+		return FormatParquet
+	}
+	return FormatJSONL
+}
+
+// CreateFile creates the file and returns a writer of the records in the format.
+func CreateFile(path, format string) (Writer, error) {
+	if format != FormatJSONL && format != FormatParquet {
+		return nil, fmt.Errorf("unknown format %s, it must be %s or %s", format, FormatJSONL, FormatParquet)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatParquet {
+		return &parquetWriter{f: f, w: parquet.NewGenericWriter[Record](f, parquet.Compression(&parquet.Zstd))}, nil
+	}
+	return &jsonlWriter{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// OpenFile opens the file and returns a reader of the records in the format, the file is closed by the
+// returned func.
+func OpenFile(path, format string) (Reader, func() error, error) {
+	if format != FormatJSONL && format != FormatParquet {
+		return nil, nil, fmt.Errorf("unknown format %s, it must be %s or %s", format, FormatJSONL, FormatParquet)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if format == FormatParquet {
+		r := parquet.NewGenericReader[Record](f)
+		return &parquetReader{r: r}, func() error {
+			if err := r.Close(); err != nil {
+				_ = f.Close()
+				return err
+			}
+			return f.Close()
+		}, nil
+	}
+	return newJSONLReader(f), f.Close, nil
+}
+
+// jsonlWriter writes a record as JSON by line.
+type jsonlWriter struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+func (w *jsonlWriter) Write(r Record) error {
+	bz, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := w.w.Write(append(bz, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *jsonlWriter) Close() error {
+	if err := w.w.Flush(); err != nil {
+		_ = w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// jsonlReader reads a record as JSON by line, the empty lines are skipped.
+type jsonlReader struct {
+	s *bufio.Scanner
+}
+
+// newJSONLReader returns a reader of the records encoded as JSON by line.
+func newJSONLReader(r io.Reader) Reader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+	return &jsonlReader{s: s}
+}
+
+func (r *jsonlReader) Read() (record Record, err error) {
+	for r.s.Scan() {
+		line := r.s.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		err = json.Unmarshal(line, &record)
+		return record, err
+	}
+	if err := r.s.Err(); err != nil {
+		return record, err
+	}
+	return record, io.EOF
+}
+
+// parquetWriter writes the records as rows of a parquet file.
+type parquetWriter struct {
+	f *os.File
+	w *parquet.GenericWriter[Record]
+}
+
+func (w *parquetWriter) Write(r Record) error {
+	_, err := w.w.Write([]Record{r})
+	return err
+}
+
+func (w *parquetWriter) Close() error {
+	if err := w.w.Close(); err != nil {
+		_ = w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// parquetReader reads the rows of a parquet file as records.
+type parquetReader struct {
+	r *parquet.GenericReader[Record]
+}
+
+func (r *parquetReader) Read() (Record, error) {
+	rows := make([]Record, 1)
+	n, err := r.r.Read(rows)
+	if n == 1 {
+		return rows[0], nil
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return Record{}, err
+}
@@ -0,0 +1,227 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+)
+
+// Checkpoint is the progress of a migration of a chain, it is saved after every batch copied so the
+// migration resumes after the last doc copied.
+type Checkpoint struct {
+	ChainID string `json:"chainID"`
+	// LastIDs are the ids of the last docs copied by collection.
+	LastIDs map[string]string `json:"lastIDs"`
+	// Copied are the amount of docs copied by collection.
+	Copied map[string]int `json:"copied"`
+	// Done are the collections copied entirely.
+	Done map[string]bool `json:"done"`
+	// ChainInfoDone is set once the chain info is copied, it is copied after all the collections.
+	ChainInfoDone bool `json:"chainInfoDone"`
+}
+
+// CollectionSum is the amount of docs of a collection and a checksum of their ids and contents, which
+// does not depend on the order the docs are read.
+type CollectionSum struct {
+	Collection string `json:"collection"`
+	Count      int    `json:"count"`
+	Checksum   string `json:"checksum"`
+}
+
+// SumMismatch is a collection that differs between the source and the destination of a migration.
+type SumMismatch struct {
+	Source      CollectionSum `json:"source"`
+	Destination CollectionSum `json:"destination"`
+}
+
+// NewCheckpoint returns the checkpoint of a migration of the chain that did not start.
+func NewCheckpoint(chainID string) *Checkpoint {
+	return &Checkpoint{
+		ChainID: chainID,
+		LastIDs: make(map[string]string),
+		Copied:  make(map[string]int),
+		Done:    make(map[string]bool),
+	}
+}
+
+// LoadCheckpoint returns the checkpoint saved in the file, a new one if the file does not exist.
+func LoadCheckpoint(path, chainID string) (*Checkpoint, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewCheckpoint(chainID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cp := NewCheckpoint(chainID)
+	if err := json.Unmarshal(bz, cp); err != nil {
+		return nil, fmt.Errorf("error decoding the checkpoint %s: %w", path, err)
+	}
+	if cp.ChainID != chainID {
+		return nil, fmt.Errorf("the checkpoint %s is of the chain %s, not %s", path, cp.ChainID, chainID)
+	}
+	return cp, nil
+}
+
+// Save writes the checkpoint to the file, replacing it at once so a failure never leaves it half written.
+func (cp *Checkpoint) Save(path string) error {
+	bz, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Migrate copies the docs of all the collections of the chain from the source to the destination in
+// batches of the size, saving the checkpoint after each batch, and then copies the chain info and the
+// schema version, so the destination only has the blocks marked as indexed once all of their records
+// are copied. It resumes from the checkpoint and must not run while an indexer writes to the source.
+func Migrate(ctx context.Context, src, dst database.Database, cp *Checkpoint, batchSize int, save func(*Checkpoint) error) error {
+	batchSize = max(batchSize, 1)
+
+	for _, coll := range collections {
+		if cp.Done[coll.name] {
+			continue
+		}
+
+		batch, lastID := make(map[string]any), ""
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := dst.ImportDocs(ctx, cp.ChainID, coll.name, batch); err != nil {
+				return fmt.Errorf("error copying %s: %w", coll.name, err)
+			}
+			cp.LastIDs[coll.name] = lastID
+			cp.Copied[coll.name] += len(batch)
+			batch = make(map[string]any)
+			return save(cp)
+		}
+
+		err := src.ForEachDoc(ctx, cp.ChainID, coll.name, cp.LastIDs[coll.name], coll.newDoc, func(id string, doc any) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			batch[id], lastID = doc, id
+			if len(batch) < batchSize {
+				return nil
+			}
+			return flush()
+		})
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", coll.name, err)
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		cp.Done[coll.name] = true
+		if err := save(cp); err != nil {
+			return err
+		}
+	}
+
+	if cp.ChainInfoDone {
+		return nil
+	}
+	info, err := src.GetChainInfo(ctx, cp.ChainID)
+	if err != nil {
+		return err
+	}
+	if err := dst.UpsertChainInfo(ctx, *info); err != nil {
+		return err
+	}
+	// the docs copied are of the schema version of the source, they do not need to be migrated again.
+	version, err := src.GetSchemaVersion(ctx, cp.ChainID)
+	if err != nil {
+		return err
+	}
+	if err := dst.SetSchemaVersion(ctx, cp.ChainID, version); err != nil {
+		return err
+	}
+	cp.ChainInfoDone = true
+	return save(cp)
+}
+
+// VerifyMigration compares the amount of docs and the checksums of the chain info and of every
+// collection of the chain in both databases, returning the ones that differ.
+func VerifyMigration(ctx context.Context, src, dst database.Database, chainID string) ([]SumMismatch, error) {
+	srcSums, err := Checksums(ctx, src, chainID)
+	if err != nil {
+		return nil, err
+	}
+	dstSums, err := Checksums(ctx, dst, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := make([]SumMismatch, 0)
+	for i := range srcSums {
+		if srcSums[i] != dstSums[i] {
+			mismatches = append(mismatches, SumMismatch{Source: srcSums[i], Destination: dstSums[i]})
+		}
+	}
+	return mismatches, nil
+}
+
+// Checksums returns the amount of docs and the checksum of the chain info and of every collection of
+// the chain. The checksum of a collection is the XOR of the sha256 of the id and the JSON of each doc.
+func Checksums(ctx context.Context, db database.Database, chainID string) ([]CollectionSum, error) {
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	var infoSum checksum
+	if err := infoSum.add(chainID, info); err != nil {
+		return nil, err
+	}
+	sums := []CollectionSum{{Collection: firebase.CollChain, Count: 1, Checksum: infoSum.String()}}
+
+	for _, coll := range collections {
+		var sum checksum
+		count := 0
+		err := db.ForEachDoc(ctx, chainID, coll.name, "", coll.newDoc, func(id string, doc any) error {
+			count++
+			return sum.add(id, doc)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", coll.name, err)
+		}
+		sums = append(sums, CollectionSum{Collection: coll.name, Count: count, Checksum: sum.String()})
+	}
+	return sums, nil
+}
+
+// checksum is a hash of a set of docs that does not depend on the order they are added.
+type checksum [sha256.Size]byte
+
+func (c *checksum) add(id string, doc any) error {
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	h.Write([]byte(id))
+	h.Write([]byte{0})
+	h.Write(bz)
+	for i, b := range h.Sum(nil) {
+		c[i] ^= b
+	}
+	return nil
+}
+
+func (c checksum) String() string {
+	return hex.EncodeToString(c[:])
+}
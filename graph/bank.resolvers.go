@@ -0,0 +1,29 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// BankSends is the resolver for the bankSends field.
+func (r *queryResolver) BankSends(ctx context.Context, chainID *string, address string) ([]*types.IndexedTx, error) {
+	return r.db.GetBankSends(ctx, defaultChainID(chainID), address)
+}
+
+// BalanceChanges is the resolver for the balanceChanges field.
+func (r *queryResolver) BalanceChanges(ctx context.Context, chainID *string, address string, denom *string, fromTimeUnix *int, toTimeUnix *int, limit *int, cursor *string) (*types.BalanceChangePage, error) {
+	pageSize := defaultBalanceChangesLimit
+	if limit != nil {
+		pageSize = *limit
+	}
+	if pageSize <= 0 || pageSize > maxBalanceChangesLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxBalanceChangesLimit)
+	}
+	return r.db.GetBalanceChanges(ctx, defaultChainID(chainID), address, denom, fromTimeUnix, toTimeUnix, pageSize, cursor)
+}
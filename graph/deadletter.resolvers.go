@@ -0,0 +1,25 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// DeadLetters is the resolver for the deadLetters field.
+func (r *queryResolver) DeadLetters(ctx context.Context, chainID *string, handler *string) ([]*types.DeadLetter, error) {
+	return r.db.GetDeadLetters(ctx, defaultChainID(chainID), handler)
+}
+
+// DeadLetterCounts is the resolver for the deadLetterCounts field.
+func (r *queryResolver) DeadLetterCounts(ctx context.Context, chainID *string) ([]*types.DeadLetterCount, error) {
+	letters, err := r.db.GetDeadLetters(ctx, defaultChainID(chainID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return types.DeadLetterCounts(letters), nil
+}
@@ -0,0 +1,45 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// GovProposal is the resolver for the govProposal field.
+func (r *queryResolver) GovProposal(ctx context.Context, chainID *string, proposalID int) (*types.GovProposal, error) {
+	return r.db.GetGovProposal(ctx, defaultChainID(chainID), proposalID)
+}
+
+// GovProposals is the resolver for the govProposals field.
+func (r *queryResolver) GovProposals(ctx context.Context, chainID *string, status *string) ([]*types.GovProposal, error) {
+	return r.db.GetGovProposals(ctx, defaultChainID(chainID), status)
+}
+
+// GovProposalDeposits is the resolver for the govProposalDeposits field.
+func (r *queryResolver) GovProposalDeposits(ctx context.Context, chainID *string, proposalID int) ([]*types.IndexedTx, error) {
+	return r.db.GetGovProposalTxs(ctx, defaultChainID(chainID), proposalID, types.MsgNameDeposit)
+}
+
+// GovProposalVotes is the resolver for the govProposalVotes field.
+func (r *queryResolver) GovProposalVotes(ctx context.Context, chainID *string, proposalID int) ([]*types.IndexedTx, error) {
+	return r.db.GetGovProposalTxs(ctx, defaultChainID(chainID), proposalID, types.MsgNameVote, types.MsgNameVoteWeighted)
+}
+
+// GovProposalVoteCounts is the resolver for the govProposalVoteCounts field.
+func (r *queryResolver) GovProposalVoteCounts(ctx context.Context, chainID *string, proposalID int) ([]*types.GovVoteCount, error) {
+	txs, err := r.db.GetGovProposalTxs(ctx, defaultChainID(chainID), proposalID, types.MsgNameVote, types.MsgNameVoteWeighted)
+	if err != nil {
+		return nil, err
+	}
+	return types.GovVoteCounts(txs)
+}
+
+// GovVoterHistory is the resolver for the govVoterHistory field.
+func (r *queryResolver) GovVoterHistory(ctx context.Context, chainID *string, voter string) ([]*types.IndexedTx, error) {
+	return r.db.GetGovVoterHistory(ctx, defaultChainID(chainID), voter)
+}
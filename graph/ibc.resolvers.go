@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// IbcTransfer is the resolver for the ibcTransfer field.
+func (r *queryResolver) IbcTransfer(ctx context.Context, chainID *string, direction string, sourceChannel string, sequence int) (*types.IBCTransfer, error) {
+	return r.db.GetIBCTransfer(ctx, defaultChainID(chainID), direction, transfertypes.PortID, sourceChannel, sequence)
+}
+
+// IbcTransfers is the resolver for the ibcTransfers field.
+func (r *queryResolver) IbcTransfers(ctx context.Context, chainID *string, address string, status *string) ([]*types.IBCTransfer, error) {
+	return r.db.GetIBCTransfers(ctx, defaultChainID(chainID), address, status)
+}
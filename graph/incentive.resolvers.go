@@ -0,0 +1,56 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// IncentiveBondedUTokens is the resolver for the incentiveBondedUTokens field.
+func (r *queryResolver) IncentiveBondedUTokens(ctx context.Context, chainID *string, account string, uToken string) ([]*types.IncentiveBondedBalance, error) {
+	txs, err := r.db.GetIncentiveAccountTxs(ctx, defaultChainID(chainID), account)
+	if err != nil {
+		return nil, err
+	}
+	return types.IncentiveBondedOverTime(txs, uToken)
+}
+
+// IncentiveClaimedRewards is the resolver for the incentiveClaimedRewards field.
+func (r *queryResolver) IncentiveClaimedRewards(ctx context.Context, chainID *string, account string) ([]*types.IndexedTx, error) {
+	txs, err := r.db.GetIncentiveAccountTxs(ctx, defaultChainID(chainID), account)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*types.IndexedTx, 0, len(txs))
+	for _, tx := range txs {
+		if len(types.IncentiveRewards(tx)) == 0 {
+			continue
+		}
+		claimed = append(claimed, tx)
+	}
+	return claimed, nil
+}
+
+// IncentiveProgramHistory is the resolver for the incentiveProgramHistory field.
+func (r *queryResolver) IncentiveProgramHistory(ctx context.Context, chainID *string, programID int) (*types.IncentiveProgramHistory, error) {
+	fundings, err := r.db.GetIncentiveProgramFundings(ctx, defaultChainID(chainID), programID)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts, err := r.db.GetIncentiveProgramSnapshots(ctx, defaultChainID(chainID), programID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.IncentiveProgramHistory{
+		ProgramID: programID,
+		Fundings:  fundings,
+		Payouts:   payouts,
+	}, nil
+}
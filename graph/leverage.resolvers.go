@@ -0,0 +1,29 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// TokenRegistryHistory is the resolver for the tokenRegistryHistory field.
+func (r *queryResolver) TokenRegistryHistory(ctx context.Context, chainID *string, denom string) ([]*types.TokenRegistryVersion, error) {
+	changes, err := r.db.GetTokenRegistryChanges(ctx, defaultChainID(chainID), denom)
+	if err != nil {
+		return nil, err
+	}
+	return types.TokenRegistryHistory(changes), nil
+}
+
+// TokenRegistryAt is the resolver for the tokenRegistryAt field.
+func (r *queryResolver) TokenRegistryAt(ctx context.Context, chainID *string, denom string, blockHeight int) (*types.TokenRegistryVersion, error) {
+	changes, err := r.db.GetTokenRegistryChanges(ctx, defaultChainID(chainID), denom)
+	if err != nil {
+		return nil, err
+	}
+	return types.TokenRegistryAt(types.TokenRegistryHistory(changes), blockHeight), nil
+}
@@ -0,0 +1,39 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// MetokenUserTxs is the resolver for the metokenUserTxs field.
+func (r *queryResolver) MetokenUserTxs(ctx context.Context, chainID *string, user string) ([]*types.IndexedTx, error) {
+	return r.db.GetMetokenUserTxs(ctx, defaultChainID(chainID), user)
+}
+
+// MetokenSwapVolume is the resolver for the metokenSwapVolume field.
+func (r *queryResolver) MetokenSwapVolume(ctx context.Context, chainID *string, metokenDenom string, assetDenom string, fromTimeUnix *int, toTimeUnix *int) (*types.MetokenVolume, error) {
+	txs, err := r.db.GetMetokenTxs(ctx, defaultChainID(chainID), types.MsgNameSwap, metokenDenom, assetDenom, fromTimeUnix, toTimeUnix)
+	if err != nil {
+		return nil, err
+	}
+	return types.MetokenSwapVolume(txs, metokenDenom, assetDenom)
+}
+
+// MetokenRedeemVolume is the resolver for the metokenRedeemVolume field.
+func (r *queryResolver) MetokenRedeemVolume(ctx context.Context, chainID *string, metokenDenom string, assetDenom string, fromTimeUnix *int, toTimeUnix *int) (*types.MetokenVolume, error) {
+	txs, err := r.db.GetMetokenTxs(ctx, defaultChainID(chainID), types.MsgNameRedeem, metokenDenom, assetDenom, fromTimeUnix, toTimeUnix)
+	if err != nil {
+		return nil, err
+	}
+	return types.MetokenRedeemVolume(txs, metokenDenom, assetDenom)
+}
+
+// MetokenIndexSnapshots is the resolver for the metokenIndexSnapshots field.
+func (r *queryResolver) MetokenIndexSnapshots(ctx context.Context, chainID *string, metokenDenom string, fromTimeUnix *int, toTimeUnix *int) ([]*types.MetokenIndexSnapshot, error) {
+	return r.db.GetMetokenIndexSnapshots(ctx, defaultChainID(chainID), metokenDenom, fromTimeUnix, toTimeUnix)
+}
@@ -0,0 +1,21 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// OracleValidatorPerformance is the resolver for the oracleValidatorPerformance field.
+func (r *queryResolver) OracleValidatorPerformance(ctx context.Context, chainID *string, valoper string, window *int) ([]*types.OracleValidatorPerformance, error) {
+	return r.db.GetOracleValidatorPerformance(ctx, defaultChainID(chainID), valoper, window)
+}
+
+// OracleFeederDelegations is the resolver for the oracleFeederDelegations field.
+func (r *queryResolver) OracleFeederDelegations(ctx context.Context, chainID *string, valoper string) ([]*types.IndexedTx, error) {
+	return r.db.GetFeederDelegations(ctx, defaultChainID(chainID), valoper)
+}
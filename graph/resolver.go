@@ -8,7 +8,13 @@ import (
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
-const DefaultChainID = "umee-1"
+const (
+	DefaultChainID = "umee-1"
+
+	// defaultBalanceChangesLimit and maxBalanceChangesLimit are the page sizes of the balance changes.
+	defaultBalanceChangesLimit = 100
+	maxBalanceChangesLimit     = 1000
+)
 
 type Resolver struct {
 	db     database.Database
@@ -15,6 +15,11 @@ func (r *queryResolver) GetLiquidateMsgs(ctx context.Context, chainID *string, b
 	return r.db.GetLiquidateMsgs(ctx, defaultChainID(chainID), borrower)
 }
 
+// GetGranteeMsgs is the resolver for the getGranteeMsgs field.
+func (r *queryResolver) GetGranteeMsgs(ctx context.Context, chainID *string, grantee string) ([]*types.IndexedTx, error) {
+	return r.db.GetGranteeMsgs(ctx, defaultChainID(chainID), grantee)
+}
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
@@ -0,0 +1,26 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// StakingDelegatorTxs is the resolver for the stakingDelegatorTxs field.
+func (r *queryResolver) StakingDelegatorTxs(ctx context.Context, chainID *string, delegator string) ([]*types.IndexedTx, error) {
+	return r.db.GetStakingDelegatorTxs(ctx, defaultChainID(chainID), delegator)
+}
+
+// StakingValidatorTxs is the resolver for the stakingValidatorTxs field.
+func (r *queryResolver) StakingValidatorTxs(ctx context.Context, chainID *string, validator string) ([]*types.IndexedTx, error) {
+	return r.db.GetStakingValidatorTxs(ctx, defaultChainID(chainID), validator)
+}
+
+// StakingUnbondings is the resolver for the stakingUnbondings field.
+func (r *queryResolver) StakingUnbondings(ctx context.Context, chainID *string, delegator string, completesAfterUnix *int) ([]*types.IndexedTx, error) {
+	return r.db.GetStakingUnbondings(ctx, defaultChainID(chainID), delegator, completesAfterUnix)
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+var (
+	MsgNameSend      = proto.MessageName(&banktypes.MsgSend{})
+	MsgNameMultiSend = proto.MessageName(&banktypes.MsgMultiSend{})
+)
+
+// MsgNameBalanceChanges is the name the balance changes of the blocks are indexed by, they are stored
+// by a block handler apart from the bank msgs, so each one is reindexed without deleting the other.
+const MsgNameBalanceChanges = "umeed.indexer.BalanceChanges"
+
+const (
+	BalanceChangeSourceBeginBlock = "begin_block"
+	BalanceChangeSourceTx         = "tx"
+	BalanceChangeSourceEndBlock   = "end_block"
+)
+
+// ParseTxSend gets a bank tx msg and transpile to the graphql one.
+func ParseTxSend(msg *banktypes.MsgSend) MsgSend {
+	return MsgSend{
+		FromAddress: msg.FromAddress,
+		ToAddress:   msg.ToAddress,
+		Amount:      msg.Amount.String(),
+	}
+}
+
+// ParseTxMultiSend gets a bank tx msg and transpile to the graphql one.
+func ParseTxMultiSend(msg *banktypes.MsgMultiSend) MsgMultiSend {
+	parsed := MsgMultiSend{
+		Inputs:    make([]*BankTransferCoins, len(msg.Inputs)),
+		Outputs:   make([]*BankTransferCoins, len(msg.Outputs)),
+		Addresses: make([]string, 0, len(msg.Inputs)+len(msg.Outputs)),
+	}
+
+	seen := make(map[string]struct{})
+	addAddress := func(address string) {
+		if _, ok := seen[address]; ok {
+			return
+		}
+		seen[address] = struct{}{}
+		parsed.Addresses = append(parsed.Addresses, address)
+	}
+	for i, input := range msg.Inputs {
+		parsed.Inputs[i] = &BankTransferCoins{Address: input.Address, Coins: input.Coins.String()}
+		addAddress(input.Address)
+	}
+	for i, output := range msg.Outputs {
+		parsed.Outputs[i] = &BankTransferCoins{Address: output.Address, Coins: output.Coins.String()}
+		addAddress(output.Address)
+	}
+	return parsed
+}
+
+// BalanceChangeDocID returns the doc id of the balance change, firestore ids can not contain slashes
+// which are part of the ibc denoms.
+func BalanceChangeDocID(change BalanceChange) string {
+	source := change.Source
+	if change.TxHash != "" {
+		source = change.TxHash
+	}
+	return fmt.Sprintf("%d-%s-%s-%s", change.BlockHeight, source, change.Address, strings.ReplaceAll(change.Denom, "/", "_"))
+}
+
+// ParseBalanceChanges sums the coin_spent and coin_received events by address and denom into the net
+// balance changes, ordered by address and denom. The tx hash is only informed for the tx source.
+func ParseBalanceChanges(events []abcitypes.Event, source, txHash string, blkHeight, blockTimeUnix int) []BalanceChange {
+	type key struct{ address, denom string }
+	received := make(map[key]sdkmath.Int)
+	spent := make(map[key]sdkmath.Int)
+	keys := make([]key, 0)
+
+	add := func(totals map[key]sdkmath.Int, address, amount string) {
+		coins, err := sdktypes.ParseCoinsNormalized(amount)
+		if err != nil {
+			return
+		}
+		for _, coin := range coins {
+			k := key{address, coin.Denom}
+			if _, ok := received[k]; !ok {
+				received[k], spent[k] = sdkmath.ZeroInt(), sdkmath.ZeroInt()
+				keys = append(keys, k)
+			}
+			totals[k] = totals[k].Add(coin.Amount)
+		}
+	}
+
+	for _, evt := range events {
+		switch evt.Type {
+		case banktypes.EventTypeCoinSpent:
+			add(spent, EventAttr(evt, banktypes.AttributeKeySpender), EventAttr(evt, sdktypes.AttributeKeyAmount))
+		case banktypes.EventTypeCoinReceived:
+			add(received, EventAttr(evt, banktypes.AttributeKeyReceiver), EventAttr(evt, sdktypes.AttributeKeyAmount))
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].address != keys[j].address {
+			return keys[i].address < keys[j].address
+		}
+		return keys[i].denom < keys[j].denom
+	})
+
+	changes := make([]BalanceChange, 0, len(keys))
+	for _, k := range keys {
+		changes = append(changes, BalanceChange{
+			Address:       k.address,
+			Denom:         k.denom,
+			Amount:        received[k].Sub(spent[k]).String(),
+			Received:      received[k].String(),
+			Spent:         spent[k].String(),
+			Source:        source,
+			TxHash:        txHash,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestParseBalanceChanges(t *testing.T) {
+	coinEvent := func(eventType, key, address, amount string) abcitypes.Event {
+		return abcitypes.Event{Type: eventType, Attributes: []abcitypes.EventAttribute{
+			{Key: key, Value: address},
+			{Key: "amount", Value: amount},
+		}}
+	}
+	events := []abcitypes.Event{
+		// fee payment.
+		coinEvent("coin_spent", "spender", "umee1user", "10uumee"),
+		coinEvent("coin_received", "receiver", "umee1fee", "10uumee"),
+		// send with two denoms.
+		coinEvent("coin_spent", "spender", "umee1user", "100uumee,5ibc/ATOM"),
+		coinEvent("coin_received", "receiver", "umee1other", "100uumee,5ibc/ATOM"),
+		coinEvent("coin_received", "receiver", "umee1user", "1uumee"),
+		{Type: "transfer", Attributes: []abcitypes.EventAttribute{{Key: "amount", Value: "3uumee"}}},
+	}
+
+	changes := types.ParseBalanceChanges(events, types.BalanceChangeSourceTx, "hash", 10, 100)
+	require.Len(t, changes, 5)
+
+	require.Equal(t, "umee1fee", changes[0].Address)
+	require.Equal(t, "10", changes[0].Amount)
+
+	require.Equal(t, "umee1other", changes[1].Address)
+	require.Equal(t, "ibc/ATOM", changes[1].Denom)
+	require.Equal(t, "uumee", changes[2].Denom)
+
+	require.Equal(t, types.BalanceChange{
+		Address: "umee1user", Denom: "uumee", Amount: "-109", Received: "1", Spent: "110",
+		Source: types.BalanceChangeSourceTx, TxHash: "hash", BlockHeight: 10, BlockTimeUnix: 100,
+	}, changes[4])
+	require.Equal(t, "10-hash-umee1user-ibc_ATOM", types.BalanceChangeDocID(changes[3]))
+}
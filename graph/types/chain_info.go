@@ -9,9 +9,11 @@ import (
 )
 
 var (
-	MsgNameLiquidate                              = proto.MessageName(&lvgtypes.MsgLiquidate{})
-	MsgNameLeveragedLiquidate                     = proto.MessageName(&lvgtypes.MsgLeveragedLiquidate{})
-	defaultCosmosMsgs         []*CosmosMsgIndexed = []*CosmosMsgIndexed{
+	MsgNameLiquidate                                  = proto.MessageName(&lvgtypes.MsgLiquidate{})
+	MsgNameLeveragedLiquidate                         = proto.MessageName(&lvgtypes.MsgLeveragedLiquidate{})
+	MsgNameGovUpdateRegistry                          = proto.MessageName(&lvgtypes.MsgGovUpdateRegistry{})
+	MsgNameGovUpdateSpecialAssets                     = proto.MessageName(&lvgtypes.MsgGovUpdateSpecialAssets{})
+	defaultCosmosMsgs             []*CosmosMsgIndexed = []*CosmosMsgIndexed{
 		{
 			ProtoMsgName:  MsgNameLiquidate,
 			BlocksIndexed: []*BlockIndexedInterval{},
@@ -20,6 +22,134 @@ var (
 			ProtoMsgName:  MsgNameLeveragedLiquidate,
 			BlocksIndexed: []*BlockIndexedInterval{},
 		},
+		{
+			ProtoMsgName:  MsgNameAggregateExchangeRateVote,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameDelegateFeedConsent,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameBond,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameBeginUnbonding,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameEmergencyUnbond,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameClaim,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameSponsor,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameGovCreatePrograms,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameSwap,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameRedeem,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameGovUpdateQuota,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameGovSetIBCStatus,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameTransfer,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameRecvPacket,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameAcknowledgement,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameTimeout,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameDelegate,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameUndelegate,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameBeginRedelegate,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameCancelUnbondingDelegation,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameWithdrawDelegatorReward,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameWithdrawValidatorCommission,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameGovUpdateRegistry,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameGovUpdateSpecialAssets,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameSubmitProposal,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameDeposit,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameVote,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameVoteWeighted,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameSend,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameMultiSend,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameRawAny,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
+		{
+			ProtoMsgName:  MsgNameBalanceChanges,
+			BlocksIndexed: []*BlockIndexedInterval{},
+		},
 	}
 	_ sort.Interface = BlockIndexedIntervalSorter{}
 )
@@ -30,7 +160,7 @@ type BlockIndexedIntervalSorter []*BlockIndexedInterval
 func DefaultChainInfo(chainID string) *ChainInfo {
 	return &ChainInfo{
 		ChainID:                   chainID,
-		CosmosMsgs:                defaultCosmosMsgs,
+		CosmosMsgs:                MergeCosmosMsgIndexedWithDefaults(),
 		LastBlockHeightReceived:   0,
 		LastBlockTimeUnixReceived: 0,
 	}
@@ -39,7 +169,7 @@ func DefaultChainInfo(chainID string) *ChainInfo {
 // MergeWithDefault merge with the default of chain info if needed.
 func (c *ChainInfo) MergeWithDefault() {
 	if len(c.CosmosMsgs) == 0 {
-		c.CosmosMsgs = defaultCosmosMsgs
+		c.CosmosMsgs = MergeCosmosMsgIndexedWithDefaults()
 	}
 
 	for _, dftCosmoMsg := range defaultCosmosMsgs {
@@ -55,7 +185,7 @@ func (c *ChainInfo) MergeWithDefault() {
 		if defaultExist {
 			continue
 		}
-		c.CosmosMsgs = append(c.CosmosMsgs, dftCosmoMsg)
+		c.CosmosMsgs = append(c.CosmosMsgs, newDefaultCosmosMsg(dftCosmoMsg))
 	}
 }
 
@@ -78,12 +208,21 @@ func MergeCosmosMsgIndexedWithDefaults(msgs ...*CosmosMsgIndexed) []*CosmosMsgIn
 			continue
 		}
 
-		cosmosMsgs = append(cosmosMsgs, dftMsg)
+		cosmosMsgs = append(cosmosMsgs, newDefaultCosmosMsg(dftMsg))
 	}
 
 	return cosmosMsgs
 }
 
+// newDefaultCosmosMsg returns a copy of the default cosmos msg, so the intervals indexed of one chain
+// info are not shared with the others.
+func newDefaultCosmosMsg(dftMsg *CosmosMsgIndexed) *CosmosMsgIndexed {
+	return &CosmosMsgIndexed{
+		ProtoMsgName:  dftMsg.ProtoMsgName,
+		BlocksIndexed: []*BlockIndexedInterval{},
+	}
+}
+
 // MergeWithDefaults loads the defaults of cosmos msgs indexed.
 func (c *ChainInfo) MergeWithDefaults() {
 	c.CosmosMsgs = MergeCosmosMsgIndexedWithDefaults(c.CosmosMsgs...)
@@ -272,6 +411,140 @@ func RemoveFromBlockIndexedInterval(slice []*BlockIndexedInterval, idxToRemove i
 	return append(slice[:idxToRemove], slice[idxToRemove+1:]...)
 }
 
+// MergeBlocksIndexed adds the intervals indexed of the other chain info to the ones of this chain info,
+// so the progress of indexers running apart on the same chain is kept.
+func (c *ChainInfo) MergeBlocksIndexed(other *ChainInfo) {
+	if other == nil {
+		return
+	}
+
+	for _, otherMsg := range other.CosmosMsgs {
+		for _, cosmosMsg := range c.CosmosMsgs {
+			if !strings.EqualFold(otherMsg.ProtoMsgName, cosmosMsg.ProtoMsgName) {
+				continue
+			}
+			cosmosMsg.BlocksIndexed = MergeBlockIndexedIntervals(cosmosMsg.BlocksIndexed, otherMsg.BlocksIndexed)
+			break
+		}
+	}
+}
+
+// MergeBlockIndexedIntervals returns the union of the intervals, sorted and with the overlapping or
+// neighbour intervals joined.
+func MergeBlockIndexedIntervals(a, b []*BlockIndexedInterval) []*BlockIndexedInterval {
+	all := make([]*BlockIndexedInterval, 0, len(a)+len(b))
+	for _, blkIndexed := range append(append([]*BlockIndexedInterval{}, a...), b...) {
+		all = append(all, &BlockIndexedInterval{IdxFromBlockHeight: blkIndexed.IdxFromBlockHeight, IdxToBlockHeight: blkIndexed.IdxToBlockHeight})
+	}
+	sort.Sort(BlockIndexedIntervalSorter(all))
+
+	intervals := make([]*BlockIndexedInterval, 0, len(all))
+	for _, blkIndexed := range all {
+		if len(intervals) > 0 {
+			last := intervals[len(intervals)-1]
+			if blkIndexed.IdxFromBlockHeight <= last.IdxToBlockHeight+1 {
+				last.IdxToBlockHeight = max(last.IdxToBlockHeight, blkIndexed.IdxToBlockHeight)
+				continue
+			}
+		}
+		intervals = append(intervals, blkIndexed)
+	}
+	return intervals
+}
+
+// BlocksNotIndexed returns the amount of block heights from ~ to (inclusive) out of the intervals.
+func BlocksNotIndexed(intervals []*BlockIndexedInterval, fromHeight, toHeight int) int {
+	notIndexed := max(toHeight-fromHeight+1, 0)
+	for _, blkIndexed := range MergeBlockIndexedIntervals(intervals, nil) {
+		from, to := max(blkIndexed.IdxFromBlockHeight, fromHeight), min(blkIndexed.IdxToBlockHeight, toHeight)
+		if from <= to {
+			notIndexed -= to - from + 1
+		}
+	}
+	return notIndexed
+}
+
+// UpdateLastBlockReceived updates the last block received from the chain, keeping the highest one as
+// the indexers of the chain receive the blocks in different orders.
+func (c *ChainInfo) UpdateLastBlockReceived(blkHeight, blkTimeUnix int) {
+	if blkHeight < c.LastBlockHeightReceived {
+		return
+	}
+	c.LastBlockHeightReceived = blkHeight
+	c.LastBlockTimeUnixReceived = blkTimeUnix
+}
+
+// IndexBlockRange adds the block heights from ~ to (inclusive) to the intervals indexed of the msgs, or
+// of all the cosmos msgs if none is given. It returns false if a msg is not one of the cosmos msgs indexed.
+func (c *ChainInfo) IndexBlockRange(msgNames []string, fromHeight, toHeight int) (found bool) {
+	if len(msgNames) == 0 {
+		for _, cosmosMsg := range c.CosmosMsgs {
+			c.IndexBlockRangeForMsg(cosmosMsg.ProtoMsgName, fromHeight, toHeight)
+		}
+		return true
+	}
+
+	for _, msgName := range msgNames {
+		if !c.IndexBlockRangeForMsg(msgName, fromHeight, toHeight) {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexBlockRangeForMsg adds the block heights to the intervals indexed of the msg, so they are
+// never indexed. It returns false if the msg is not one of the cosmos msgs indexed.
+func (c *ChainInfo) IndexBlockRangeForMsg(msgName string, fromHeight, toHeight int) (found bool) {
+	for _, cosmosMsg := range c.CosmosMsgs {
+		if !strings.EqualFold(msgName, cosmosMsg.ProtoMsgName) {
+			continue
+		}
+
+		cosmosMsg.BlocksIndexed = MergeBlockIndexedIntervals(cosmosMsg.BlocksIndexed, []*BlockIndexedInterval{
+			{IdxFromBlockHeight: fromHeight, IdxToBlockHeight: toHeight},
+		})
+		return true
+	}
+	return false
+}
+
+// UnindexBlockRangeForMsg removes the block heights from the intervals indexed of the msg, so they
+// are indexed again. It returns false if the msg is not one of the cosmos msgs indexed.
+func (c *ChainInfo) UnindexBlockRangeForMsg(msgName string, fromHeight, toHeight int) (found bool) {
+	for _, cosmosMsg := range c.CosmosMsgs {
+		if !strings.EqualFold(msgName, cosmosMsg.ProtoMsgName) {
+			continue
+		}
+
+		cosmosMsg.BlocksIndexed = UnindexBlockRangeFromIntervals(cosmosMsg.BlocksIndexed, fromHeight, toHeight)
+		return true
+	}
+	return false
+}
+
+// UnindexBlockRangeFromIntervals returns new intervals without the block heights from ~ to (inclusive),
+// the intervals that contain the range are split in two.
+func UnindexBlockRangeFromIntervals(slice []*BlockIndexedInterval, fromHeight, toHeight int) []*BlockIndexedInterval {
+	intervals := make([]*BlockIndexedInterval, 0, len(slice)+1)
+	for _, blkIndexed := range slice {
+		from, to := blkIndexed.IdxFromBlockHeight, blkIndexed.IdxToBlockHeight
+		if to < fromHeight || from > toHeight { // does not overlap the range
+			intervals = append(intervals, &BlockIndexedInterval{IdxFromBlockHeight: from, IdxToBlockHeight: to})
+			continue
+		}
+
+		if from < fromHeight {
+			intervals = append(intervals, &BlockIndexedInterval{IdxFromBlockHeight: from, IdxToBlockHeight: fromHeight - 1})
+		}
+		if to > toHeight {
+			intervals = append(intervals, &BlockIndexedInterval{IdxFromBlockHeight: toHeight + 1, IdxToBlockHeight: to})
+		}
+	}
+
+	sort.Sort(BlockIndexedIntervalSorter(intervals))
+	return intervals
+}
+
 // LowestBlockHeightToIndex returns the least block height it should start to try to index based on the cosmos msgs already indexed
 func LowestBlockHeightToIndex(cosmosMsgs []*CosmosMsgIndexed, minHeight int) (blockHeight int) {
 	blockHeight = 1    // starts at one
@@ -167,6 +167,143 @@ func TestIndexBlockHeightToInterval(t *testing.T) {
 	}
 }
 
+func TestUnindexBlockRangeFromIntervals(t *testing.T) {
+	tcs := []struct {
+		title     string
+		intervals []*types.BlockIndexedInterval
+		from, to  int
+
+		expected []*types.BlockIndexedInterval
+	}{
+		{
+			"empty, remove 3~4 = empty",
+			[]*types.BlockIndexedInterval{},
+			3, 4,
+			blockIntervals(),
+		},
+		{
+			"3~10, remove 5~6 = 3~4,7~10",
+			blockIntervals(3, 10),
+			5, 6,
+			blockIntervals(3, 4, 7, 10),
+		},
+		{
+			"3~10, remove 3~10 = empty",
+			blockIntervals(3, 10),
+			3, 10,
+			blockIntervals(),
+		},
+		{
+			"3~10, remove 1~4 = 5~10",
+			blockIntervals(3, 10),
+			1, 4,
+			blockIntervals(5, 10),
+		},
+		{
+			"3~10, remove 8~20 = 3~7",
+			blockIntervals(3, 10),
+			8, 20,
+			blockIntervals(3, 7),
+		},
+		{
+			"3~4,6~6,8~10 remove 4~8 = 3~3,9~10",
+			blockIntervals(3, 4, 6, 6, 8, 10),
+			4, 8,
+			blockIntervals(3, 3, 9, 10),
+		},
+		{
+			"3~4,8~10 remove 5~7 = 3~4,8~10",
+			blockIntervals(3, 4, 8, 10),
+			5, 7,
+			blockIntervals(3, 4, 8, 10),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			act := types.UnindexBlockRangeFromIntervals(tc.intervals, tc.from, tc.to)
+			require.Equal(t, tc.expected, act)
+		})
+	}
+}
+
+func TestMergeBlockIndexedIntervals(t *testing.T) {
+	tcs := []struct {
+		title string
+		a, b  []*types.BlockIndexedInterval
+
+		expected []*types.BlockIndexedInterval
+	}{
+		{
+			"empty, empty = empty",
+			blockIntervals(),
+			blockIntervals(),
+			blockIntervals(),
+		},
+		{
+			"3~4, empty = 3~4",
+			blockIntervals(3, 4),
+			blockIntervals(),
+			blockIntervals(3, 4),
+		},
+		{
+			"3~4, 5~10 = 3~10",
+			blockIntervals(3, 4),
+			blockIntervals(5, 10),
+			blockIntervals(3, 10),
+		},
+		{
+			"3~4,12~15, 6~10 = 3~4,6~10,12~15",
+			blockIntervals(3, 4, 12, 15),
+			blockIntervals(6, 10),
+			blockIntervals(3, 4, 6, 10, 12, 15),
+		},
+		{
+			"3~8,12~15, 6~13 = 3~15",
+			blockIntervals(3, 8, 12, 15),
+			blockIntervals(6, 13),
+			blockIntervals(3, 15),
+		},
+		{
+			"3~20, 6~13 = 3~20",
+			blockIntervals(3, 20),
+			blockIntervals(6, 13),
+			blockIntervals(3, 20),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			act := types.MergeBlockIndexedIntervals(tc.a, tc.b)
+			require.Equal(t, tc.expected, act)
+		})
+	}
+}
+
+func TestBlocksNotIndexed(t *testing.T) {
+	tcs := []struct {
+		title     string
+		intervals []*types.BlockIndexedInterval
+		from, to  int
+
+		expected int
+	}{
+		{"empty, 1~10 = 10", blockIntervals(), 1, 10, 10},
+		{"3~4,8~20, 1~10 = 5", blockIntervals(3, 4, 8, 20), 1, 10, 5},
+		{"1~4,3~12, 5~10 = 0", blockIntervals(1, 4, 3, 12), 5, 10, 0},
+		{"1~4, 10~5 = 0", blockIntervals(1, 4), 10, 5, 0},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.BlocksNotIndexed(tc.intervals, tc.from, tc.to))
+		})
+	}
+}
+
 func msgCosmosLiquidate(fromTos ...int) (msg *types.CosmosMsgIndexed) {
 	return msgCosmos(types.MsgNameLiquidate, fromTos...)
 }
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	DeadLetterHandlerDecodeTx     = "DecodeTx"
+	DeadLetterHandlerMsg          = "HandleMsg"
+	DeadLetterHandlerOracleMisses = "HandleOracleMisses"
+	DeadLetterHandlerGovEndBlock  = "HandleGovEndBlock"
+	DeadLetterHandlerBalances     = "HandleBalanceChanges"
+)
+
+// ErrTxFailed is returned when the tx failed on chain, it is not an indexing failure.
+var ErrTxFailed = errors.New("tx failed")
+
+// DeadLetterDocID returns the doc id of the dead letter, the same failure always has the same id.
+func DeadLetterDocID(blkHeight int, handler, txHash, msgPath string) string {
+	if txHash == "" {
+		return fmt.Sprintf("%d-%s", blkHeight, handler)
+	}
+	return fmt.Sprintf("%d-%s-%s-%s", blkHeight, txHash, handler, msgPath)
+}
+
+// NewDeadLetter creates the dead letter of a failure, the tx is nil for the block handlers
+// and the msg path is only informed for msg failures.
+func NewDeadLetter(blkHeight, blockTimeUnix int, handler string, tmTx tmtypes.Tx, msgPath, protoMsgName string, err error) DeadLetter {
+	txHash, tx := "", ""
+	if len(tmTx) > 0 {
+		txHash = hex.EncodeToString(tmTx.Hash())
+		tx = base64.StdEncoding.EncodeToString(tmTx)
+	}
+
+	return DeadLetter{
+		ID:            DeadLetterDocID(blkHeight, handler, txHash, msgPath),
+		BlockHeight:   blkHeight,
+		BlockTimeUnix: blockTimeUnix,
+		Handler:       handler,
+		TxHash:        txHash,
+		Tx:            tx,
+		MsgPath:       msgPath,
+		ProtoMsgName:  protoMsgName,
+		Error:         err.Error(),
+		Attempts:      1,
+		FailedAtUnix:  int(time.Now().Unix()),
+	}
+}
+
+// DeadLetterTx decodes the tx bytes of the dead letter.
+func DeadLetterTx(letter DeadLetter) (tmtypes.Tx, error) {
+	return base64.StdEncoding.DecodeString(letter.Tx)
+}
+
+// MergeDeadLetter keeps the last failure, counting the attempts of the stored dead letter.
+func MergeDeadLetter(stored *DeadLetter, update DeadLetter) DeadLetter {
+	if stored != nil {
+		update.Attempts += stored.Attempts
+	}
+	return update
+}
+
+// DeadLetterCounts counts the dead letters by handler and proto msg name.
+func DeadLetterCounts(letters []*DeadLetter) []*DeadLetterCount {
+	type key struct{ handler, protoMsgName string }
+	counts := make(map[key]*DeadLetterCount)
+	for _, letter := range letters {
+		k := key{letter.Handler, letter.ProtoMsgName}
+		count, ok := counts[k]
+		if !ok {
+			count = &DeadLetterCount{Handler: letter.Handler, ProtoMsgName: letter.ProtoMsgName}
+			counts[k] = count
+		}
+		count.Count++
+	}
+
+	sorted := make([]*DeadLetterCount, 0, len(counts))
+	for _, count := range counts {
+		sorted = append(sorted, count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Handler != sorted[j].Handler {
+			return sorted[i].Handler < sorted[j].Handler
+		}
+		return sorted[i].ProtoMsgName < sorted[j].ProtoMsgName
+	})
+	return sorted
+}
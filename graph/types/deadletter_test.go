@@ -0,0 +1,36 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestDeadLetter(t *testing.T) {
+	tmTx := tmtypes.Tx("tx bytes")
+	letter := types.NewDeadLetter(10, 100, types.DeadLetterHandlerMsg, tmTx, "0.1", "cosmos.bank.v1beta1.MsgSend", errors.New("db down"))
+	require.Equal(t, "10-"+letter.TxHash+"-HandleMsg-0.1", letter.ID)
+
+	decoded, err := types.DeadLetterTx(letter)
+	require.NoError(t, err)
+	require.Equal(t, tmTx, decoded)
+
+	blockLetter := types.NewDeadLetter(10, 100, types.DeadLetterHandlerGovEndBlock, nil, "", "", errors.New("node down"))
+	require.Equal(t, "10-HandleGovEndBlock", blockLetter.ID)
+	require.Empty(t, blockLetter.Tx)
+
+	retry := types.NewDeadLetter(10, 100, types.DeadLetterHandlerMsg, tmTx, "0.1", "cosmos.bank.v1beta1.MsgSend", errors.New("timeout"))
+	merged := types.MergeDeadLetter(&letter, retry)
+	require.Equal(t, 2, merged.Attempts)
+	require.Equal(t, "timeout", merged.Error)
+	require.Equal(t, 1, types.MergeDeadLetter(nil, retry).Attempts)
+
+	counts := types.DeadLetterCounts([]*types.DeadLetter{&letter, &blockLetter, &retry})
+	require.Equal(t, []*types.DeadLetterCount{
+		{Handler: types.DeadLetterHandlerGovEndBlock, Count: 1},
+		{Handler: types.DeadLetterHandlerMsg, ProtoMsgName: "cosmos.bank.v1beta1.MsgSend", Count: 2},
+	}, counts)
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// EventAttr returns the value of the attribute key inside of the event, empty if not found.
+func EventAttr(evt abcitypes.Event, key string) string {
+	for _, attr := range evt.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// MsgEvents returns the events emitted by the msg at the index of the tx. The events of each msg start with
+// the message event holding its action, so the events of the ante handler and of the other msgs are skipped.
+func MsgEvents(events []abcitypes.Event, msgIndex int) []abcitypes.Event {
+	start, n := -1, -1
+	for idx, evt := range events {
+		if evt.Type != sdktypes.EventTypeMessage || EventAttr(evt, sdktypes.AttributeKeyAction) == "" {
+			continue
+		}
+		n++
+		if n == msgIndex {
+			start = idx
+			continue
+		}
+		if n == msgIndex+1 {
+			return events[start:idx]
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	return events[start:]
+}
+
+// CoinsTransferred sums the coins of the transfer events filtering by sender and recipient,
+// an empty sender or recipient matches any address.
+func CoinsTransferred(events []abcitypes.Event, sender, recipient string) sdktypes.Coins {
+	total := sdktypes.NewCoins()
+	for _, evt := range events {
+		if evt.Type != banktypes.EventTypeTransfer {
+			continue
+		}
+		if sender != "" && EventAttr(evt, banktypes.AttributeKeySender) != sender {
+			continue
+		}
+		if recipient != "" && EventAttr(evt, banktypes.AttributeKeyRecipient) != recipient {
+			continue
+		}
+
+		coins, err := sdktypes.ParseCoinsNormalized(EventAttr(evt, sdktypes.AttributeKeyAmount))
+		if err != nil {
+			continue
+		}
+		total = total.Add(coins...)
+	}
+	return total
+}
+
+// TypedEvents returns the events emitted as typed events (proto messages), the others are ignored.
+func TypedEvents(events []abcitypes.Event) []proto.Message {
+	msgs := make([]proto.Message, 0)
+	for _, evt := range events {
+		msg, err := sdktypes.ParseTypedEvent(evt)
+		if err != nil {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
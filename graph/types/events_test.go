@@ -0,0 +1,65 @@
+package types_test
+
+import (
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestMsgEvents(t *testing.T) {
+	const (
+		module  = "umee1incentivemodule"
+		account = "umee1account"
+	)
+	event := func(eventType string, kv ...string) abcitypes.Event {
+		evt := abcitypes.Event{Type: eventType}
+		for idx := 0; idx < len(kv); idx += 2 {
+			evt.Attributes = append(evt.Attributes, abcitypes.EventAttribute{Key: kv[idx], Value: kv[idx+1]})
+		}
+		return evt
+	}
+	transfer := func(sender, recipient, amount string) abcitypes.Event {
+		return event("transfer", "recipient", recipient, "sender", sender, "amount", amount)
+	}
+
+	// a tx bonding, claiming twice and sponsoring, each msg pays rewards from the incentive module.
+	events := []abcitypes.Event{
+		// the fee paid in the ante handler, the bank module emits message events without action.
+		transfer(account, "umee1feecollector", "100uumee"),
+		event("message", "sender", account),
+		event("message", "action", "/umee.incentive.v1.MsgBond", "sender", account, "module", "incentive"),
+		transfer(module, account, "5uumee"),
+		event("message", "sender", module),
+		event("message", "action", "/umee.incentive.v1.MsgClaim", "sender", account, "module", "incentive"),
+		transfer(module, account, "7uumee"),
+		event("message", "action", "/umee.incentive.v1.MsgClaim", "sender", account, "module", "incentive"),
+		transfer(module, account, "11uumee,2uatom"),
+		event("message", "action", "/umee.incentive.v1.MsgSponsor", "sender", account, "module", "incentive"),
+		transfer(account, module, "1000uumee"),
+	}
+	require.Equal(t, "2uatom,23uumee", types.CoinsTransferred(events, module, account).String(), "the whole tx")
+
+	tcs := []struct {
+		title     string
+		msgIndex  int
+		rewards   string
+		sponsored string
+	}{
+		{title: "bond", msgIndex: 0, rewards: "5uumee"},
+		{title: "first claim", msgIndex: 1, rewards: "7uumee"},
+		{title: "second claim", msgIndex: 2, rewards: "2uatom,11uumee"},
+		{title: "sponsor", msgIndex: 3, sponsored: "1000uumee"},
+		{title: "msg not in the tx", msgIndex: 4},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			msgEvents := types.MsgEvents(events, tc.msgIndex)
+			require.Equal(t, tc.rewards, types.CoinsTransferred(msgEvents, module, account).String())
+			require.Equal(t, tc.sponsored, types.CoinsTransferred(msgEvents, account, module).String())
+		})
+	}
+}
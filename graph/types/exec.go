@@ -0,0 +1,47 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/gogoproto/proto"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+)
+
+var MsgNameExec = proto.MessageName(&authz.MsgExec{})
+
+// InnerMsgPath returns the nesting path of the msg at the index inside of the parent msg.
+func InnerMsgPath(parentPath string, index int) string {
+	return fmt.Sprintf("%s.%d", parentPath, index)
+}
+
+// RootMsgIndex returns the index in the tx of the msg at the root of the nesting path.
+func RootMsgIndex(path string) (int, error) {
+	root, _, _ := strings.Cut(path, ".")
+	idx, err := strconv.Atoi(root)
+	if err != nil {
+		return 0, fmt.Errorf("invalid msg path %s: %w", path, err)
+	}
+	return idx, nil
+}
+
+// NewMsgExecution returns the execution context of an inner msg.
+func NewMsgExecution(path, wrapper, grantee, granter string) *MsgExecution {
+	return &MsgExecution{
+		Path:    path,
+		Wrapper: wrapper,
+		Grantee: grantee,
+		Granter: granter,
+	}
+}
+
+// ICAControllerOwner returns the owner of the interchain account from the controller port ID,
+// empty if the port is not an interchain accounts controller port.
+func ICAControllerOwner(portID string) string {
+	if !strings.HasPrefix(portID, icatypes.ControllerPortPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(portID, icatypes.ControllerPortPrefix)
+}
@@ -0,0 +1,29 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestICAControllerOwner(t *testing.T) {
+	require.Equal(t, "osmo1owner", types.ICAControllerOwner("icacontroller-osmo1owner"))
+	require.Equal(t, "", types.ICAControllerOwner("transfer"))
+}
+
+func TestInnerMsgPath(t *testing.T) {
+	require.Equal(t, "0.2", types.InnerMsgPath("0", 2))
+	require.Equal(t, "1.0.3", types.InnerMsgPath(types.InnerMsgPath("1", 0), 3))
+}
+
+func TestRootMsgIndex(t *testing.T) {
+	idx, err := types.RootMsgIndex("2")
+	require.NoError(t, err)
+	require.Equal(t, 2, idx)
+	idx, err = types.RootMsgIndex("1.0.3")
+	require.NoError(t, err)
+	require.Equal(t, 1, idx)
+	_, err = types.RootMsgIndex("")
+	require.ErrorContains(t, err, "invalid msg path")
+}
@@ -0,0 +1,342 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/incentive"
+	lvgtypes "github.com/umee-network/umee/v6/x/leverage/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+)
+
+var (
+	MsgNameSubmitProposal = proto.MessageName(&govv1.MsgSubmitProposal{})
+	MsgNameDeposit        = proto.MessageName(&govv1.MsgDeposit{})
+	MsgNameVote           = proto.MessageName(&govv1.MsgVote{})
+	MsgNameVoteWeighted   = proto.MessageName(&govv1.MsgVoteWeighted{})
+
+	// govStatusOrder is the order of the proposal status, a status never goes back.
+	govStatusOrder = map[string]int{
+		govv1.StatusNil.String():           0,
+		govv1.StatusDepositPeriod.String(): 1,
+		govv1.StatusVotingPeriod.String():  2,
+		govv1.StatusPassed.String():        3,
+		govv1.StatusRejected.String():      3,
+		govv1.StatusFailed.String():        3,
+	}
+
+	// govResultStatus maps the proposal result emitted at the end block to the proposal status.
+	// Dropped proposals are deleted from the chain, they are stored as failed.
+	govResultStatus = map[string]string{
+		govtypes.AttributeValueProposalPassed:   govv1.StatusPassed.String(),
+		govtypes.AttributeValueProposalRejected: govv1.StatusRejected.String(),
+		govtypes.AttributeValueProposalFailed:   govv1.StatusFailed.String(),
+		govtypes.AttributeValueProposalDropped:  govv1.StatusFailed.String(),
+	}
+)
+
+// GovProposalResult is the result of a proposal emitted at the end block.
+type GovProposalResult struct {
+	ProposalID int
+	Status     string
+	// VotingEnded is false for proposals dropped in the deposit period.
+	VotingEnded bool
+}
+
+// ParseGovProposalMsg transpiles the msg executed by a proposal, content is the msg encoded as JSON.
+func ParseGovProposalMsg(msg sdktypes.Msg, content string) *GovProposalMsg {
+	parsed := &GovProposalMsg{
+		ProtoMsgName: proto.MessageName(msg),
+		Content:      content,
+	}
+
+	switch m := msg.(type) {
+	case *lvgtypes.MsgGovUpdateRegistry:
+		registry := ParseTxGovUpdateRegistry(m)
+		parsed.MsgGovUpdateRegistry = &registry
+	case *lvgtypes.MsgGovUpdateSpecialAssets:
+		special := ParseTxGovUpdateSpecialAssets(m)
+		parsed.MsgGovUpdateSpecialAssets = &special
+	case *uibc.MsgGovUpdateQuota:
+		quota := ParseTxGovUpdateQuota(m)
+		parsed.MsgGovUpdateQuota = &quota
+	case *uibc.MsgGovSetIBCStatus:
+		status := ParseTxGovSetIBCStatus(m)
+		parsed.MsgGovSetIBCStatus = &status
+	case *incentive.MsgGovCreatePrograms:
+		programs := ParseTxGovCreatePrograms(m)
+		parsed.MsgGovCreatePrograms = &programs
+	}
+	return parsed
+}
+
+// ParseTxSubmitProposal gets a gov tx msg and transpile to the graphql one.
+func ParseTxSubmitProposal(msg *govv1.MsgSubmitProposal, proposalID int, msgs []*GovProposalMsg) MsgSubmitProposal {
+	msgNames := make([]string, len(msgs))
+	for i, m := range msgs {
+		msgNames[i] = m.ProtoMsgName
+	}
+
+	return MsgSubmitProposal{
+		ProposalID:     proposalID,
+		Proposer:       msg.Proposer,
+		Title:          msg.Title,
+		InitialDeposit: sdktypes.NewCoins(msg.InitialDeposit...).String(),
+		MsgNames:       msgNames,
+	}
+}
+
+// NewGovProposal creates the proposal from the submitted msg, the status depends if the voting started in the tx.
+func NewGovProposal(msg *govv1.MsgSubmitProposal, proposalID int, msgs []*GovProposalMsg, votingStarted bool, blockHeight, blockTimeUnix int, txHash string) GovProposal {
+	changes := []*GovStatusChange{{Status: govv1.StatusDepositPeriod.String(), BlockHeight: blockHeight, BlockTimeUnix: blockTimeUnix}}
+	if votingStarted {
+		changes = append(changes, &GovStatusChange{Status: govv1.StatusVotingPeriod.String(), BlockHeight: blockHeight, BlockTimeUnix: blockTimeUnix})
+	}
+
+	return GovProposal{
+		ProposalID:        proposalID,
+		Proposer:          msg.Proposer,
+		Title:             msg.Title,
+		Summary:           msg.Summary,
+		Metadata:          msg.Metadata,
+		Messages:          msgs,
+		Status:            changes[len(changes)-1].Status,
+		StatusChanges:     changes,
+		TotalDeposit:      sdktypes.NewCoins(msg.InitialDeposit...).String(),
+		SubmitTxHash:      txHash,
+		SubmitBlockHeight: blockHeight,
+		SubmitTimeUnix:    blockTimeUnix,
+	}
+}
+
+// NewGovProposalDeposit returns the proposal update of a deposit, the amount is added to the total deposit.
+func NewGovProposalDeposit(msg *govv1.MsgDeposit, votingStarted bool, blockHeight, blockTimeUnix int) GovProposal {
+	update := GovProposal{
+		ProposalID:    int(msg.ProposalId),
+		Status:        govv1.StatusDepositPeriod.String(),
+		Messages:      []*GovProposalMsg{},
+		StatusChanges: []*GovStatusChange{},
+		TotalDeposit:  sdktypes.NewCoins(msg.Amount...).String(),
+	}
+	if votingStarted {
+		update.Status = govv1.StatusVotingPeriod.String()
+		update.StatusChanges = append(update.StatusChanges, &GovStatusChange{Status: update.Status, BlockHeight: blockHeight, BlockTimeUnix: blockTimeUnix})
+	}
+	return update
+}
+
+// NewGovProposalResult returns the proposal update of the result emitted at the end block.
+func NewGovProposalResult(result GovProposalResult, finalTally *GovTally, blockHeight, blockTimeUnix int) GovProposal {
+	return GovProposal{
+		ProposalID:    result.ProposalID,
+		Messages:      []*GovProposalMsg{},
+		Status:        result.Status,
+		StatusChanges: []*GovStatusChange{{Status: result.Status, BlockHeight: blockHeight, BlockTimeUnix: blockTimeUnix}},
+		FinalTally:    finalTally,
+	}
+}
+
+// ParseTxDeposit gets a gov tx msg and transpile to the graphql one.
+func ParseTxDeposit(msg *govv1.MsgDeposit, votingStarted bool) MsgDeposit {
+	return MsgDeposit{
+		ProposalID:    int(msg.ProposalId),
+		Depositor:     msg.Depositor,
+		Amount:        sdktypes.NewCoins(msg.Amount...).String(),
+		VotingStarted: votingStarted,
+	}
+}
+
+// ParseTxVote gets a gov tx msg and transpile to the graphql one.
+func ParseTxVote(msg *govv1.MsgVote) MsgVote {
+	return MsgVote{
+		ProposalID: int(msg.ProposalId),
+		Voter:      msg.Voter,
+		Option:     msg.Option.String(),
+		Metadata:   msg.Metadata,
+	}
+}
+
+// ParseTxVoteWeighted gets a gov tx msg and transpile to the graphql one.
+func ParseTxVoteWeighted(msg *govv1.MsgVoteWeighted) MsgVoteWeighted {
+	options := make([]*GovWeightedOption, len(msg.Options))
+	for i, opt := range msg.Options {
+		options[i] = &GovWeightedOption{Option: opt.Option.String(), Weight: opt.Weight}
+	}
+
+	return MsgVoteWeighted{
+		ProposalID: int(msg.ProposalId),
+		Voter:      msg.Voter,
+		Options:    options,
+		Metadata:   msg.Metadata,
+	}
+}
+
+// ParseGovTally transpiles the tally result of the chain.
+func ParseGovTally(tally *govv1.TallyResult) *GovTally {
+	if tally == nil {
+		return nil
+	}
+	return &GovTally{
+		Yes:        tally.YesCount,
+		Abstain:    tally.AbstainCount,
+		No:         tally.NoCount,
+		NoWithVeto: tally.NoWithVetoCount,
+	}
+}
+
+// SubmittedProposalID returns the id of the proposal submitted with the msgs from the tx events.
+func SubmittedProposalID(events []abcitypes.Event, msgs []sdktypes.Msg) (int, error) {
+	msgsStr := ""
+	for _, msg := range msgs {
+		msgsStr += fmt.Sprintf(",%s", sdktypes.MsgTypeURL(msg))
+	}
+
+	for _, evt := range events {
+		if evt.Type != govtypes.EventTypeSubmitProposal || EventAttr(evt, govtypes.AttributeKeyProposalMessages) != msgsStr {
+			continue
+		}
+		return strconv.Atoi(EventAttr(evt, govtypes.AttributeKeyProposalID))
+	}
+	return 0, fmt.Errorf("submit_proposal event not found for msgs %q", msgsStr)
+}
+
+// GovVotingStarted returns true if the tx events have the start of the voting period of the proposal.
+func GovVotingStarted(events []abcitypes.Event, proposalID int) bool {
+	id := strconv.Itoa(proposalID)
+	for _, evt := range events {
+		if evt.Type != govtypes.EventTypeSubmitProposal && evt.Type != govtypes.EventTypeProposalDeposit {
+			continue
+		}
+		if EventAttr(evt, govtypes.AttributeKeyVotingPeriodStart) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GovProposalResults returns the results of the proposals emitted in the end block events.
+func GovProposalResults(endBlockEvents []abcitypes.Event) []GovProposalResult {
+	results := make([]GovProposalResult, 0)
+	for _, evt := range endBlockEvents {
+		if evt.Type != govtypes.EventTypeActiveProposal && evt.Type != govtypes.EventTypeInactiveProposal {
+			continue
+		}
+
+		status, ok := govResultStatus[EventAttr(evt, govtypes.AttributeKeyProposalResult)]
+		if !ok {
+			continue
+		}
+		proposalID, err := strconv.Atoi(EventAttr(evt, govtypes.AttributeKeyProposalID))
+		if err != nil {
+			continue
+		}
+
+		results = append(results, GovProposalResult{
+			ProposalID:  proposalID,
+			Status:      status,
+			VotingEnded: evt.Type == govtypes.EventTypeActiveProposal,
+		})
+	}
+	return results
+}
+
+// MergeGovProposal merges the proposal already stored with an update (submit, deposit or result).
+// The updates can be indexed in any order, the status only moves forward and deposits are summed.
+func MergeGovProposal(stored *GovProposal, update GovProposal) (GovProposal, error) {
+	if stored == nil {
+		return update, nil
+	}
+
+	merged := *stored
+	if govStatusOrder[update.Status] > govStatusOrder[merged.Status] {
+		merged.Status = update.Status
+	}
+	if update.SubmitTxHash != "" {
+		merged.Proposer = update.Proposer
+		merged.Title = update.Title
+		merged.Summary = update.Summary
+		merged.Metadata = update.Metadata
+		merged.Messages = update.Messages
+		merged.SubmitTxHash = update.SubmitTxHash
+		merged.SubmitBlockHeight = update.SubmitBlockHeight
+		merged.SubmitTimeUnix = update.SubmitTimeUnix
+	}
+	if update.FinalTally != nil {
+		merged.FinalTally = update.FinalTally
+	}
+
+	storedDeposit, err := sdktypes.ParseCoinsNormalized(stored.TotalDeposit)
+	if err != nil {
+		return merged, err
+	}
+	deposit, err := sdktypes.ParseCoinsNormalized(update.TotalDeposit)
+	if err != nil {
+		return merged, err
+	}
+	merged.TotalDeposit = storedDeposit.Add(deposit...).String()
+
+	changes := make([]*GovStatusChange, 0, len(stored.StatusChanges)+len(update.StatusChanges))
+	seen := make(map[string]bool)
+	for _, change := range append(append([]*GovStatusChange{}, stored.StatusChanges...), update.StatusChanges...) {
+		if seen[change.Status] {
+			continue
+		}
+		seen[change.Status] = true
+		changes = append(changes, change)
+		if govStatusOrder[change.Status] > govStatusOrder[merged.Status] {
+			merged.Status = change.Status
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return govStatusOrder[changes[i].Status] < govStatusOrder[changes[j].Status]
+	})
+	merged.StatusChanges = changes
+	return merged, nil
+}
+
+// GovVoteCounts counts the voters by option from the votes of a proposal, only the last vote of each voter counts.
+func GovVoteCounts(txs []*IndexedTx) ([]*GovVoteCount, error) {
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].BlockHeight < txs[j].BlockHeight
+	})
+
+	lastVote := make(map[string][]*GovWeightedOption)
+	for _, tx := range txs {
+		switch {
+		case tx.MsgVote != nil:
+			lastVote[tx.MsgVote.Voter] = []*GovWeightedOption{{Option: tx.MsgVote.Option, Weight: sdktypes.OneDec().String()}}
+		case tx.MsgVoteWeighted != nil:
+			lastVote[tx.MsgVoteWeighted.Voter] = tx.MsgVoteWeighted.Options
+		}
+	}
+
+	counts := make(map[string]sdktypes.Dec)
+	for _, options := range lastVote {
+		for _, opt := range options {
+			weight, err := sdktypes.NewDecFromStr(opt.Weight)
+			if err != nil {
+				return nil, err
+			}
+			if count, ok := counts[opt.Option]; ok {
+				weight = weight.Add(count)
+			}
+			counts[opt.Option] = weight
+		}
+	}
+
+	voteCounts := make([]*GovVoteCount, 0, len(counts))
+	for option, count := range counts {
+		voteCounts = append(voteCounts, &GovVoteCount{Option: option, Voters: count.String()})
+	}
+	sort.Slice(voteCounts, func(i, j int) bool {
+		return strings.Compare(voteCounts[i].Option, voteCounts[j].Option) < 0
+	})
+	return voteCounts, nil
+}
@@ -0,0 +1,70 @@
+package types_test
+
+import (
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestGovProposalResults(t *testing.T) {
+	evt := func(evtType, id, result string) abcitypes.Event {
+		return abcitypes.Event{Type: evtType, Attributes: []abcitypes.EventAttribute{
+			{Key: "proposal_id", Value: id},
+			{Key: "proposal_result", Value: result},
+		}}
+	}
+
+	results := types.GovProposalResults([]abcitypes.Event{
+		evt("active_proposal", "7", "proposal_passed"),
+		evt("inactive_proposal", "8", "proposal_dropped"),
+		evt("transfer", "9", "proposal_passed"),
+	})
+	require.Equal(t, []types.GovProposalResult{
+		{ProposalID: 7, Status: govv1.StatusPassed.String(), VotingEnded: true},
+		{ProposalID: 8, Status: govv1.StatusFailed.String(), VotingEnded: false},
+	}, results)
+}
+
+func TestMergeGovProposal(t *testing.T) {
+	passed := types.NewGovProposalResult(types.GovProposalResult{ProposalID: 3, Status: govv1.StatusPassed.String(), VotingEnded: true},
+		&types.GovTally{Yes: "100"}, 50, 500)
+	deposit := types.NewGovProposalDeposit(&govv1.MsgDeposit{ProposalId: 3}, true, 20, 200)
+	deposit.TotalDeposit = "10uumee"
+
+	// the result was indexed before the deposit, the status does not go back.
+	merged, err := types.MergeGovProposal(&passed, deposit)
+	require.NoError(t, err)
+	require.Equal(t, govv1.StatusPassed.String(), merged.Status)
+	require.Equal(t, "10uumee", merged.TotalDeposit)
+	require.Equal(t, "100", merged.FinalTally.Yes)
+	require.Len(t, merged.StatusChanges, 2)
+	require.Equal(t, govv1.StatusVotingPeriod.String(), merged.StatusChanges[0].Status)
+
+	merged, err = types.MergeGovProposal(&merged, deposit)
+	require.NoError(t, err)
+	require.Equal(t, "20uumee", merged.TotalDeposit)
+	require.Len(t, merged.StatusChanges, 2)
+}
+
+func TestGovVoteCounts(t *testing.T) {
+	yes, no := govv1.OptionYes.String(), govv1.OptionNo.String()
+	txs := []*types.IndexedTx{
+		{BlockHeight: 2, MsgVote: &types.MsgVote{Voter: "a", Option: no}},
+		{BlockHeight: 1, MsgVote: &types.MsgVote{Voter: "a", Option: yes}},
+		{BlockHeight: 3, MsgVoteWeighted: &types.MsgVoteWeighted{Voter: "b", Options: []*types.GovWeightedOption{
+			{Option: yes, Weight: "0.25"},
+			{Option: no, Weight: "0.75"},
+		}}},
+	}
+
+	counts, err := types.GovVoteCounts(txs)
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+	require.Equal(t, no, counts[0].Option)
+	require.Equal(t, "1.750000000000000000", counts[0].Voters)
+	require.Equal(t, yes, counts[1].Option)
+	require.Equal(t, "0.250000000000000000", counts[1].Voters)
+}
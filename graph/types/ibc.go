@@ -0,0 +1,216 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+)
+
+const (
+	IBCDirectionOutgoing = "outgoing"
+	IBCDirectionIncoming = "incoming"
+
+	IBCStatusPending  = "pending"
+	IBCStatusReceived = "received"
+	IBCStatusAcked    = "acked"
+	IBCStatusTimedOut = "timed_out"
+)
+
+var (
+	MsgNameTransfer        = proto.MessageName(&transfertypes.MsgTransfer{})
+	MsgNameRecvPacket      = proto.MessageName(&channeltypes.MsgRecvPacket{})
+	MsgNameAcknowledgement = proto.MessageName(&channeltypes.MsgAcknowledgement{})
+	MsgNameTimeout         = proto.MessageName(&channeltypes.MsgTimeout{})
+
+	// ibcStatusOrder is the order of the status in the packet lifecycle, a status never goes back.
+	ibcStatusOrder = map[string]int{
+		IBCStatusPending:  0,
+		IBCStatusReceived: 1,
+		IBCStatusAcked:    2,
+		IBCStatusTimedOut: 2,
+	}
+)
+
+// IBCTransferDocID returns the identifier of the transfer, unique by direction, source channel and sequence.
+func IBCTransferDocID(direction, sourcePort, sourceChannel string, sequence int) string {
+	return fmt.Sprintf("%s-%s-%s-%d", direction, sourcePort, sourceChannel, sequence)
+}
+
+// ParseTxTransfer creates the outgoing transfer from the msg and the send_packet event emitted for it.
+// The denom trace is expected to be already resolved from the ibc/HASH denom.
+func ParseTxTransfer(msg *transfertypes.MsgTransfer, events []abcitypes.Event, trace transfertypes.DenomTrace, step IBCTransferStep) (IBCTransfer, error) {
+	for _, evt := range events {
+		if evt.Type != channeltypes.EventTypeSendPacket ||
+			EventAttr(evt, channeltypes.AttributeKeySrcPort) != msg.SourcePort ||
+			EventAttr(evt, channeltypes.AttributeKeySrcChannel) != msg.SourceChannel {
+			continue
+		}
+
+		var data transfertypes.FungibleTokenPacketData
+		if err := transfertypes.ModuleCdc.UnmarshalJSON([]byte(EventAttr(evt, channeltypes.AttributeKeyData)), &data); err != nil {
+			continue
+		}
+		if data.Sender != msg.Sender || data.Receiver != msg.Receiver || data.Amount != msg.Token.Amount.String() {
+			continue
+		}
+
+		sequence, err := strconv.Atoi(EventAttr(evt, channeltypes.AttributeKeySequence))
+		if err != nil {
+			return IBCTransfer{}, err
+		}
+
+		step.Status = IBCStatusPending
+		return IBCTransfer{
+			Direction:          IBCDirectionOutgoing,
+			Status:             IBCStatusPending,
+			Sequence:           sequence,
+			SourcePort:         msg.SourcePort,
+			SourceChannel:      msg.SourceChannel,
+			DestinationPort:    EventAttr(evt, channeltypes.AttributeKeyDstPort),
+			DestinationChannel: EventAttr(evt, channeltypes.AttributeKeyDstChannel),
+			Sender:             msg.Sender,
+			Receiver:           msg.Receiver,
+			Denom:              msg.Token.Denom,
+			Amount:             msg.Token.Amount.String(),
+			DenomTrace:         &IBCDenomTrace{Path: trace.Path, BaseDenom: trace.BaseDenom},
+			Memo:               msg.Memo,
+			Steps:              []*IBCTransferStep{&step},
+			LastBlockTimeUnix:  step.BlockTimeUnix,
+			Execution:          step.Execution,
+		}, nil
+	}
+	return IBCTransfer{}, fmt.Errorf("send_packet event not found for transfer from %s on %s", msg.Sender, msg.SourceChannel)
+}
+
+// ParsePacketTransfer creates the transfer from the packet of a relayed msg (recv, acknowledgement or timeout).
+// It returns false if the packet is not a fungible token transfer.
+func ParsePacketTransfer(packet channeltypes.Packet, direction, status string, step IBCTransferStep) (IBCTransfer, bool) {
+	if packet.SourcePort != transfertypes.PortID && packet.DestinationPort != transfertypes.PortID {
+		return IBCTransfer{}, false
+	}
+
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return IBCTransfer{}, false
+	}
+
+	trace := UmeeDenomTrace(packet, direction, data.Denom)
+	step.Status = status
+	// the acknowledgements and timeouts of outgoing transfers are relayed, the tokens were moved by the MsgTransfer.
+	var exec *MsgExecution
+	if status == IBCStatusReceived {
+		exec = step.Execution
+	}
+	return IBCTransfer{
+		Direction:          direction,
+		Status:             status,
+		Sequence:           int(packet.Sequence),
+		SourcePort:         packet.SourcePort,
+		SourceChannel:      packet.SourceChannel,
+		DestinationPort:    packet.DestinationPort,
+		DestinationChannel: packet.DestinationChannel,
+		Sender:             data.Sender,
+		Receiver:           data.Receiver,
+		Denom:              trace.IBCDenom(),
+		Amount:             data.Amount,
+		DenomTrace:         &IBCDenomTrace{Path: trace.Path, BaseDenom: trace.BaseDenom},
+		Memo:               data.Memo,
+		Steps:              []*IBCTransferStep{&step},
+		LastBlockTimeUnix:  step.BlockTimeUnix,
+		Execution:          exec,
+	}, true
+}
+
+// UmeeDenomTrace returns the denom trace of the packet token as it is known by umee.
+// Outgoing packets carry the full denom path of umee, incoming ones the path of the sender chain.
+func UmeeDenomTrace(packet channeltypes.Packet, direction, packetDenom string) transfertypes.DenomTrace {
+	if direction == IBCDirectionOutgoing {
+		return transfertypes.ParseDenomTrace(packetDenom)
+	}
+
+	if transfertypes.ReceiverChainIsSource(packet.SourcePort, packet.SourceChannel, packetDenom) {
+		// the token is returning to umee, removes the prefix added by the sender chain.
+		unprefixed := packetDenom[len(transfertypes.GetDenomPrefix(packet.SourcePort, packet.SourceChannel)):]
+		return transfertypes.ParseDenomTrace(unprefixed)
+	}
+	return transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(packet.DestinationPort, packet.DestinationChannel, packetDenom))
+}
+
+// AckError returns the error of the acknowledgement, empty if the packet was successfully received.
+func AckError(acknowledgement []byte) string {
+	var ack channeltypes.Acknowledgement
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return ""
+	}
+	return ack.GetError()
+}
+
+// PacketExecuted returns true if the acknowledgement written for the packet received in the tx events is
+// successful. Redundant relays of a packet already received do not write it.
+func PacketExecuted(events []abcitypes.Event, packet channeltypes.Packet) bool {
+	for _, evt := range events {
+		if evt.Type != channeltypes.EventTypeWriteAck ||
+			EventAttr(evt, channeltypes.AttributeKeySequence) != strconv.FormatUint(packet.Sequence, 10) ||
+			EventAttr(evt, channeltypes.AttributeKeyDstPort) != packet.DestinationPort ||
+			EventAttr(evt, channeltypes.AttributeKeyDstChannel) != packet.DestinationChannel {
+			continue
+		}
+
+		ack, err := hex.DecodeString(EventAttr(evt, channeltypes.AttributeKeyAckHex))
+		if err != nil {
+			ack = []byte(EventAttr(evt, channeltypes.AttributeKeyAck))
+		}
+		return AckError(ack) == ""
+	}
+	return false
+}
+
+// MergeIBCTransfer merges the transfer already stored with a new step of the packet lifecycle.
+// The steps can be indexed in any order, the status only moves forward.
+func MergeIBCTransfer(stored *IBCTransfer, update IBCTransfer) IBCTransfer {
+	if stored == nil {
+		return update
+	}
+
+	merged := *stored
+	if ibcStatusOrder[update.Status] > ibcStatusOrder[merged.Status] {
+		merged.Status = update.Status
+	}
+	if update.AckError != "" {
+		merged.AckError = update.AckError
+	}
+	if update.Execution != nil {
+		merged.Execution = update.Execution
+	}
+	// the denom of outgoing transfers is resolved from the ibc/HASH, it takes precedence over the packet one.
+	if update.Status == IBCStatusPending {
+		merged.Denom = update.Denom
+		merged.DenomTrace = update.DenomTrace
+		merged.Memo = update.Memo
+	}
+
+	steps := make([]*IBCTransferStep, 0, len(stored.Steps)+len(update.Steps))
+	seen := make(map[string]bool)
+	for _, step := range append(append([]*IBCTransferStep{}, stored.Steps...), update.Steps...) {
+		key := step.Status + step.TxHash
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		steps = append(steps, step)
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].BlockHeight < steps[j].BlockHeight
+	})
+	merged.Steps = steps
+	if update.LastBlockTimeUnix > merged.LastBlockTimeUnix {
+		merged.LastBlockTimeUnix = update.LastBlockTimeUnix
+	}
+	return merged
+}
@@ -0,0 +1,106 @@
+package types_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestUmeeDenomTrace(t *testing.T) {
+	packet := channeltypes.Packet{
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-1",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-0",
+	}
+
+	// atom sent from the hub to umee.
+	trace := types.UmeeDenomTrace(packet, types.IBCDirectionIncoming, "uatom")
+	require.Equal(t, "transfer/channel-0", trace.Path)
+	require.Equal(t, "uatom", trace.BaseDenom)
+
+	// umee returning from the hub.
+	trace = types.UmeeDenomTrace(packet, types.IBCDirectionIncoming, "transfer/channel-1/uumee")
+	require.Equal(t, "", trace.Path)
+	require.Equal(t, "uumee", trace.IBCDenom())
+
+	// atom sent from umee back to the hub.
+	trace = types.UmeeDenomTrace(packet, types.IBCDirectionOutgoing, "transfer/channel-0/uatom")
+	require.Equal(t, transfertypes.ParseDenomTrace("transfer/channel-0/uatom").IBCDenom(), trace.IBCDenom())
+}
+
+func TestMergeIBCTransfer(t *testing.T) {
+	acked := types.IBCTransfer{
+		Direction:         types.IBCDirectionOutgoing,
+		Status:            types.IBCStatusAcked,
+		Denom:             "uumee",
+		AckError:          "insufficient funds",
+		Steps:             []*types.IBCTransferStep{{Status: types.IBCStatusAcked, TxHash: "B", BlockHeight: 20, BlockTimeUnix: 200}},
+		LastBlockTimeUnix: 200,
+	}
+	pending := types.IBCTransfer{
+		Direction:         types.IBCDirectionOutgoing,
+		Status:            types.IBCStatusPending,
+		Denom:             "uumee",
+		Memo:              "memo",
+		Steps:             []*types.IBCTransferStep{{Status: types.IBCStatusPending, TxHash: "A", BlockHeight: 10, BlockTimeUnix: 100}},
+		LastBlockTimeUnix: 100,
+	}
+
+	// the ack was indexed before the transfer, the status does not go back to pending.
+	merged := types.MergeIBCTransfer(&acked, pending)
+	require.Equal(t, types.IBCStatusAcked, merged.Status)
+	require.Equal(t, "insufficient funds", merged.AckError)
+	require.Equal(t, "memo", merged.Memo)
+	require.Equal(t, 200, merged.LastBlockTimeUnix)
+	require.Len(t, merged.Steps, 2)
+	require.Equal(t, "A", merged.Steps[0].TxHash)
+	require.Equal(t, "B", merged.Steps[1].TxHash)
+
+	// indexing the same step again does not duplicate it.
+	merged = types.MergeIBCTransfer(&merged, pending)
+	require.Len(t, merged.Steps, 2)
+
+	require.Equal(t, pending, types.MergeIBCTransfer(nil, pending))
+}
+
+func TestPacketExecuted(t *testing.T) {
+	packet := channeltypes.Packet{
+		Sequence:           7,
+		DestinationPort:    "icahost",
+		DestinationChannel: "channel-5",
+	}
+	writeAck := func(sequence string, ack []byte) abcitypes.Event {
+		return abcitypes.Event{Type: "write_acknowledgement", Attributes: []abcitypes.EventAttribute{
+			{Key: "packet_sequence", Value: sequence},
+			{Key: "packet_dst_port", Value: "icahost"},
+			{Key: "packet_dst_channel", Value: "channel-5"},
+			{Key: "packet_ack_hex", Value: hex.EncodeToString(ack)},
+		}}
+	}
+	success := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+	failure := channeltypes.NewErrorAcknowledgement(fmt.Errorf("failed")).Acknowledgement()
+
+	tcs := []struct {
+		title    string
+		events   []abcitypes.Event
+		expected bool
+	}{
+		{"successful ack", []abcitypes.Event{writeAck("7", success)}, true},
+		{"error ack", []abcitypes.Event{writeAck("7", failure)}, false},
+		{"ack of other packet", []abcitypes.Event{writeAck("8", success)}, false},
+		{"redundant relay without ack", nil, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.PacketExecuted(tc.events, packet))
+		})
+	}
+}
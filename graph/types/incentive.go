@@ -0,0 +1,176 @@
+package types
+
+import (
+	"sort"
+
+	sdkmath "cosmossdk.io/math"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/incentive"
+)
+
+var (
+	MsgNameBond              = proto.MessageName(&incentive.MsgBond{})
+	MsgNameBeginUnbonding    = proto.MessageName(&incentive.MsgBeginUnbonding{})
+	MsgNameEmergencyUnbond   = proto.MessageName(&incentive.MsgEmergencyUnbond{})
+	MsgNameClaim             = proto.MessageName(&incentive.MsgClaim{})
+	MsgNameSponsor           = proto.MessageName(&incentive.MsgSponsor{})
+	MsgNameGovCreatePrograms = proto.MessageName(&incentive.MsgGovCreatePrograms{})
+)
+
+// ParseTxBond gets an incentive tx msg and transpile to the graphql one.
+func ParseTxBond(msg *incentive.MsgBond, rewards string) MsgBond {
+	return MsgBond{
+		Account: msg.Account,
+		UToken:  msg.UToken.String(),
+		Rewards: rewards,
+	}
+}
+
+// ParseTxBeginUnbonding gets an incentive tx msg and transpile to the graphql one.
+func ParseTxBeginUnbonding(msg *incentive.MsgBeginUnbonding, rewards string) MsgBeginUnbonding {
+	return MsgBeginUnbonding{
+		Account: msg.Account,
+		UToken:  msg.UToken.String(),
+		Rewards: rewards,
+	}
+}
+
+// ParseTxEmergencyUnbond gets an incentive tx msg and transpile to the graphql one.
+func ParseTxEmergencyUnbond(msg *incentive.MsgEmergencyUnbond, rewards string) MsgEmergencyUnbond {
+	return MsgEmergencyUnbond{
+		Account: msg.Account,
+		UToken:  msg.UToken.String(),
+		Rewards: rewards,
+	}
+}
+
+// ParseTxClaim gets an incentive tx msg and transpile to the graphql one.
+func ParseTxClaim(msg *incentive.MsgClaim, rewards string) MsgClaim {
+	return MsgClaim{
+		Account: msg.Account,
+		Rewards: rewards,
+	}
+}
+
+// ParseTxSponsor gets an incentive tx msg and transpile to the graphql one.
+func ParseTxSponsor(msg *incentive.MsgSponsor, amount string) MsgSponsor {
+	return MsgSponsor{
+		Sponsor: msg.Sponsor,
+		Program: int(msg.Program),
+		Amount:  amount,
+	}
+}
+
+// ParseTxGovCreatePrograms gets an incentive tx msg and transpile to the graphql one.
+func ParseTxGovCreatePrograms(msg *incentive.MsgGovCreatePrograms) MsgGovCreatePrograms {
+	programs := make([]*IncentiveProgram, len(msg.Programs))
+	for i, program := range msg.Programs {
+		program := ParseIncentiveProgram(program)
+		programs[i] = &program
+	}
+
+	return MsgGovCreatePrograms{
+		Authority:         msg.Authority,
+		FromCommunityFund: msg.FromCommunityFund,
+		Programs:          programs,
+	}
+}
+
+// ParseIncentiveProgram transpiles the incentive program to the graphql one.
+func ParseIncentiveProgram(program incentive.IncentiveProgram) IncentiveProgram {
+	return IncentiveProgram{
+		ID:               int(program.ID),
+		StartTime:        int(program.StartTime),
+		Duration:         int(program.Duration),
+		UToken:           program.UToken,
+		Funded:           program.Funded,
+		TotalRewards:     program.TotalRewards.String(),
+		RemainingRewards: program.RemainingRewards.String(),
+	}
+}
+
+// NewIncentiveProgramSnapshot returns the snapshot of the program state at the block height.
+func NewIncentiveProgramSnapshot(program incentive.IncentiveProgram, blockHeight, blockTimeUnix int) IncentiveProgramSnapshot {
+	paid := program.TotalRewards
+	if program.RemainingRewards.Denom == paid.Denom && program.RemainingRewards.Amount.LTE(paid.Amount) {
+		paid = paid.Sub(program.RemainingRewards)
+	}
+
+	return IncentiveProgramSnapshot{
+		ProgramID:        int(program.ID),
+		BlockHeight:      blockHeight,
+		BlockTimeUnix:    blockTimeUnix,
+		UToken:           program.UToken,
+		TotalRewards:     program.TotalRewards.String(),
+		RemainingRewards: program.RemainingRewards.String(),
+		PaidRewards:      paid.String(),
+	}
+}
+
+// IncentiveBondedOverTime returns the bonded amount of the uToken denom after each of the txs,
+// the txs are expected to be the incentive bond and unbond msgs of a single account.
+func IncentiveBondedOverTime(txs []*IndexedTx, uTokenDenom string) ([]*IncentiveBondedBalance, error) {
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].BlockHeight < txs[j].BlockHeight
+	})
+
+	bonded := sdkmath.ZeroInt()
+	balances := make([]*IncentiveBondedBalance, 0, len(txs))
+	for _, tx := range txs {
+		var (
+			uToken string
+			isBond bool
+		)
+		switch {
+		case tx.MsgBond != nil:
+			uToken, isBond = tx.MsgBond.UToken, true
+		case tx.MsgBeginUnbonding != nil:
+			uToken = tx.MsgBeginUnbonding.UToken
+		case tx.MsgEmergencyUnbond != nil:
+			uToken = tx.MsgEmergencyUnbond.UToken
+		default:
+			continue
+		}
+
+		coin, err := sdktypes.ParseCoinNormalized(uToken)
+		if err != nil {
+			return nil, err
+		}
+		if coin.Denom != uTokenDenom {
+			continue
+		}
+
+		change := coin.Amount
+		if !isBond {
+			// can't unbond more than it is bonded.
+			change = sdkmath.MinInt(change, bonded).Neg()
+		}
+		bonded = bonded.Add(change)
+
+		balances = append(balances, &IncentiveBondedBalance{
+			TxHash:        tx.TxHash,
+			ProtoMsgName:  tx.ProtoMsgName,
+			BlockHeight:   tx.BlockHeight,
+			BlockTimeUnix: tx.BlockTimeUnix,
+			Change:        change.String(),
+			Bonded:        bonded.String(),
+		})
+	}
+	return balances, nil
+}
+
+// IncentiveRewards returns the rewards claimed by the incentive msg in the tx, empty if there is none.
+func IncentiveRewards(tx *IndexedTx) string {
+	switch {
+	case tx.MsgBond != nil:
+		return tx.MsgBond.Rewards
+	case tx.MsgBeginUnbonding != nil:
+		return tx.MsgBeginUnbonding.Rewards
+	case tx.MsgEmergencyUnbond != nil:
+		return tx.MsgEmergencyUnbond.Rewards
+	case tx.MsgClaim != nil:
+		return tx.MsgClaim.Rewards
+	}
+	return ""
+}
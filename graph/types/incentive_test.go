@@ -0,0 +1,35 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestIncentiveBondedOverTime(t *testing.T) {
+	txs := []*types.IndexedTx{
+		{BlockHeight: 30, MsgEmergencyUnbond: &types.MsgEmergencyUnbond{UToken: "500u/uumee"}},
+		{BlockHeight: 10, MsgBond: &types.MsgBond{UToken: "100u/uumee"}},
+		{BlockHeight: 15, MsgBond: &types.MsgBond{UToken: "70u/uatom"}},
+		{BlockHeight: 20, MsgBeginUnbonding: &types.MsgBeginUnbonding{UToken: "40u/uumee"}},
+		{BlockHeight: 25, MsgClaim: &types.MsgClaim{Rewards: "3uumee"}},
+	}
+
+	balances, err := types.IncentiveBondedOverTime(txs, "u/uumee")
+	require.NoError(t, err)
+	require.Len(t, balances, 3)
+
+	require.Equal(t, 10, balances[0].BlockHeight)
+	require.Equal(t, "100", balances[0].Change)
+	require.Equal(t, "100", balances[0].Bonded)
+
+	require.Equal(t, 20, balances[1].BlockHeight)
+	require.Equal(t, "-40", balances[1].Change)
+	require.Equal(t, "60", balances[1].Bonded)
+
+	// can't unbond more than it is bonded.
+	require.Equal(t, 30, balances[2].BlockHeight)
+	require.Equal(t, "-60", balances[2].Change)
+	require.Equal(t, "0", balances[2].Bonded)
+}
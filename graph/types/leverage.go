@@ -0,0 +1,222 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	lvgtypes "github.com/umee-network/umee/v6/x/leverage/types"
+)
+
+// ParseTxGovUpdateSpecialAssets gets an lvg tx msg and transpile to the graphql one. The special asset
+// sets are expanded into the pairs of every two distinct assets of the set, followed by the msg pairs
+// which is the order the chain applies them.
+func ParseTxGovUpdateSpecialAssets(lvgMsg *lvgtypes.MsgGovUpdateSpecialAssets) MsgGovUpdateSpecialAssets {
+	pairs := make([]*LeverageSpecialPair, 0, len(lvgMsg.Pairs))
+	for _, set := range lvgMsg.Sets {
+		for _, collateral := range set.Assets {
+			for _, borrow := range set.Assets {
+				if collateral == borrow {
+					continue
+				}
+				pairs = append(pairs, &LeverageSpecialPair{
+					Collateral:           collateral,
+					Borrow:               borrow,
+					CollateralWeight:     set.CollateralWeight.String(),
+					LiquidationThreshold: set.LiquidationThreshold.String(),
+				})
+			}
+		}
+	}
+	for _, pair := range lvgMsg.Pairs {
+		pairs = append(pairs, &LeverageSpecialPair{
+			Collateral:           pair.Collateral,
+			Borrow:               pair.Borrow,
+			CollateralWeight:     pair.CollateralWeight.String(),
+			LiquidationThreshold: pair.LiquidationThreshold.String(),
+		})
+	}
+
+	return MsgGovUpdateSpecialAssets{
+		Authority:   lvgMsg.Authority,
+		Description: lvgMsg.Description,
+		Pairs:       pairs,
+	}
+}
+
+// TokenRegistryChangeDocID returns the doc id of the registry change, firestore ids can not contain slashes
+// which are part of the ibc denoms.
+func TokenRegistryChangeDocID(change TokenRegistryChange) string {
+	return fmt.Sprintf("%s-%d-%d-%s-%s", strings.ReplaceAll(change.Denom, "/", "_"), change.BlockHeight,
+		change.ProposalID, change.TxHash, change.ProtoMsgName)
+}
+
+// NewTokenRegistryChanges returns the change of each token updated by the leverage gov msg. It returns
+// nil if the msg does not change the token registry. The proposal id is zero for msgs executed directly
+// by a tx and the tx hash is empty for msgs executed by a proposal.
+func NewTokenRegistryChanges(msg *GovProposalMsg, blkHeight, blockTimeUnix, proposalID int, txHash string) []*TokenRegistryChange {
+	newChange := func(denom string) *TokenRegistryChange {
+		return &TokenRegistryChange{
+			Denom:         denom,
+			ProtoMsgName:  msg.ProtoMsgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			ProposalID:    proposalID,
+			TxHash:        txHash,
+			SpecialPairs:  []*LeverageSpecialPair{},
+		}
+	}
+
+	var changes []*TokenRegistryChange
+	if registry := msg.MsgGovUpdateRegistry; registry != nil {
+		for _, token := range append(registry.AddTokens, registry.UpdateTokens...) {
+			change := newChange(token.BaseDenom)
+			change.Token = token
+			changes = append(changes, change)
+		}
+	}
+
+	if special := msg.MsgGovUpdateSpecialAssets; special != nil {
+		byCollateral := make(map[string]*TokenRegistryChange)
+		for _, pair := range special.Pairs {
+			change, ok := byCollateral[pair.Collateral]
+			if !ok {
+				change = newChange(pair.Collateral)
+				byCollateral[pair.Collateral] = change
+				changes = append(changes, change)
+			}
+			change.SpecialPairs = append(change.SpecialPairs, pair)
+		}
+	}
+
+	return changes
+}
+
+// TokenRegistryHistory returns the versions of the token config built from the registry changes of the
+// token in any order. Each version carries the token and the special pairs of the previous one forward
+// and the diff against it. Changes of the same block are applied with the txs first, as the proposals
+// are only executed at the end block.
+func TokenRegistryHistory(changes []*TokenRegistryChange) []*TokenRegistryVersion {
+	sorted := make([]*TokenRegistryChange, len(changes))
+	copy(sorted, changes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].BlockHeight != sorted[j].BlockHeight {
+			return sorted[i].BlockHeight < sorted[j].BlockHeight
+		}
+		return sorted[i].ProposalID < sorted[j].ProposalID
+	})
+
+	versions := make([]*TokenRegistryVersion, 0, len(sorted))
+	var (
+		token *LeverageToken
+		pairs = make(map[string]*LeverageSpecialPair)
+	)
+	for _, change := range sorted {
+		diff := make([]*TokenRegistryDiff, 0)
+		if change.Token != nil {
+			diff = append(diff, diffLeverageToken(token, change.Token)...)
+			token = change.Token
+		}
+
+		for _, pair := range change.SpecialPairs {
+			from := pairs[pair.Borrow]
+			if isZeroDec(pair.CollateralWeight) {
+				// the chain deletes the special pairs with zero collateral weight.
+				delete(pairs, pair.Borrow)
+				pair = nil
+			} else {
+				pairs[pair.Borrow] = pair
+			}
+			diff = append(diff, diffSpecialPair(from, pair)...)
+		}
+
+		versions = append(versions, &TokenRegistryVersion{
+			Version:         len(versions) + 1,
+			Denom:           change.Denom,
+			BlockHeight:     change.BlockHeight,
+			BlockTimeUnix:   change.BlockTimeUnix,
+			EffectiveHeight: change.BlockHeight + 1,
+			ProposalID:      change.ProposalID,
+			TxHash:          change.TxHash,
+			ProtoMsgName:    change.ProtoMsgName,
+			Token:           token,
+			SpecialPairs:    sortedSpecialPairs(pairs),
+			Diff:            diff,
+		})
+	}
+	return versions
+}
+
+// TokenRegistryAt returns the version used by the txs of the block height, nil if there is none.
+func TokenRegistryAt(versions []*TokenRegistryVersion, blockHeight int) *TokenRegistryVersion {
+	var found *TokenRegistryVersion
+	for _, version := range versions {
+		if version.EffectiveHeight > blockHeight {
+			break
+		}
+		found = version
+	}
+	return found
+}
+
+// diffLeverageToken compares the token fields by their json name, the previous token can be nil.
+func diffLeverageToken(from, to *LeverageToken) []*TokenRegistryDiff {
+	toValue := reflect.ValueOf(*to)
+	fromValue := reflect.Zero(toValue.Type())
+	if from != nil {
+		fromValue = reflect.ValueOf(*from)
+	}
+
+	diff := make([]*TokenRegistryDiff, 0)
+	for i := 0; i < toValue.NumField(); i++ {
+		fromField, toField := fmt.Sprint(fromValue.Field(i).Interface()), fmt.Sprint(toValue.Field(i).Interface())
+		if from != nil && fromField == toField {
+			continue
+		}
+		if from == nil {
+			fromField = ""
+		}
+		diff = append(diff, &TokenRegistryDiff{
+			Field: strings.Split(toValue.Type().Field(i).Tag.Get("json"), ",")[0],
+			From:  fromField,
+			To:    toField,
+		})
+	}
+	return diff
+}
+
+// diffSpecialPair compares the special pair weights, nil pairs are the ones that do not exist.
+func diffSpecialPair(from, to *LeverageSpecialPair) []*TokenRegistryDiff {
+	borrow, fromWeight, fromThreshold, toWeight, toThreshold := "", "", "", "", ""
+	if from != nil {
+		borrow, fromWeight, fromThreshold = from.Borrow, from.CollateralWeight, from.LiquidationThreshold
+	}
+	if to != nil {
+		borrow, toWeight, toThreshold = to.Borrow, to.CollateralWeight, to.LiquidationThreshold
+	}
+
+	diff := make([]*TokenRegistryDiff, 0)
+	if fromWeight != toWeight {
+		diff = append(diff, &TokenRegistryDiff{Field: "specialPair/" + borrow + "/collateralWeight", From: fromWeight, To: toWeight})
+	}
+	if fromThreshold != toThreshold {
+		diff = append(diff, &TokenRegistryDiff{Field: "specialPair/" + borrow + "/liquidationThreshold", From: fromThreshold, To: toThreshold})
+	}
+	return diff
+}
+
+func sortedSpecialPairs(pairs map[string]*LeverageSpecialPair) []*LeverageSpecialPair {
+	sorted := make([]*LeverageSpecialPair, 0, len(pairs))
+	for _, pair := range pairs {
+		sorted = append(sorted, pair)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Borrow < sorted[j].Borrow
+	})
+	return sorted
+}
+
+func isZeroDec(dec string) bool {
+	return strings.Trim(strings.ReplaceAll(dec, ".", ""), "0") == ""
+}
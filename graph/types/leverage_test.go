@@ -0,0 +1,50 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestTokenRegistryHistory(t *testing.T) {
+	token := func(collateralWeight string) *types.LeverageToken {
+		return &types.LeverageToken{BaseDenom: "uumee", SymbolDenom: "UMEE", Exponent: 6, CollateralWeight: collateralWeight}
+	}
+	pair := func(borrow, collateralWeight string) *types.LeverageSpecialPair {
+		return &types.LeverageSpecialPair{Collateral: "uumee", Borrow: borrow, CollateralWeight: collateralWeight, LiquidationThreshold: "0.5"}
+	}
+
+	changes := []*types.TokenRegistryChange{
+		{Denom: "uumee", BlockHeight: 30, ProposalID: 2, SpecialPairs: []*types.LeverageSpecialPair{pair("uatom", "0.000000000000000000")}},
+		{Denom: "uumee", BlockHeight: 20, ProposalID: 1, Token: token("0.35")},
+		{Denom: "uumee", BlockHeight: 20, TxHash: "tx", SpecialPairs: []*types.LeverageSpecialPair{pair("uatom", "0.4"), pair("uusdc", "0.45")}},
+		{Denom: "uumee", BlockHeight: 10, ProposalID: 1, Token: token("0.3")},
+	}
+
+	versions := types.TokenRegistryHistory(changes)
+	require.Len(t, versions, 4)
+
+	require.Equal(t, 1, versions[0].Version)
+	require.Equal(t, 11, versions[0].EffectiveHeight)
+	require.Contains(t, versions[0].Diff, &types.TokenRegistryDiff{Field: "collateralWeight", From: "", To: "0.3"})
+
+	// the tx is applied before the proposal of the same block.
+	require.Equal(t, "tx", versions[1].TxHash)
+	require.Equal(t, "0.3", versions[1].Token.CollateralWeight)
+	require.Len(t, versions[1].SpecialPairs, 2)
+	require.Equal(t, []*types.TokenRegistryDiff{{Field: "collateralWeight", From: "0.3", To: "0.35"}}, versions[2].Diff)
+	require.Len(t, versions[2].SpecialPairs, 2)
+
+	// zero collateral weight deletes the pair.
+	require.Equal(t, []*types.LeverageSpecialPair{pair("uusdc", "0.45")}, versions[3].SpecialPairs)
+	require.Equal(t, []*types.TokenRegistryDiff{
+		{Field: "specialPair/uatom/collateralWeight", From: "0.4", To: ""},
+		{Field: "specialPair/uatom/liquidationThreshold", From: "0.5", To: ""},
+	}, versions[3].Diff)
+	require.Equal(t, "0.35", versions[3].Token.CollateralWeight)
+
+	require.Nil(t, types.TokenRegistryAt(versions, 10))
+	require.Equal(t, 1, types.TokenRegistryAt(versions, 11).Version)
+	require.Equal(t, 3, types.TokenRegistryAt(versions, 25).Version)
+}
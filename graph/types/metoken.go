@@ -0,0 +1,158 @@
+package types
+
+import (
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/metoken"
+)
+
+var (
+	MsgNameSwap   = proto.MessageName(&metoken.MsgSwap{})
+	MsgNameRedeem = proto.MessageName(&metoken.MsgRedeem{})
+)
+
+// ParseTxSwap gets a metoken tx msg and transpile to the graphql one, the metoken received and the
+// fee are filled if the event emitted by the swap is informed.
+func ParseTxSwap(msg *metoken.MsgSwap, evt *metoken.EventSwap) MsgSwap {
+	swap := MsgSwap{
+		User:         msg.User,
+		MetokenDenom: msg.MetokenDenom,
+		AssetDenom:   msg.Asset.Denom,
+		Asset:        msg.Asset.String(),
+	}
+	if evt != nil {
+		swap.Metoken = evt.Metoken.String()
+		swap.Fee = evt.Fee.String()
+	}
+	return swap
+}
+
+// ParseTxRedeem gets a metoken tx msg and transpile to the graphql one, the asset received and the
+// fee are filled if the event emitted by the redemption is informed.
+func ParseTxRedeem(msg *metoken.MsgRedeem, evt *metoken.EventRedeem) MsgRedeem {
+	redeem := MsgRedeem{
+		User:         msg.User,
+		MetokenDenom: msg.Metoken.Denom,
+		AssetDenom:   msg.AssetDenom,
+		Metoken:      msg.Metoken.String(),
+	}
+	if evt != nil {
+		redeem.Asset = evt.Asset.String()
+		redeem.Fee = evt.Fee.String()
+	}
+	return redeem
+}
+
+// NewMetokenIndexSnapshot returns the snapshot of the index balances at the block height.
+func NewMetokenIndexSnapshot(balances metoken.IndexBalances, blockHeight, blockTimeUnix int) MetokenIndexSnapshot {
+	assets := make([]*MetokenAssetBalance, len(balances.AssetBalances))
+	for i, asset := range balances.AssetBalances {
+		assets[i] = &MetokenAssetBalance{
+			Denom:     asset.Denom,
+			Leveraged: asset.Leveraged.String(),
+			Reserved:  asset.Reserved.String(),
+			Fees:      asset.Fees.String(),
+			Interest:  asset.Interest.String(),
+		}
+	}
+
+	return MetokenIndexSnapshot{
+		MetokenDenom:  balances.MetokenSupply.Denom,
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+		MetokenSupply: balances.MetokenSupply.String(),
+		AssetBalances: assets,
+	}
+}
+
+// MetokenSwapVolume sums the swaps of the asset into the meToken index.
+func MetokenSwapVolume(txs []*IndexedTx, metokenDenom, assetDenom string) (*MetokenVolume, error) {
+	var asset, metoken, fee sdktypes.Coins
+	count := 0
+	for _, tx := range txs {
+		swap := tx.MsgSwap
+		if swap == nil || swap.MetokenDenom != metokenDenom || swap.AssetDenom != assetDenom {
+			continue
+		}
+
+		var err error
+		if asset, err = addCoinStr(asset, swap.Asset); err != nil {
+			return nil, err
+		}
+		if metoken, err = addCoinStr(metoken, swap.Metoken); err != nil {
+			return nil, err
+		}
+		if fee, err = addCoinStr(fee, swap.Fee); err != nil {
+			return nil, err
+		}
+		count++
+	}
+	return newMetokenVolume(metokenDenom, assetDenom, count, asset, metoken, fee), nil
+}
+
+// MetokenRedeemVolume sums the redemptions of the asset from the meToken index.
+func MetokenRedeemVolume(txs []*IndexedTx, metokenDenom, assetDenom string) (*MetokenVolume, error) {
+	var asset, metoken, fee sdktypes.Coins
+	count := 0
+	for _, tx := range txs {
+		redeem := tx.MsgRedeem
+		if redeem == nil || redeem.MetokenDenom != metokenDenom || redeem.AssetDenom != assetDenom {
+			continue
+		}
+
+		var err error
+		if asset, err = addCoinStr(asset, redeem.Asset); err != nil {
+			return nil, err
+		}
+		if metoken, err = addCoinStr(metoken, redeem.Metoken); err != nil {
+			return nil, err
+		}
+		if fee, err = addCoinStr(fee, redeem.Fee); err != nil {
+			return nil, err
+		}
+		count++
+	}
+	return newMetokenVolume(metokenDenom, assetDenom, count, asset, metoken, fee), nil
+}
+
+func newMetokenVolume(metokenDenom, assetDenom string, count int, asset, metoken, fee sdktypes.Coins) *MetokenVolume {
+	return &MetokenVolume{
+		MetokenDenom: metokenDenom,
+		AssetDenom:   assetDenom,
+		Count:        count,
+		Asset:        asset.String(),
+		Metoken:      metoken.String(),
+		Fee:          fee.String(),
+	}
+}
+
+// TimeUnixCondition is a condition of a query on a unix time field.
+type TimeUnixCondition struct {
+	Op    string
+	Value int
+}
+
+// TimeUnixConditions returns the conditions filtering a unix time field by the time interval, inclusive
+// on both ends, nil values are not filtered.
+func TimeUnixConditions(fromTimeUnix, toTimeUnix *int) []TimeUnixCondition {
+	conditions := make([]TimeUnixCondition, 0, 2)
+	if fromTimeUnix != nil {
+		conditions = append(conditions, TimeUnixCondition{Op: ">=", Value: *fromTimeUnix})
+	}
+	if toTimeUnix != nil {
+		conditions = append(conditions, TimeUnixCondition{Op: "<=", Value: *toTimeUnix})
+	}
+	return conditions
+}
+
+// addCoinStr parses the coin string and adds it to the coins, empty strings are ignored.
+func addCoinStr(coins sdktypes.Coins, coinStr string) (sdktypes.Coins, error) {
+	if len(coinStr) == 0 {
+		return coins, nil
+	}
+	coin, err := sdktypes.ParseCoinNormalized(coinStr)
+	if err != nil {
+		return nil, err
+	}
+	return coins.Add(coin), nil
+}
@@ -0,0 +1,189 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+const (
+	metokenDenom = "me/USD"
+	usdtDenom    = "ibc/USDT"
+)
+
+func TestMetokenSwapVolume(t *testing.T) {
+	swap := func(metokenDenom, assetDenom, asset, metoken, fee string) *types.IndexedTx {
+		return &types.IndexedTx{ProtoMsgName: types.MsgNameSwap, MsgSwap: &types.MsgSwap{
+			MetokenDenom: metokenDenom, AssetDenom: assetDenom, Asset: asset, Metoken: metoken, Fee: fee,
+		}}
+	}
+
+	tcs := []struct {
+		title    string
+		txs      []*types.IndexedTx
+		expected types.MetokenVolume
+		errMsg   string
+	}{
+		{
+			title:    "no swaps",
+			expected: types.MetokenVolume{MetokenDenom: metokenDenom, AssetDenom: usdtDenom},
+		},
+		{
+			title: "sums the swaps of the asset to the index",
+			txs: []*types.IndexedTx{
+				swap(metokenDenom, usdtDenom, "1000ibc/USDT", "995me/USD", "5ibc/USDT"),
+				swap(metokenDenom, usdtDenom, "500ibc/USDT", "498me/USD", "2ibc/USDT"),
+			},
+			expected: types.MetokenVolume{
+				MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Count: 2,
+				Asset: "1500ibc/USDT", Metoken: "1493me/USD", Fee: "7ibc/USDT",
+			},
+		},
+		{
+			title: "other assets, indexes and redemptions are not summed",
+			txs: []*types.IndexedTx{
+				swap(metokenDenom, usdtDenom, "1000ibc/USDT", "995me/USD", "5ibc/USDT"),
+				swap(metokenDenom, "ibc/USDC", "300ibc/USDC", "299me/USD", "1ibc/USDC"),
+				swap("me/EUR", usdtDenom, "200ibc/USDT", "180me/EUR", "1ibc/USDT"),
+				{ProtoMsgName: types.MsgNameRedeem, MsgRedeem: &types.MsgRedeem{MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Asset: "100ibc/USDT"}},
+			},
+			expected: types.MetokenVolume{
+				MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Count: 1,
+				Asset: "1000ibc/USDT", Metoken: "995me/USD", Fee: "5ibc/USDT",
+			},
+		},
+		{
+			title: "swaps stored without their event only sum the asset",
+			txs: []*types.IndexedTx{
+				swap(metokenDenom, usdtDenom, "1000ibc/USDT", "", ""),
+			},
+			expected: types.MetokenVolume{MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Count: 1, Asset: "1000ibc/USDT"},
+		},
+		{
+			title:  "invalid coin",
+			txs:    []*types.IndexedTx{swap(metokenDenom, usdtDenom, "ibc/USDT", "", "")},
+			errMsg: "invalid decimal coin expression",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			volume, err := types.MetokenSwapVolume(tc.txs, metokenDenom, usdtDenom)
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, *volume)
+		})
+	}
+}
+
+func TestMetokenRedeemVolume(t *testing.T) {
+	redeem := func(metokenDenom, assetDenom, metoken, asset, fee string) *types.IndexedTx {
+		return &types.IndexedTx{ProtoMsgName: types.MsgNameRedeem, MsgRedeem: &types.MsgRedeem{
+			MetokenDenom: metokenDenom, AssetDenom: assetDenom, Metoken: metoken, Asset: asset, Fee: fee,
+		}}
+	}
+
+	tcs := []struct {
+		title    string
+		txs      []*types.IndexedTx
+		expected types.MetokenVolume
+		errMsg   string
+	}{
+		{
+			title:    "no redemptions",
+			expected: types.MetokenVolume{MetokenDenom: metokenDenom, AssetDenom: usdtDenom},
+		},
+		{
+			title: "sums the redemptions of the asset from the index",
+			txs: []*types.IndexedTx{
+				redeem(metokenDenom, usdtDenom, "1000me/USD", "995ibc/USDT", "5ibc/USDT"),
+				redeem(metokenDenom, usdtDenom, "200me/USD", "199ibc/USDT", "1ibc/USDT"),
+			},
+			expected: types.MetokenVolume{
+				MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Count: 2,
+				Asset: "1194ibc/USDT", Metoken: "1200me/USD", Fee: "6ibc/USDT",
+			},
+		},
+		{
+			title: "other assets, indexes and swaps are not summed",
+			txs: []*types.IndexedTx{
+				redeem(metokenDenom, usdtDenom, "1000me/USD", "995ibc/USDT", "5ibc/USDT"),
+				redeem(metokenDenom, "ibc/USDC", "300me/USD", "299ibc/USDC", "1ibc/USDC"),
+				redeem("me/EUR", usdtDenom, "200me/EUR", "220ibc/USDT", "1ibc/USDT"),
+				{ProtoMsgName: types.MsgNameSwap, MsgSwap: &types.MsgSwap{MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Asset: "100ibc/USDT"}},
+			},
+			expected: types.MetokenVolume{
+				MetokenDenom: metokenDenom, AssetDenom: usdtDenom, Count: 1,
+				Asset: "995ibc/USDT", Metoken: "1000me/USD", Fee: "5ibc/USDT",
+			},
+		},
+		{
+			title:  "invalid coin",
+			txs:    []*types.IndexedTx{redeem(metokenDenom, usdtDenom, "1000", "", "")},
+			errMsg: "invalid decimal coin expression",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			volume, err := types.MetokenRedeemVolume(tc.txs, metokenDenom, usdtDenom)
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, *volume)
+		})
+	}
+}
+
+func TestTimeUnixConditions(t *testing.T) {
+	from, to := 1_700_000_000, 1_700_086_400
+
+	tcs := []struct {
+		title        string
+		fromTimeUnix *int
+		toTimeUnix   *int
+		expected     []types.TimeUnixCondition
+	}{
+		{
+			title:    "no interval is not filtered",
+			expected: []types.TimeUnixCondition{},
+		},
+		{
+			title:        "from the time on",
+			fromTimeUnix: &from,
+			expected:     []types.TimeUnixCondition{{Op: ">=", Value: from}},
+		},
+		{
+			title:      "up to the time",
+			toTimeUnix: &to,
+			expected:   []types.TimeUnixCondition{{Op: "<=", Value: to}},
+		},
+		{
+			title:        "both ends are inclusive",
+			fromTimeUnix: &from,
+			toTimeUnix:   &to,
+			expected:     []types.TimeUnixCondition{{Op: ">=", Value: from}, {Op: "<=", Value: to}},
+		},
+		{
+			title:        "a single second",
+			fromTimeUnix: &from,
+			toTimeUnix:   &from,
+			expected:     []types.TimeUnixCondition{{Op: ">=", Value: from}, {Op: "<=", Value: from}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.TimeUnixConditions(tc.fromTimeUnix, tc.toTimeUnix))
+		})
+	}
+}
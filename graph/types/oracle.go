@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+)
+
+var (
+	MsgNameAggregateExchangeRateVote = proto.MessageName(&oracletypes.MsgAggregateExchangeRateVote{})
+	MsgNameDelegateFeedConsent       = proto.MessageName(&oracletypes.MsgDelegateFeedConsent{})
+)
+
+// IsPeriodLastBlock returns true if the block height is the last block of the period,
+// it follows the same rule used by the oracle module EndBlocker.
+func IsPeriodLastBlock(blockHeight, period int) bool {
+	if period <= 0 {
+		return false
+	}
+	return (blockHeight+1)%period == 0
+}
+
+// PeriodOf returns the period number in which the block height is in.
+func PeriodOf(blockHeight, period int) int {
+	if period <= 0 {
+		return 0
+	}
+	return blockHeight / period
+}
+
+// PeriodInterval returns the first and last block height of the given period number.
+func PeriodInterval(periodNumber, period int) (from, to int) {
+	from = periodNumber * period
+	return from, from + period - 1
+}
+
+// ParseOracleVote gets an oracle vote msg and transpile to the graphql one.
+func ParseOracleVote(msg *oracletypes.MsgAggregateExchangeRateVote, votePeriod, slashWindow, blockHeight, blockTimeUnix int, txHash string) OracleValidatorVote {
+	return OracleValidatorVote{
+		Validator:     msg.Validator,
+		Feeder:        msg.Feeder,
+		Voted:         true,
+		VotePeriod:    PeriodOf(blockHeight, votePeriod),
+		SlashWindow:   PeriodOf(blockHeight, slashWindow),
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+		TxHash:        txHash,
+	}
+}
+
+// OracleVoteDocID returns the doc id of the vote or miss of the validator in the vote period, each
+// validator counts once by period so storing the votes of a block again does not count them twice.
+func OracleVoteDocID(vote OracleValidatorVote) string {
+	return fmt.Sprintf("%s-%d", vote.Validator, vote.VotePeriod)
+}
+
+// NewOracleMiss returns a vote structure representing a validator that missed the vote in the vote period.
+func NewOracleMiss(validator string, votePeriod, slashWindow, blockHeight, blockTimeUnix int) OracleValidatorVote {
+	return OracleValidatorVote{
+		Validator:     validator,
+		Voted:         false,
+		VotePeriod:    PeriodOf(blockHeight, votePeriod),
+		SlashWindow:   PeriodOf(blockHeight, slashWindow),
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+	}
+}
+
+// OracleMisses returns the misses of the validators of the set that did not vote in the vote period ending
+// at the block height, in the order of the validator set.
+func OracleMisses(valopers []string, voted map[string]struct{}, votePeriod, slashWindow, blockHeight, blockTimeUnix int) []OracleValidatorVote {
+	misses := make([]OracleValidatorVote, 0)
+	for _, valoper := range valopers {
+		if _, ok := voted[valoper]; ok {
+			continue
+		}
+		misses = append(misses, NewOracleMiss(valoper, votePeriod, slashWindow, blockHeight, blockTimeUnix))
+	}
+	return misses
+}
+
+// ParseTxDelegateFeedConsent gets an oracle tx msg and transpile to the graphql one.
+func ParseTxDelegateFeedConsent(msg *oracletypes.MsgDelegateFeedConsent) MsgDelegateFeedConsent {
+	return MsgDelegateFeedConsent{
+		Operator: msg.Operator,
+		Delegate: msg.Delegate,
+	}
+}
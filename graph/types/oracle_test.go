@@ -0,0 +1,87 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestIsPeriodLastBlock(t *testing.T) {
+	tcs := []struct {
+		title       string
+		blockHeight int
+		period      int
+		expected    bool
+	}{
+		{"last block of the first period", 4, 5, true},
+		{"first block of the period", 5, 5, false},
+		{"middle of the period", 7, 5, false},
+		{"last block of a later period", 99, 5, true},
+		{"period of one block", 10, 1, true},
+		{"zero period", 4, 0, false},
+		{"negative period", 4, -5, false},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.IsPeriodLastBlock(tc.blockHeight, tc.period))
+		})
+	}
+}
+
+func TestPeriodOf(t *testing.T) {
+	tcs := []struct {
+		title       string
+		blockHeight int
+		period      int
+		expected    int
+	}{
+		{"first period", 4, 5, 0},
+		{"first block of the second period", 5, 5, 1},
+		{"last block of the second period", 9, 5, 1},
+		{"slash window", 1_234_567, 100_800, 12},
+		{"zero period", 4, 0, 0},
+		{"negative period", 4, -5, 0},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.PeriodOf(tc.blockHeight, tc.period))
+		})
+	}
+}
+
+func TestOracleMisses(t *testing.T) {
+	valopers := []string{"valA", "valB", "valC"}
+
+	tcs := []struct {
+		title    string
+		voted    map[string]struct{}
+		expected []string
+	}{
+		{"everyone voted", map[string]struct{}{"valA": {}, "valB": {}, "valC": {}}, []string{}},
+		{"nobody voted", map[string]struct{}{}, []string{"valA", "valB", "valC"}},
+		{"one missed", map[string]struct{}{"valA": {}, "valC": {}}, []string{"valB"}},
+		{"voter out of the validator set", map[string]struct{}{"valD": {}, "valA": {}, "valB": {}}, []string{"valC"}},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.title, func(t *testing.T) {
+			misses := types.OracleMisses(valopers, tc.voted, 5, 100, 104, 1000)
+			missed := make([]string, len(misses))
+			for i, miss := range misses {
+				require.False(t, miss.Voted)
+				require.Equal(t, 20, miss.VotePeriod)
+				require.Equal(t, 1, miss.SlashWindow)
+				require.Equal(t, 104, miss.BlockHeight)
+				require.Equal(t, 1000, miss.BlockTimeUnix)
+				missed[i] = miss.Validator
+			}
+			require.Equal(t, tc.expected, missed)
+		})
+	}
+}
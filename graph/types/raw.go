@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/base64"
+	"strings"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgNameRawAny is the name of the msgs the codec of the block height does not know how to decode.
+const MsgNameRawAny = "umeed.indexer.RawAny"
+
+// RawAnyMsg is a msg that could not be decoded, it keeps the Any as it was in the tx.
+type RawAnyMsg struct {
+	*codectypes.Any
+}
+
+var _ sdktypes.Msg = &RawAnyMsg{}
+
+// NewRawAnyMsg wraps the Any that could not be unpacked.
+func NewRawAnyMsg(anyMsg *codectypes.Any) *RawAnyMsg {
+	return &RawAnyMsg{Any: anyMsg}
+}
+
+// XXX_MessageName is the name used by proto.MessageName to handle the raw msgs.
+func (*RawAnyMsg) XXX_MessageName() string {
+	return MsgNameRawAny
+}
+
+// ValidateBasic does nothing, the raw msgs were already executed by the chain.
+func (*RawAnyMsg) ValidateBasic() error {
+	return nil
+}
+
+// GetSigners is unknown for raw msgs.
+func (*RawAnyMsg) GetSigners() []sdktypes.AccAddress {
+	return nil
+}
+
+// ParseTxRawAny transpiles the raw msg to the graphql one, the value is encoded as base64.
+func ParseTxRawAny(msg *RawAnyMsg) MsgRawAny {
+	return MsgRawAny{
+		TypeURL: msg.TypeUrl,
+		Value:   base64.StdEncoding.EncodeToString(msg.Value),
+	}
+}
+
+// RawAnyProtoMsgName returns the proto name of the raw msg from its type url.
+func RawAnyProtoMsgName(msg *RawAnyMsg) string {
+	return msg.TypeUrl[strings.LastIndex(msg.TypeUrl, "/")+1:]
+}
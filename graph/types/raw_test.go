@@ -0,0 +1,18 @@
+package types_test
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestRawAnyMsg(t *testing.T) {
+	msg := types.NewRawAnyMsg(&codectypes.Any{TypeUrl: "/gravity.v1.MsgSendToEth", Value: []byte{1, 2}})
+
+	require.Equal(t, types.MsgNameRawAny, proto.MessageName(msg))
+	require.Equal(t, "gravity.v1.MsgSendToEth", types.RawAnyProtoMsgName(msg))
+	require.Equal(t, types.MsgRawAny{TypeURL: "/gravity.v1.MsgSendToEth", Value: "AQI="}, types.ParseTxRawAny(msg))
+}
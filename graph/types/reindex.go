@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// msgsNotReindexable are the msgs whose handlers add up their values into merged docs, indexing them
+// again would count the same blocks twice.
+var msgsNotReindexable = map[string]string{
+	MsgNameSubmitProposal: "the deposits are added up in the gov proposals",
+	MsgNameDeposit:        "the deposits are added up in the gov proposals",
+	MsgNameTransfer:       "the amounts are added up in the uibc outflow windows",
+}
+
+// FindCosmosMsgName returns the proto name of the cosmos msg indexed by its full or short name,
+// ex.: umee.leverage.v1.MsgLiquidate or MsgLiquidate.
+func FindCosmosMsgName(cosmosMsgs []*CosmosMsgIndexed, name string) (protoMsgName string, found bool) {
+	for _, cosmosMsg := range cosmosMsgs {
+		shortName := cosmosMsg.ProtoMsgName[strings.LastIndex(cosmosMsg.ProtoMsgName, ".")+1:]
+		if strings.EqualFold(name, cosmosMsg.ProtoMsgName) || strings.EqualFold(name, shortName) {
+			return cosmosMsg.ProtoMsgName, true
+		}
+	}
+	return "", false
+}
+
+// ValidateReindexMsg returns an error if the records of the msg can not be deleted by block height.
+func ValidateReindexMsg(msgName string) error {
+	if reason, ok := msgsNotReindexable[msgName]; ok {
+		return fmt.Errorf("msg %s can not be reindexed: %s", msgName, reason)
+	}
+	return nil
+}
+
+// StoredTxMsgName returns the proto msg name the txs of the msg are stored with, the raw msgs are
+// stored with their type url name, an empty name means the txs can not be found by name.
+func StoredTxMsgName(msgName string) string {
+	if msgName == MsgNameRawAny {
+		return ""
+	}
+	return msgName
+}
+
+// IsTxOfMsg returns true if the tx was stored by the handler of the msg.
+func IsTxOfMsg(tx IndexedTx, msgName string) bool {
+	return TxMsgName(tx) == msgName
+}
+
+// TxMsgName returns the name of the msg whose handler stored the tx, the raw msgs are stored with
+// their type url name.
+func TxMsgName(tx IndexedTx) string {
+	if tx.MsgRawAny != nil {
+		return MsgNameRawAny
+	}
+	return tx.ProtoMsgName
+}
+
+// BlockHandlerOfMsg returns the name of the block handler gated by the msg, empty if there is none.
+func BlockHandlerOfMsg(msgName string) string {
+	switch msgName {
+	case MsgNameAggregateExchangeRateVote:
+		return DeadLetterHandlerOracleMisses
+	case MsgNameSubmitProposal:
+		return DeadLetterHandlerGovEndBlock
+	case MsgNameBalanceChanges:
+		return DeadLetterHandlerBalances
+	default:
+		return ""
+	}
+}
+
+// IsDeadLetterOfMsg returns true if the failure was of the msg or of the block handler gated by it.
+func IsDeadLetterOfMsg(letter DeadLetter, msgName string) bool {
+	if letter.Handler == DeadLetterHandlerMsg {
+		return letter.ProtoMsgName == msgName
+	}
+	return letter.Handler != "" && letter.Handler == BlockHandlerOfMsg(msgName)
+}
+
+// IsRegistryChangeOfMsg returns true if the registry change was stored by the tx of the msg, the
+// changes made by passed proposals are stored by the gov end block handler.
+func IsRegistryChangeOfMsg(change TokenRegistryChange, msgName string) bool {
+	return change.ProtoMsgName == msgName && change.TxHash != ""
+}
+
+// RevertOracleVote decreases the performance counter increased by the vote or miss.
+func RevertOracleVote(perf *OracleValidatorPerformance, vote OracleValidatorVote) {
+	if vote.Voted {
+		perf.Votes = max(perf.Votes-1, 0)
+		return
+	}
+	perf.Misses = max(perf.Misses-1, 0)
+}
@@ -0,0 +1,9 @@
+package types
+
+// SchemaVersion is the version of the schema of the docs stored of a chain, the migrations upgrade the
+// docs stored by older versions of the indexer to the latest one.
+type SchemaVersion struct {
+	Version int `json:"version" firestore:"version"`
+	// MigratedTimeUnix is when the chain was migrated to the version.
+	MigratedTimeUnix int `json:"migratedTimeUnix" firestore:"migratedTimeUnix"`
+}
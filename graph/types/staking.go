@@ -0,0 +1,161 @@
+package types
+
+import (
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+var (
+	MsgNameDelegate                    = proto.MessageName(&stakingtypes.MsgDelegate{})
+	MsgNameUndelegate                  = proto.MessageName(&stakingtypes.MsgUndelegate{})
+	MsgNameBeginRedelegate             = proto.MessageName(&stakingtypes.MsgBeginRedelegate{})
+	MsgNameCancelUnbondingDelegation   = proto.MessageName(&stakingtypes.MsgCancelUnbondingDelegation{})
+	MsgNameWithdrawDelegatorReward     = proto.MessageName(&distrtypes.MsgWithdrawDelegatorReward{})
+	MsgNameWithdrawValidatorCommission = proto.MessageName(&distrtypes.MsgWithdrawValidatorCommission{})
+)
+
+// ParseTxDelegate gets a staking tx msg and transpile to the graphql one.
+func ParseTxDelegate(msg *stakingtypes.MsgDelegate, rewards string) MsgDelegate {
+	return MsgDelegate{
+		Delegator: msg.DelegatorAddress,
+		Validator: msg.ValidatorAddress,
+		Amount:    msg.Amount.String(),
+		Rewards:   rewards,
+	}
+}
+
+// ParseTxUndelegate gets a staking tx msg and transpile to the graphql one.
+func ParseTxUndelegate(msg *stakingtypes.MsgUndelegate, rewards string, completionTimeUnix int) MsgUndelegate {
+	return MsgUndelegate{
+		Delegator:          msg.DelegatorAddress,
+		Validator:          msg.ValidatorAddress,
+		Amount:             msg.Amount.String(),
+		Rewards:            rewards,
+		CompletionTimeUnix: completionTimeUnix,
+	}
+}
+
+// ParseTxBeginRedelegate gets a staking tx msg and transpile to the graphql one.
+func ParseTxBeginRedelegate(msg *stakingtypes.MsgBeginRedelegate, rewards string, completionTimeUnix int) MsgBeginRedelegate {
+	return MsgBeginRedelegate{
+		Delegator:          msg.DelegatorAddress,
+		ValidatorSrc:       msg.ValidatorSrcAddress,
+		ValidatorDst:       msg.ValidatorDstAddress,
+		Amount:             msg.Amount.String(),
+		Rewards:            rewards,
+		CompletionTimeUnix: completionTimeUnix,
+	}
+}
+
+// ParseTxCancelUnbondingDelegation gets a staking tx msg and transpile to the graphql one.
+func ParseTxCancelUnbondingDelegation(msg *stakingtypes.MsgCancelUnbondingDelegation) MsgCancelUnbondingDelegation {
+	return MsgCancelUnbondingDelegation{
+		Delegator:      msg.DelegatorAddress,
+		Validator:      msg.ValidatorAddress,
+		Amount:         msg.Amount.String(),
+		CreationHeight: int(msg.CreationHeight),
+	}
+}
+
+// ParseTxWithdrawDelegatorReward gets a distribution tx msg and transpile to the graphql one.
+func ParseTxWithdrawDelegatorReward(msg *distrtypes.MsgWithdrawDelegatorReward, rewards string) MsgWithdrawDelegatorReward {
+	return MsgWithdrawDelegatorReward{
+		Delegator: msg.DelegatorAddress,
+		Validator: msg.ValidatorAddress,
+		Rewards:   rewards,
+	}
+}
+
+// ParseTxWithdrawValidatorCommission gets a distribution tx msg and transpile to the graphql one.
+func ParseTxWithdrawValidatorCommission(msg *distrtypes.MsgWithdrawValidatorCommission, commission string) MsgWithdrawValidatorCommission {
+	return MsgWithdrawValidatorCommission{
+		Validator:  msg.ValidatorAddress,
+		Commission: commission,
+	}
+}
+
+// UnbondingCompletionTimeUnix returns when the unbonding of the delegator from the validator completes, by the
+// unbond event of the tx, as the unbonding time param can change. It returns zero if there is no such event.
+func UnbondingCompletionTimeUnix(events []abcitypes.Event, delegator, validator, amount string) int {
+	return eventCompletionTimeUnix(events, stakingtypes.EventTypeUnbond, func(evt abcitypes.Event) bool {
+		// the delegator attribute is not emitted by every sdk version.
+		if evtDelegator := EventAttr(evt, stakingtypes.AttributeKeyDelegator); evtDelegator != "" && evtDelegator != delegator {
+			return false
+		}
+		return EventAttr(evt, stakingtypes.AttributeKeyValidator) == validator &&
+			EventAttr(evt, sdktypes.AttributeKeyAmount) == amount
+	})
+}
+
+// RedelegationCompletionTimeUnix returns when the redelegation from the source to the destination validator
+// completes, by the redelegate event of the tx. It returns zero if there is no such event.
+func RedelegationCompletionTimeUnix(events []abcitypes.Event, srcValidator, dstValidator, amount string) int {
+	return eventCompletionTimeUnix(events, stakingtypes.EventTypeRedelegate, func(evt abcitypes.Event) bool {
+		return EventAttr(evt, stakingtypes.AttributeKeySrcValidator) == srcValidator &&
+			EventAttr(evt, stakingtypes.AttributeKeyDstValidator) == dstValidator &&
+			EventAttr(evt, sdktypes.AttributeKeyAmount) == amount
+	})
+}
+
+// eventCompletionTimeUnix returns the completion time attribute of the first event of the type accepted by the filter.
+func eventCompletionTimeUnix(events []abcitypes.Event, eventType string, filter func(evt abcitypes.Event) bool) int {
+	for _, evt := range events {
+		if evt.Type != eventType || !filter(evt) {
+			continue
+		}
+
+		completionTime, err := time.Parse(time.RFC3339, EventAttr(evt, stakingtypes.AttributeKeyCompletionTime))
+		if err != nil {
+			continue
+		}
+		return int(completionTime.Unix())
+	}
+	return 0
+}
+
+// WithdrawnRewards sums the rewards withdrawn by the delegator from the validators in the tx events,
+// an empty delegator matches any of them.
+func WithdrawnRewards(events []abcitypes.Event, delegator string, validators ...string) sdktypes.Coins {
+	return sumEventCoins(events, distrtypes.EventTypeWithdrawRewards, func(evt abcitypes.Event) bool {
+		// the delegator attribute is not emitted by every sdk version.
+		if evtDelegator := EventAttr(evt, distrtypes.AttributeKeyDelegator); delegator != "" && evtDelegator != "" && evtDelegator != delegator {
+			return false
+		}
+		for _, validator := range validators {
+			if EventAttr(evt, distrtypes.AttributeKeyValidator) == validator {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithdrawnCommission sums the commission withdrawn in the tx events, the event does not carry the validator
+// so txs withdrawing from more than one validator sum all of them.
+func WithdrawnCommission(events []abcitypes.Event) sdktypes.Coins {
+	return sumEventCoins(events, distrtypes.EventTypeWithdrawCommission, func(abcitypes.Event) bool {
+		return true
+	})
+}
+
+// sumEventCoins sums the amount attribute of the events of the type accepted by the filter.
+func sumEventCoins(events []abcitypes.Event, eventType string, filter func(evt abcitypes.Event) bool) sdktypes.Coins {
+	total := sdktypes.NewCoins()
+	for _, evt := range events {
+		if evt.Type != eventType || !filter(evt) {
+			continue
+		}
+
+		coins, err := sdktypes.ParseCoinsNormalized(EventAttr(evt, sdktypes.AttributeKeyAmount))
+		if err != nil {
+			continue
+		}
+		total = total.Add(coins...)
+	}
+	return total
+}
@@ -0,0 +1,72 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestWithdrawnRewards(t *testing.T) {
+	withdraw := func(amount, validator, delegator string) abcitypes.Event {
+		return abcitypes.Event{Type: "withdraw_rewards", Attributes: []abcitypes.EventAttribute{
+			{Key: "amount", Value: amount},
+			{Key: "validator", Value: validator},
+			{Key: "delegator", Value: delegator},
+		}}
+	}
+	events := []abcitypes.Event{
+		withdraw("10uumee", "valA", "del1"),
+		withdraw("5uumee", "valB", "del1"),
+		withdraw("7uumee", "valA", "del2"),
+		withdraw("3uumee", "valC", ""),
+	}
+
+	require.Equal(t, "10uumee", types.WithdrawnRewards(events, "del1", "valA").String())
+	require.Equal(t, "15uumee", types.WithdrawnRewards(events, "del1", "valA", "valB").String())
+	require.Equal(t, "3uumee", types.WithdrawnRewards(events, "del1", "valC").String())
+	require.Equal(t, "", types.WithdrawnRewards(events, "del1", "valD").String())
+}
+
+func TestCompletionTimeUnix(t *testing.T) {
+	completionTime := time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC)
+	unbond := func(validator, amount, delegator string) abcitypes.Event {
+		return abcitypes.Event{Type: "unbond", Attributes: []abcitypes.EventAttribute{
+			{Key: "validator", Value: validator},
+			{Key: "amount", Value: amount},
+			{Key: "delegator", Value: delegator},
+			{Key: "completion_time", Value: completionTime.Format(time.RFC3339)},
+		}}
+	}
+	events := []abcitypes.Event{
+		unbond("valA", "10uumee", "del1"),
+		unbond("valB", "5uumee", ""),
+		{Type: "redelegate", Attributes: []abcitypes.EventAttribute{
+			{Key: "source_validator", Value: "valA"},
+			{Key: "destination_validator", Value: "valB"},
+			{Key: "amount", Value: "7uumee"},
+			{Key: "completion_time", Value: completionTime.Add(time.Hour).Format(time.RFC3339)},
+		}},
+	}
+
+	tcs := []struct {
+		title    string
+		got      int
+		expected int
+	}{
+		{"unbond", types.UnbondingCompletionTimeUnix(events, "del1", "valA", "10uumee"), int(completionTime.Unix())},
+		{"unbond without delegator attribute", types.UnbondingCompletionTimeUnix(events, "del1", "valB", "5uumee"), int(completionTime.Unix())},
+		{"unbond of other delegator", types.UnbondingCompletionTimeUnix(events, "del2", "valA", "10uumee"), 0},
+		{"unbond of other amount", types.UnbondingCompletionTimeUnix(events, "del1", "valA", "3uumee"), 0},
+		{"redelegate", types.RedelegationCompletionTimeUnix(events, "valA", "valB", "7uumee"), int(completionTime.Add(time.Hour).Unix())},
+		{"redelegate reversed", types.RedelegationCompletionTimeUnix(events, "valB", "valA", "7uumee"), 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.title, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.got)
+		})
+	}
+}
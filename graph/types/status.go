@@ -0,0 +1,66 @@
+package types
+
+// ChainStatus is the indexing coverage of the chain by cosmos msg.
+type ChainStatus struct {
+	ChainID string `json:"chainID"`
+	// ChainHeight is the height of the chain tip.
+	ChainHeight int `json:"chainHeight"`
+	// LowestHeightOnNode is the lowest block height the node still has.
+	LowestHeightOnNode      int                  `json:"lowestHeightOnNode"`
+	LastBlockHeightReceived int                  `json:"lastBlockHeightReceived"`
+	Msgs                    []*CosmosMsgCoverage `json:"msgs"`
+}
+
+// CosmosMsgCoverage is the indexing coverage of a cosmos msg.
+type CosmosMsgCoverage struct {
+	ProtoMsgName string                  `json:"protoMsgName"`
+	Intervals    []*BlockIndexedInterval `json:"intervals"`
+	// BlocksIndexed is the amount of blocks covered by the intervals.
+	BlocksIndexed int `json:"blocksIndexed"`
+	// Gaps are the block heights between the intervals that were not indexed.
+	Gaps []*BlockIndexedInterval `json:"gaps"`
+	// Lag is the amount of blocks between the highest block indexed and the chain tip.
+	Lag int `json:"lag"`
+}
+
+// NewChainStatus returns the coverage of the cosmos msgs of the chain info at the chain height.
+func NewChainStatus(info ChainInfo, chainHeight, lowestHeightOnNode int) ChainStatus {
+	status := ChainStatus{
+		ChainID:                 info.ChainID,
+		ChainHeight:             chainHeight,
+		LowestHeightOnNode:      lowestHeightOnNode,
+		LastBlockHeightReceived: info.LastBlockHeightReceived,
+		Msgs:                    make([]*CosmosMsgCoverage, 0, len(info.CosmosMsgs)),
+	}
+	for _, cosmosMsg := range info.CosmosMsgs {
+		coverage := NewCosmosMsgCoverage(*cosmosMsg, chainHeight)
+		status.Msgs = append(status.Msgs, &coverage)
+	}
+	return status
+}
+
+// NewCosmosMsgCoverage returns the coverage of the intervals indexed of the msg at the chain height.
+func NewCosmosMsgCoverage(cosmosMsg CosmosMsgIndexed, chainHeight int) CosmosMsgCoverage {
+	// merging sorts and joins the intervals without changing the stored ones.
+	intervals := MergeBlockIndexedIntervals(cosmosMsg.BlocksIndexed, nil)
+
+	coverage := CosmosMsgCoverage{
+		ProtoMsgName: cosmosMsg.ProtoMsgName,
+		Intervals:    intervals,
+		Gaps:         make([]*BlockIndexedInterval, 0),
+		Lag:          chainHeight,
+	}
+	for i, blkIndexed := range intervals {
+		coverage.BlocksIndexed += blkIndexed.IdxToBlockHeight - blkIndexed.IdxFromBlockHeight + 1
+		if i > 0 {
+			coverage.Gaps = append(coverage.Gaps, &BlockIndexedInterval{
+				IdxFromBlockHeight: intervals[i-1].IdxToBlockHeight + 1,
+				IdxToBlockHeight:   blkIndexed.IdxFromBlockHeight - 1,
+			})
+		}
+	}
+	if len(intervals) > 0 {
+		coverage.Lag = max(chainHeight-intervals[len(intervals)-1].IdxToBlockHeight, 0)
+	}
+	return coverage
+}
@@ -0,0 +1,27 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestNewCosmosMsgCoverage(t *testing.T) {
+	coverage := types.NewCosmosMsgCoverage(*msgCosmosLiquidate(20, 30, 3, 10, 11, 15), 40)
+	require.Equal(t, types.CosmosMsgCoverage{
+		ProtoMsgName:  types.MsgNameLiquidate,
+		Intervals:     blockIntervals(3, 15, 20, 30),
+		BlocksIndexed: 24,
+		Gaps:          blockIntervals(16, 19),
+		Lag:           10,
+	}, coverage)
+
+	coverage = types.NewCosmosMsgCoverage(*msgCosmosLiquidate(), 40)
+	require.Equal(t, types.CosmosMsgCoverage{
+		ProtoMsgName: types.MsgNameLiquidate,
+		Intervals:    blockIntervals(),
+		Gaps:         blockIntervals(),
+		Lag:          40,
+	}, coverage)
+}
@@ -24,3 +24,42 @@ func ParseTxLeverageLiquidate(lvgMsg *lvgtypes.MsgLeveragedLiquidate) MsgLeverag
 		MaxRepay:    lvgMsg.MaxRepay.String(),
 	}
 }
+
+// ParseTxGovUpdateRegistry gets an lvg tx msg and transpile to the graphql one.
+func ParseTxGovUpdateRegistry(lvgMsg *lvgtypes.MsgGovUpdateRegistry) MsgGovUpdateRegistry {
+	return MsgGovUpdateRegistry{
+		Authority:    lvgMsg.Authority,
+		Description:  lvgMsg.Description,
+		AddTokens:    ParseLeverageTokens(lvgMsg.AddTokens),
+		UpdateTokens: ParseLeverageTokens(lvgMsg.UpdateTokens),
+	}
+}
+
+// ParseLeverageTokens transpiles the registry tokens to the graphql ones.
+func ParseLeverageTokens(tokens []lvgtypes.Token) []*LeverageToken {
+	parsed := make([]*LeverageToken, len(tokens))
+	for i, token := range tokens {
+		parsed[i] = &LeverageToken{
+			BaseDenom:              token.BaseDenom,
+			SymbolDenom:            token.SymbolDenom,
+			Exponent:               int(token.Exponent),
+			ReserveFactor:          token.ReserveFactor.String(),
+			CollateralWeight:       token.CollateralWeight.String(),
+			LiquidationThreshold:   token.LiquidationThreshold.String(),
+			BaseBorrowRate:         token.BaseBorrowRate.String(),
+			KinkBorrowRate:         token.KinkBorrowRate.String(),
+			MaxBorrowRate:          token.MaxBorrowRate.String(),
+			KinkUtilization:        token.KinkUtilization.String(),
+			LiquidationIncentive:   token.LiquidationIncentive.String(),
+			EnableMsgSupply:        token.EnableMsgSupply,
+			EnableMsgBorrow:        token.EnableMsgBorrow,
+			Blacklist:              token.Blacklist,
+			MaxCollateralShare:     token.MaxCollateralShare.String(),
+			MaxSupplyUtilization:   token.MaxSupplyUtilization.String(),
+			MinCollateralLiquidity: token.MinCollateralLiquidity.String(),
+			MaxSupply:              token.MaxSupply.String(),
+			HistoricMedians:        int(token.HistoricMedians),
+		}
+	}
+	return parsed
+}
@@ -2,6 +2,28 @@
 
 package types
 
+type BalanceChange struct {
+	Address       string `json:"address" firestore:"address"`
+	Denom         string `json:"denom" firestore:"denom"`
+	Amount        string `json:"amount" firestore:"amount"`
+	Received      string `json:"received" firestore:"received"`
+	Spent         string `json:"spent" firestore:"spent"`
+	Source        string `json:"source" firestore:"source"`
+	TxHash        string `json:"txHash" firestore:"txHash"`
+	BlockHeight   int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+}
+
+type BalanceChangePage struct {
+	Changes    []*BalanceChange `json:"changes"`
+	NextCursor *string          `json:"nextCursor,omitempty"`
+}
+
+type BankTransferCoins struct {
+	Address string `json:"address" firestore:"address"`
+	Coins   string `json:"coins" firestore:"coins"`
+}
+
 type BlockIndexedInterval struct {
 	IdxFromBlockHeight int `json:"idxFromBlockHeight" firestore:"idxFromBlockHeight"`
 	IdxToBlockHeight   int `json:"idxToBlockHeight" firestore:"idxToBlockHeight"`
@@ -19,13 +41,332 @@ type CosmosMsgIndexed struct {
 	BlocksIndexed []*BlockIndexedInterval `json:"blocksIndexed" firestore:"blocksIndexed"`
 }
 
+type DeadLetter struct {
+	ID            string `json:"id" firestore:"id"`
+	BlockHeight   int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	Handler       string `json:"handler" firestore:"handler"`
+	TxHash        string `json:"txHash" firestore:"txHash"`
+	Tx            string `json:"tx" firestore:"tx"`
+	MsgPath       string `json:"msgPath" firestore:"msgPath"`
+	ProtoMsgName  string `json:"protoMsgName" firestore:"protoMsgName"`
+	Error         string `json:"error" firestore:"error"`
+	Attempts      int    `json:"attempts" firestore:"attempts"`
+	FailedAtUnix  int    `json:"failedAtUnix" firestore:"failedAtUnix"`
+}
+
+type DeadLetterCount struct {
+	Handler      string `json:"handler"`
+	ProtoMsgName string `json:"protoMsgName"`
+	Count        int    `json:"count"`
+}
+
+type GovProposal struct {
+	ProposalID        int                `json:"proposalID" firestore:"proposalID"`
+	Proposer          string             `json:"proposer" firestore:"proposer"`
+	Title             string             `json:"title" firestore:"title"`
+	Summary           string             `json:"summary" firestore:"summary"`
+	Metadata          string             `json:"metadata" firestore:"metadata"`
+	Messages          []*GovProposalMsg  `json:"messages" firestore:"messages"`
+	Status            string             `json:"status" firestore:"status"`
+	StatusChanges     []*GovStatusChange `json:"statusChanges" firestore:"statusChanges"`
+	TotalDeposit      string             `json:"totalDeposit" firestore:"totalDeposit"`
+	SubmitTxHash      string             `json:"submitTxHash" firestore:"submitTxHash"`
+	SubmitBlockHeight int                `json:"submitBlockHeight" firestore:"submitBlockHeight"`
+	SubmitTimeUnix    int                `json:"submitTimeUnix" firestore:"submitTimeUnix"`
+	FinalTally        *GovTally          `json:"finalTally,omitempty" firestore:"finalTally"`
+}
+
+type GovProposalMsg struct {
+	ProtoMsgName              string                     `json:"protoMsgName" firestore:"protoMsgName"`
+	Content                   string                     `json:"content" firestore:"content"`
+	MsgGovUpdateRegistry      *MsgGovUpdateRegistry      `json:"msgGovUpdateRegistry,omitempty" firestore:"msgGovUpdateRegistry"`
+	MsgGovUpdateSpecialAssets *MsgGovUpdateSpecialAssets `json:"msgGovUpdateSpecialAssets,omitempty" firestore:"msgGovUpdateSpecialAssets"`
+	MsgGovUpdateQuota         *MsgGovUpdateQuota         `json:"msgGovUpdateQuota,omitempty" firestore:"msgGovUpdateQuota"`
+	MsgGovSetIBCStatus        *MsgGovSetIBCStatus        `json:"msgGovSetIBCStatus,omitempty" firestore:"msgGovSetIBCStatus"`
+	MsgGovCreatePrograms      *MsgGovCreatePrograms      `json:"msgGovCreatePrograms,omitempty" firestore:"msgGovCreatePrograms"`
+}
+
+type GovStatusChange struct {
+	Status        string `json:"status" firestore:"status"`
+	BlockHeight   int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+}
+
+type GovTally struct {
+	Yes        string `json:"yes" firestore:"yes"`
+	Abstain    string `json:"abstain" firestore:"abstain"`
+	No         string `json:"no" firestore:"no"`
+	NoWithVeto string `json:"noWithVeto" firestore:"noWithVeto"`
+}
+
+type GovVoteCount struct {
+	Option string `json:"option"`
+	Voters string `json:"voters"`
+}
+
+type GovWeightedOption struct {
+	Option string `json:"option" firestore:"option"`
+	Weight string `json:"weight" firestore:"weight"`
+}
+
+type IBCDenomTrace struct {
+	Path      string `json:"path" firestore:"path"`
+	BaseDenom string `json:"baseDenom" firestore:"baseDenom"`
+}
+
+type IBCTransfer struct {
+	Direction          string             `json:"direction" firestore:"direction"`
+	Status             string             `json:"status" firestore:"status"`
+	Sequence           int                `json:"sequence" firestore:"sequence"`
+	SourcePort         string             `json:"sourcePort" firestore:"sourcePort"`
+	SourceChannel      string             `json:"sourceChannel" firestore:"sourceChannel"`
+	DestinationPort    string             `json:"destinationPort" firestore:"destinationPort"`
+	DestinationChannel string             `json:"destinationChannel" firestore:"destinationChannel"`
+	Sender             string             `json:"sender" firestore:"sender"`
+	Receiver           string             `json:"receiver" firestore:"receiver"`
+	Denom              string             `json:"denom" firestore:"denom"`
+	Amount             string             `json:"amount" firestore:"amount"`
+	DenomTrace         *IBCDenomTrace     `json:"denomTrace" firestore:"denomTrace"`
+	Memo               string             `json:"memo" firestore:"memo"`
+	AckError           string             `json:"ackError" firestore:"ackError"`
+	Steps              []*IBCTransferStep `json:"steps" firestore:"steps"`
+	LastBlockTimeUnix  int                `json:"lastBlockTimeUnix" firestore:"lastBlockTimeUnix"`
+	Execution          *MsgExecution      `json:"execution,omitempty" firestore:"execution"`
+}
+
+type IBCTransferStep struct {
+	Status        string        `json:"status" firestore:"status"`
+	TxHash        string        `json:"txHash" firestore:"txHash"`
+	BlockHeight   int           `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int           `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	Execution     *MsgExecution `json:"execution,omitempty" firestore:"execution"`
+}
+
+type IncentiveBondedBalance struct {
+	TxHash        string `json:"txHash"`
+	ProtoMsgName  string `json:"protoMsgName"`
+	BlockHeight   int    `json:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix"`
+	Change        string `json:"change"`
+	Bonded        string `json:"bonded"`
+}
+
+type IncentiveProgram struct {
+	ID               int    `json:"id" firestore:"id"`
+	StartTime        int    `json:"startTime" firestore:"startTime"`
+	Duration         int    `json:"duration" firestore:"duration"`
+	UToken           string `json:"uToken" firestore:"uToken"`
+	Funded           bool   `json:"funded" firestore:"funded"`
+	TotalRewards     string `json:"totalRewards" firestore:"totalRewards"`
+	RemainingRewards string `json:"remainingRewards" firestore:"remainingRewards"`
+}
+
+type IncentiveProgramHistory struct {
+	ProgramID int                         `json:"programID"`
+	Fundings  []*IndexedTx                `json:"fundings"`
+	Payouts   []*IncentiveProgramSnapshot `json:"payouts"`
+}
+
+type IncentiveProgramSnapshot struct {
+	ProgramID        int    `json:"programID" firestore:"programID"`
+	BlockHeight      int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix    int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	UToken           string `json:"uToken" firestore:"uToken"`
+	TotalRewards     string `json:"totalRewards" firestore:"totalRewards"`
+	RemainingRewards string `json:"remainingRewards" firestore:"remainingRewards"`
+	PaidRewards      string `json:"paidRewards" firestore:"paidRewards"`
+}
+
 type IndexedTx struct {
-	TxHash               string                `json:"txHash" firestore:"txHash"`
-	ProtoMsgName         string                `json:"protoMsgName" firestore:"protoMsgName"`
-	BlockHeight          int                   `json:"blockHeight" firestore:"blockHeight"`
-	BlockTimeUnix        int                   `json:"blockTimeUnix" firestore:"blockTimeUnix"`
-	MsgLiquidate         *MsgLiquidate         `json:"msgLiquidate,omitempty" firestore:"msgLiquidate"`
-	MsgLeverageLiquidate *MsgLeverageLiquidate `json:"msgLeverageLiquidate,omitempty" firestore:"msgLeverageLiquidate"`
+	TxHash                         string                          `json:"txHash" firestore:"txHash"`
+	ProtoMsgName                   string                          `json:"protoMsgName" firestore:"protoMsgName"`
+	BlockHeight                    int                             `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix                  int                             `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	Execution                      *MsgExecution                   `json:"execution,omitempty" firestore:"execution"`
+	MsgLiquidate                   *MsgLiquidate                   `json:"msgLiquidate,omitempty" firestore:"msgLiquidate"`
+	MsgLeverageLiquidate           *MsgLeverageLiquidate           `json:"msgLeverageLiquidate,omitempty" firestore:"msgLeverageLiquidate"`
+	MsgDelegateFeedConsent         *MsgDelegateFeedConsent         `json:"msgDelegateFeedConsent,omitempty" firestore:"msgDelegateFeedConsent"`
+	MsgBond                        *MsgBond                        `json:"msgBond,omitempty" firestore:"msgBond"`
+	MsgBeginUnbonding              *MsgBeginUnbonding              `json:"msgBeginUnbonding,omitempty" firestore:"msgBeginUnbonding"`
+	MsgEmergencyUnbond             *MsgEmergencyUnbond             `json:"msgEmergencyUnbond,omitempty" firestore:"msgEmergencyUnbond"`
+	MsgClaim                       *MsgClaim                       `json:"msgClaim,omitempty" firestore:"msgClaim"`
+	MsgSponsor                     *MsgSponsor                     `json:"msgSponsor,omitempty" firestore:"msgSponsor"`
+	MsgGovCreatePrograms           *MsgGovCreatePrograms           `json:"msgGovCreatePrograms,omitempty" firestore:"msgGovCreatePrograms"`
+	MsgSwap                        *MsgSwap                        `json:"msgSwap,omitempty" firestore:"msgSwap"`
+	MsgRedeem                      *MsgRedeem                      `json:"msgRedeem,omitempty" firestore:"msgRedeem"`
+	MsgGovUpdateQuota              *MsgGovUpdateQuota              `json:"msgGovUpdateQuota,omitempty" firestore:"msgGovUpdateQuota"`
+	MsgGovSetIBCStatus             *MsgGovSetIBCStatus             `json:"msgGovSetIBCStatus,omitempty" firestore:"msgGovSetIBCStatus"`
+	MsgDelegate                    *MsgDelegate                    `json:"msgDelegate,omitempty" firestore:"msgDelegate"`
+	MsgUndelegate                  *MsgUndelegate                  `json:"msgUndelegate,omitempty" firestore:"msgUndelegate"`
+	MsgBeginRedelegate             *MsgBeginRedelegate             `json:"msgBeginRedelegate,omitempty" firestore:"msgBeginRedelegate"`
+	MsgCancelUnbondingDelegation   *MsgCancelUnbondingDelegation   `json:"msgCancelUnbondingDelegation,omitempty" firestore:"msgCancelUnbondingDelegation"`
+	MsgWithdrawDelegatorReward     *MsgWithdrawDelegatorReward     `json:"msgWithdrawDelegatorReward,omitempty" firestore:"msgWithdrawDelegatorReward"`
+	MsgWithdrawValidatorCommission *MsgWithdrawValidatorCommission `json:"msgWithdrawValidatorCommission,omitempty" firestore:"msgWithdrawValidatorCommission"`
+	MsgGovUpdateRegistry           *MsgGovUpdateRegistry           `json:"msgGovUpdateRegistry,omitempty" firestore:"msgGovUpdateRegistry"`
+	MsgGovUpdateSpecialAssets      *MsgGovUpdateSpecialAssets      `json:"msgGovUpdateSpecialAssets,omitempty" firestore:"msgGovUpdateSpecialAssets"`
+	MsgSend                        *MsgSend                        `json:"msgSend,omitempty" firestore:"msgSend"`
+	MsgMultiSend                   *MsgMultiSend                   `json:"msgMultiSend,omitempty" firestore:"msgMultiSend"`
+	MsgRawAny                      *MsgRawAny                      `json:"msgRawAny,omitempty" firestore:"msgRawAny"`
+	MsgSubmitProposal              *MsgSubmitProposal              `json:"msgSubmitProposal,omitempty" firestore:"msgSubmitProposal"`
+	MsgDeposit                     *MsgDeposit                     `json:"msgDeposit,omitempty" firestore:"msgDeposit"`
+	MsgVote                        *MsgVote                        `json:"msgVote,omitempty" firestore:"msgVote"`
+	MsgVoteWeighted                *MsgVoteWeighted                `json:"msgVoteWeighted,omitempty" firestore:"msgVoteWeighted"`
+}
+
+type LeverageSpecialPair struct {
+	Collateral           string `json:"collateral" firestore:"collateral"`
+	Borrow               string `json:"borrow" firestore:"borrow"`
+	CollateralWeight     string `json:"collateralWeight" firestore:"collateralWeight"`
+	LiquidationThreshold string `json:"liquidationThreshold" firestore:"liquidationThreshold"`
+}
+
+type LeverageToken struct {
+	BaseDenom              string `json:"baseDenom" firestore:"baseDenom"`
+	SymbolDenom            string `json:"symbolDenom" firestore:"symbolDenom"`
+	Exponent               int    `json:"exponent" firestore:"exponent"`
+	ReserveFactor          string `json:"reserveFactor" firestore:"reserveFactor"`
+	CollateralWeight       string `json:"collateralWeight" firestore:"collateralWeight"`
+	LiquidationThreshold   string `json:"liquidationThreshold" firestore:"liquidationThreshold"`
+	BaseBorrowRate         string `json:"baseBorrowRate" firestore:"baseBorrowRate"`
+	KinkBorrowRate         string `json:"kinkBorrowRate" firestore:"kinkBorrowRate"`
+	MaxBorrowRate          string `json:"maxBorrowRate" firestore:"maxBorrowRate"`
+	KinkUtilization        string `json:"kinkUtilization" firestore:"kinkUtilization"`
+	LiquidationIncentive   string `json:"liquidationIncentive" firestore:"liquidationIncentive"`
+	EnableMsgSupply        bool   `json:"enableMsgSupply" firestore:"enableMsgSupply"`
+	EnableMsgBorrow        bool   `json:"enableMsgBorrow" firestore:"enableMsgBorrow"`
+	Blacklist              bool   `json:"blacklist" firestore:"blacklist"`
+	MaxCollateralShare     string `json:"maxCollateralShare" firestore:"maxCollateralShare"`
+	MaxSupplyUtilization   string `json:"maxSupplyUtilization" firestore:"maxSupplyUtilization"`
+	MinCollateralLiquidity string `json:"minCollateralLiquidity" firestore:"minCollateralLiquidity"`
+	MaxSupply              string `json:"maxSupply" firestore:"maxSupply"`
+	HistoricMedians        int    `json:"historicMedians" firestore:"historicMedians"`
+}
+
+type MetokenAssetBalance struct {
+	Denom     string `json:"denom" firestore:"denom"`
+	Leveraged string `json:"leveraged" firestore:"leveraged"`
+	Reserved  string `json:"reserved" firestore:"reserved"`
+	Fees      string `json:"fees" firestore:"fees"`
+	Interest  string `json:"interest" firestore:"interest"`
+}
+
+type MetokenIndexSnapshot struct {
+	MetokenDenom  string                 `json:"metokenDenom" firestore:"metokenDenom"`
+	BlockHeight   int                    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int                    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	MetokenSupply string                 `json:"metokenSupply" firestore:"metokenSupply"`
+	AssetBalances []*MetokenAssetBalance `json:"assetBalances" firestore:"assetBalances"`
+}
+
+type MetokenVolume struct {
+	MetokenDenom string `json:"metokenDenom"`
+	AssetDenom   string `json:"assetDenom"`
+	Count        int    `json:"count"`
+	Asset        string `json:"asset"`
+	Metoken      string `json:"metoken"`
+	Fee          string `json:"fee"`
+}
+
+type MsgBeginRedelegate struct {
+	Delegator          string `json:"delegator" firestore:"delegator"`
+	ValidatorSrc       string `json:"validatorSrc" firestore:"validatorSrc"`
+	ValidatorDst       string `json:"validatorDst" firestore:"validatorDst"`
+	Amount             string `json:"amount" firestore:"amount"`
+	Rewards            string `json:"rewards" firestore:"rewards"`
+	CompletionTimeUnix int    `json:"completionTimeUnix" firestore:"completionTimeUnix"`
+}
+
+type MsgBeginUnbonding struct {
+	Account string `json:"account" firestore:"account"`
+	UToken  string `json:"uToken" firestore:"uToken"`
+	Rewards string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgBond struct {
+	Account string `json:"account" firestore:"account"`
+	UToken  string `json:"uToken" firestore:"uToken"`
+	Rewards string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgCancelUnbondingDelegation struct {
+	Delegator      string `json:"delegator" firestore:"delegator"`
+	Validator      string `json:"validator" firestore:"validator"`
+	Amount         string `json:"amount" firestore:"amount"`
+	CreationHeight int    `json:"creationHeight" firestore:"creationHeight"`
+}
+
+type MsgClaim struct {
+	Account string `json:"account" firestore:"account"`
+	Rewards string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgDelegate struct {
+	Delegator string `json:"delegator" firestore:"delegator"`
+	Validator string `json:"validator" firestore:"validator"`
+	Amount    string `json:"amount" firestore:"amount"`
+	Rewards   string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgDelegateFeedConsent struct {
+	Operator string `json:"operator" firestore:"operator"`
+	Delegate string `json:"delegate" firestore:"delegate"`
+}
+
+type MsgDeposit struct {
+	ProposalID    int    `json:"proposalID" firestore:"proposalID"`
+	Depositor     string `json:"depositor" firestore:"depositor"`
+	Amount        string `json:"amount" firestore:"amount"`
+	VotingStarted bool   `json:"votingStarted" firestore:"votingStarted"`
+}
+
+type MsgEmergencyUnbond struct {
+	Account string `json:"account" firestore:"account"`
+	UToken  string `json:"uToken" firestore:"uToken"`
+	Rewards string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgExecution struct {
+	Path    string `json:"path" firestore:"path"`
+	Wrapper string `json:"wrapper" firestore:"wrapper"`
+	Grantee string `json:"grantee" firestore:"grantee"`
+	Granter string `json:"granter" firestore:"granter"`
+}
+
+type MsgGovCreatePrograms struct {
+	Authority         string              `json:"authority" firestore:"authority"`
+	FromCommunityFund bool                `json:"fromCommunityFund" firestore:"fromCommunityFund"`
+	Programs          []*IncentiveProgram `json:"programs" firestore:"programs"`
+}
+
+type MsgGovSetIBCStatus struct {
+	Authority   string `json:"authority" firestore:"authority"`
+	Description string `json:"description" firestore:"description"`
+	IbcStatus   string `json:"ibcStatus" firestore:"ibcStatus"`
+}
+
+type MsgGovUpdateQuota struct {
+	Authority                   string `json:"authority" firestore:"authority"`
+	Description                 string `json:"description" firestore:"description"`
+	Total                       string `json:"total" firestore:"total"`
+	PerDenom                    string `json:"perDenom" firestore:"perDenom"`
+	QuotaDuration               int    `json:"quotaDuration" firestore:"quotaDuration"`
+	InflowOutflowQuotaBase      string `json:"inflowOutflowQuotaBase" firestore:"inflowOutflowQuotaBase"`
+	InflowOutflowQuotaRate      string `json:"inflowOutflowQuotaRate" firestore:"inflowOutflowQuotaRate"`
+	InflowOutflowTokenQuotaBase string `json:"inflowOutflowTokenQuotaBase" firestore:"inflowOutflowTokenQuotaBase"`
+}
+
+type MsgGovUpdateRegistry struct {
+	Authority    string           `json:"authority" firestore:"authority"`
+	Description  string           `json:"description" firestore:"description"`
+	AddTokens    []*LeverageToken `json:"addTokens" firestore:"addTokens"`
+	UpdateTokens []*LeverageToken `json:"updateTokens" firestore:"updateTokens"`
+}
+
+type MsgGovUpdateSpecialAssets struct {
+	Authority   string                 `json:"authority" firestore:"authority"`
+	Description string                 `json:"description" firestore:"description"`
+	Pairs       []*LeverageSpecialPair `json:"pairs" firestore:"pairs"`
 }
 
 type MsgLeverageLiquidate struct {
@@ -43,5 +384,176 @@ type MsgLiquidate struct {
 	RewardDenom string `json:"rewardDenom" firestore:"rewardDenom"`
 }
 
+type MsgMultiSend struct {
+	Inputs    []*BankTransferCoins `json:"inputs" firestore:"inputs"`
+	Outputs   []*BankTransferCoins `json:"outputs" firestore:"outputs"`
+	Addresses []string             `json:"addresses" firestore:"addresses"`
+}
+
+type MsgRawAny struct {
+	TypeURL string `json:"typeURL" firestore:"typeURL"`
+	Value   string `json:"value" firestore:"value"`
+}
+
+type MsgRedeem struct {
+	User         string `json:"user" firestore:"user"`
+	MetokenDenom string `json:"metokenDenom" firestore:"metokenDenom"`
+	AssetDenom   string `json:"assetDenom" firestore:"assetDenom"`
+	Metoken      string `json:"metoken" firestore:"metoken"`
+	Asset        string `json:"asset" firestore:"asset"`
+	Fee          string `json:"fee" firestore:"fee"`
+}
+
+type MsgSend struct {
+	FromAddress string `json:"fromAddress" firestore:"fromAddress"`
+	ToAddress   string `json:"toAddress" firestore:"toAddress"`
+	Amount      string `json:"amount" firestore:"amount"`
+}
+
+type MsgSponsor struct {
+	Sponsor string `json:"sponsor" firestore:"sponsor"`
+	Program int    `json:"program" firestore:"program"`
+	Amount  string `json:"amount" firestore:"amount"`
+}
+
+type MsgSubmitProposal struct {
+	ProposalID     int      `json:"proposalID" firestore:"proposalID"`
+	Proposer       string   `json:"proposer" firestore:"proposer"`
+	Title          string   `json:"title" firestore:"title"`
+	InitialDeposit string   `json:"initialDeposit" firestore:"initialDeposit"`
+	MsgNames       []string `json:"msgNames" firestore:"msgNames"`
+}
+
+type MsgSwap struct {
+	User         string `json:"user" firestore:"user"`
+	MetokenDenom string `json:"metokenDenom" firestore:"metokenDenom"`
+	AssetDenom   string `json:"assetDenom" firestore:"assetDenom"`
+	Asset        string `json:"asset" firestore:"asset"`
+	Metoken      string `json:"metoken" firestore:"metoken"`
+	Fee          string `json:"fee" firestore:"fee"`
+}
+
+type MsgUndelegate struct {
+	Delegator          string `json:"delegator" firestore:"delegator"`
+	Validator          string `json:"validator" firestore:"validator"`
+	Amount             string `json:"amount" firestore:"amount"`
+	Rewards            string `json:"rewards" firestore:"rewards"`
+	CompletionTimeUnix int    `json:"completionTimeUnix" firestore:"completionTimeUnix"`
+}
+
+type MsgVote struct {
+	ProposalID int    `json:"proposalID" firestore:"proposalID"`
+	Voter      string `json:"voter" firestore:"voter"`
+	Option     string `json:"option" firestore:"option"`
+	Metadata   string `json:"metadata" firestore:"metadata"`
+}
+
+type MsgVoteWeighted struct {
+	ProposalID int                  `json:"proposalID" firestore:"proposalID"`
+	Voter      string               `json:"voter" firestore:"voter"`
+	Options    []*GovWeightedOption `json:"options" firestore:"options"`
+	Metadata   string               `json:"metadata" firestore:"metadata"`
+}
+
+type MsgWithdrawDelegatorReward struct {
+	Delegator string `json:"delegator" firestore:"delegator"`
+	Validator string `json:"validator" firestore:"validator"`
+	Rewards   string `json:"rewards" firestore:"rewards"`
+}
+
+type MsgWithdrawValidatorCommission struct {
+	Validator  string `json:"validator" firestore:"validator"`
+	Commission string `json:"commission" firestore:"commission"`
+}
+
+type OracleValidatorPerformance struct {
+	Validator             string `json:"validator" firestore:"validator"`
+	SlashWindow           int    `json:"slashWindow" firestore:"slashWindow"`
+	WindowFromBlockHeight int    `json:"windowFromBlockHeight" firestore:"windowFromBlockHeight"`
+	WindowToBlockHeight   int    `json:"windowToBlockHeight" firestore:"windowToBlockHeight"`
+	Votes                 int    `json:"votes" firestore:"votes"`
+	Misses                int    `json:"misses" firestore:"misses"`
+}
+
+type OracleValidatorVote struct {
+	Validator     string `json:"validator" firestore:"validator"`
+	Feeder        string `json:"feeder" firestore:"feeder"`
+	Voted         bool   `json:"voted" firestore:"voted"`
+	VotePeriod    int    `json:"votePeriod" firestore:"votePeriod"`
+	SlashWindow   int    `json:"slashWindow" firestore:"slashWindow"`
+	BlockHeight   int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	TxHash        string `json:"txHash" firestore:"txHash"`
+}
+
 type Query struct {
 }
+
+type TokenRegistryChange struct {
+	Denom         string                 `json:"denom" firestore:"denom"`
+	ProtoMsgName  string                 `json:"protoMsgName" firestore:"protoMsgName"`
+	BlockHeight   int                    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int                    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	ProposalID    int                    `json:"proposalID" firestore:"proposalID"`
+	TxHash        string                 `json:"txHash" firestore:"txHash"`
+	Token         *LeverageToken         `json:"token,omitempty" firestore:"token"`
+	SpecialPairs  []*LeverageSpecialPair `json:"specialPairs" firestore:"specialPairs"`
+}
+
+type TokenRegistryDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type TokenRegistryVersion struct {
+	Version         int                    `json:"version"`
+	Denom           string                 `json:"denom"`
+	BlockHeight     int                    `json:"blockHeight"`
+	BlockTimeUnix   int                    `json:"blockTimeUnix"`
+	EffectiveHeight int                    `json:"effectiveHeight"`
+	ProposalID      int                    `json:"proposalID"`
+	TxHash          string                 `json:"txHash"`
+	ProtoMsgName    string                 `json:"protoMsgName"`
+	Token           *LeverageToken         `json:"token,omitempty"`
+	SpecialPairs    []*LeverageSpecialPair `json:"specialPairs"`
+	Diff            []*TokenRegistryDiff   `json:"diff"`
+}
+
+type UIBCDenomOutflow struct {
+	Denom     string `json:"denom" firestore:"denom"`
+	Symbol    string `json:"symbol" firestore:"symbol"`
+	AmountUsd string `json:"amountUSD" firestore:"amountUSD"`
+}
+
+type UIBCEvent struct {
+	EventType     string `json:"eventType" firestore:"eventType"`
+	BlockHeight   int    `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix int    `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	TxHash        string `json:"txHash" firestore:"txHash"`
+	FailureType   string `json:"failureType" firestore:"failureType"`
+	Packet        string `json:"packet" firestore:"packet"`
+	Sender        string `json:"sender" firestore:"sender"`
+	Token         string `json:"token" firestore:"token"`
+	Log           string `json:"log" firestore:"log"`
+}
+
+type UIBCOutflowWindow struct {
+	Denom              string `json:"denom" firestore:"denom"`
+	Window             int    `json:"window" firestore:"window"`
+	WindowFromTimeUnix int    `json:"windowFromTimeUnix" firestore:"windowFromTimeUnix"`
+	WindowToTimeUnix   int    `json:"windowToTimeUnix" firestore:"windowToTimeUnix"`
+	Amount             string `json:"amount" firestore:"amount"`
+	Transfers          int    `json:"transfers" firestore:"transfers"`
+}
+
+type UIBCQuotaSnapshot struct {
+	BlockHeight      int                 `json:"blockHeight" firestore:"blockHeight"`
+	BlockTimeUnix    int                 `json:"blockTimeUnix" firestore:"blockTimeUnix"`
+	IbcStatus        string              `json:"ibcStatus" firestore:"ibcStatus"`
+	TotalQuota       string              `json:"totalQuota" firestore:"totalQuota"`
+	TokenQuota       string              `json:"tokenQuota" firestore:"tokenQuota"`
+	QuotaExpiresUnix int                 `json:"quotaExpiresUnix" firestore:"quotaExpiresUnix"`
+	TotalOutflowUsd  string              `json:"totalOutflowUSD" firestore:"totalOutflowUSD"`
+	Outflows         []*UIBCDenomOutflow `json:"outflows" firestore:"outflows"`
+}
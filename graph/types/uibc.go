@@ -0,0 +1,141 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/uibc"
+)
+
+const (
+	// UIBCEventQuotaExceeded is the event type of the IBC transfers rejected by the uibc quota.
+	UIBCEventQuotaExceeded = "QuotaExceeded"
+)
+
+var (
+	MsgNameGovUpdateQuota  = proto.MessageName(&uibc.MsgGovUpdateQuota{})
+	MsgNameGovSetIBCStatus = proto.MessageName(&uibc.MsgGovSetIBCStatus{})
+	UIBCEventBadRevert     = proto.MessageName(&uibc.EventBadRevert{})
+)
+
+// ParseTxGovUpdateQuota gets an uibc tx msg and transpile to the graphql one.
+func ParseTxGovUpdateQuota(msg *uibc.MsgGovUpdateQuota) MsgGovUpdateQuota {
+	return MsgGovUpdateQuota{
+		Authority:                   msg.Authority,
+		Description:                 msg.Description,
+		Total:                       msg.Total.String(),
+		PerDenom:                    msg.PerDenom.String(),
+		QuotaDuration:               int(msg.QuotaDuration.Seconds()),
+		InflowOutflowQuotaBase:      msg.InflowOutflowQuotaBase.String(),
+		InflowOutflowQuotaRate:      msg.InflowOutflowQuotaRate.String(),
+		InflowOutflowTokenQuotaBase: msg.InflowOutflowTokenQuotaBase.String(),
+	}
+}
+
+// ParseTxGovSetIBCStatus gets an uibc tx msg and transpile to the graphql one.
+func ParseTxGovSetIBCStatus(msg *uibc.MsgGovSetIBCStatus) MsgGovSetIBCStatus {
+	return MsgGovSetIBCStatus{
+		Authority:   msg.Authority,
+		Description: msg.Description,
+		IbcStatus:   msg.IbcStatus.String(),
+	}
+}
+
+// ParseEventBadRevert transpiles the uibc event to the graphql one.
+func ParseEventBadRevert(evt *uibc.EventBadRevert, blockHeight, blockTimeUnix int, txHash string) UIBCEvent {
+	return UIBCEvent{
+		EventType:     UIBCEventBadRevert,
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+		TxHash:        txHash,
+		FailureType:   evt.FailureType,
+		Packet:        evt.Packet,
+	}
+}
+
+// NewUIBCQuotaExceeded returns the event of an IBC transfer rejected by the uibc quota.
+func NewUIBCQuotaExceeded(sender string, token sdktypes.Coin, log string, blockHeight, blockTimeUnix int, txHash string) UIBCEvent {
+	return UIBCEvent{
+		EventType:     UIBCEventQuotaExceeded,
+		BlockHeight:   blockHeight,
+		BlockTimeUnix: blockTimeUnix,
+		TxHash:        txHash,
+		Sender:        sender,
+		Token:         token.String(),
+		Log:           log,
+	}
+}
+
+// IsUIBCQuotaExceeded returns true if the tx failed because of the uibc quota.
+func IsUIBCQuotaExceeded(codespace string, code uint32) bool {
+	return codespace == uibc.ErrQuotaExceeded.Codespace() && code == uibc.ErrQuotaExceeded.ABCICode()
+}
+
+// NewUIBCOutflowWindow returns the outflow of a single transfer inside of the quota window of the chain at the
+// block of the transfer. The windows are identified by when they expire, the chain resets the quota on the first
+// block after it, and start the quota duration before.
+func NewUIBCOutflowWindow(token sdktypes.Coin, quotaExpires time.Time, quotaDuration time.Duration) UIBCOutflowWindow {
+	to := int(quotaExpires.Unix())
+	return UIBCOutflowWindow{
+		Denom:              token.Denom,
+		Window:             to,
+		WindowFromTimeUnix: to - int(quotaDuration.Seconds()),
+		WindowToTimeUnix:   to,
+		Amount:             token.Amount.String(),
+		Transfers:          1,
+	}
+}
+
+// UIBCOutflowTransfer is a transfer already added to the outflow of its quota window, so handling the same
+// tx again does not add it twice.
+type UIBCOutflowTransfer struct {
+	TxHash string `json:"txHash" firestore:"txHash"`
+	// TransferID is the id of the IBC transfer doc, a tx can send more than one transfer.
+	TransferID  string `json:"transferID" firestore:"transferID"`
+	Denom       string `json:"denom" firestore:"denom"`
+	Window      int    `json:"window" firestore:"window"`
+	BlockHeight int    `json:"blockHeight" firestore:"blockHeight"`
+}
+
+// NewUIBCOutflowTransfer returns the record of the transfer added to the outflow window.
+func NewUIBCOutflowTransfer(transfer IBCTransfer, outflow UIBCOutflowWindow, txHash string, blockHeight int) UIBCOutflowTransfer {
+	return UIBCOutflowTransfer{
+		TxHash:      txHash,
+		TransferID:  IBCTransferDocID(transfer.Direction, transfer.SourcePort, transfer.SourceChannel, transfer.Sequence),
+		Denom:       outflow.Denom,
+		Window:      outflow.Window,
+		BlockHeight: blockHeight,
+	}
+}
+
+// UIBCOutflowTransferDocID returns the doc id of the transfer added to the outflow, by tx hash.
+func UIBCOutflowTransferDocID(transfer UIBCOutflowTransfer) string {
+	return fmt.Sprintf("%s-%s", transfer.TxHash, transfer.TransferID)
+}
+
+// NewUIBCQuotaSnapshot returns the snapshot of the quota state at the block height.
+func NewUIBCQuotaSnapshot(params uibc.Params, outflows []uibc.DecCoinSymbol, quotaExpires time.Time, blockHeight, blockTimeUnix int) UIBCQuotaSnapshot {
+	total := sdktypes.ZeroDec()
+	denomOutflows := make([]*UIBCDenomOutflow, len(outflows))
+	for i, outflow := range outflows {
+		total = total.Add(outflow.Amount)
+		denomOutflows[i] = &UIBCDenomOutflow{
+			Denom:     outflow.Denom,
+			Symbol:    outflow.Symbol,
+			AmountUsd: outflow.Amount.String(),
+		}
+	}
+
+	return UIBCQuotaSnapshot{
+		BlockHeight:      blockHeight,
+		BlockTimeUnix:    blockTimeUnix,
+		IbcStatus:        params.IbcStatus.String(),
+		TotalQuota:       params.TotalQuota.String(),
+		TokenQuota:       params.TokenQuota.String(),
+		QuotaExpiresUnix: int(quotaExpires.Unix()),
+		TotalOutflowUsd:  total.String(),
+		Outflows:         denomOutflows,
+	}
+}
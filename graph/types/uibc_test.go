@@ -0,0 +1,31 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestNewUIBCOutflowWindow(t *testing.T) {
+	quotaExpires := time.Unix(1_700_050_000, 0)
+	token := sdktypes.NewCoin("uumee", sdkmath.NewInt(100))
+
+	outflow := types.NewUIBCOutflowWindow(token, quotaExpires, 24*time.Hour)
+	require.Equal(t, types.UIBCOutflowWindow{
+		Denom:              "uumee",
+		Window:             1_700_050_000,
+		WindowFromTimeUnix: 1_700_050_000 - 24*60*60,
+		WindowToTimeUnix:   1_700_050_000,
+		Amount:             "100",
+		Transfers:          1,
+	}, outflow)
+
+	transfer := types.IBCTransfer{Direction: types.IBCDirectionOutgoing, SourcePort: "transfer", SourceChannel: "channel-0", Sequence: 3}
+	outflowTransfer := types.NewUIBCOutflowTransfer(transfer, outflow, "ABC", 10)
+	require.Equal(t, "ABC-outgoing-transfer-channel-0-3", types.UIBCOutflowTransferDocID(outflowTransfer))
+	require.Equal(t, 1_700_050_000, outflowTransfer.Window)
+}
@@ -0,0 +1,187 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	RecordKindTx                  = "tx"
+	RecordKindOracleVote          = "oracle-vote"
+	RecordKindBalanceChange       = "balance-change"
+	RecordKindUIBCEvent           = "uibc-event"
+	RecordKindTokenRegistryChange = "token-registry-change"
+
+	RecordDiffMissing   = "missing"
+	RecordDiffExtra     = "extra"
+	RecordDiffDuplicate = "duplicate"
+	RecordDiffMismatch  = "mismatch"
+)
+
+// BlockRecords are the records the handlers store by block, the ones merged across blocks (proposals,
+// transfers, outflows and performance) are not part of it.
+type BlockRecords struct {
+	Txs            []*IndexedTx
+	OracleVotes    []*OracleValidatorVote
+	BalanceChanges []*BalanceChange
+	UIBCEvents     []*UIBCEvent
+	// TokenRegistryChanges are only the ones made by txs, the ones of proposals depend on the proposal
+	// indexed before the block.
+	TokenRegistryChanges []*TokenRegistryChange
+}
+
+// RecordDiff is a difference between the records expected by running the handlers again and the
+// stored ones. Records are matched by the key of their kind, ex.: tx hash and msg name for txs.
+type RecordDiff struct {
+	Kind        string `json:"kind"`
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	BlockHeight int    `json:"blockHeight"`
+	// ProtoMsgName is the msg whose handler stores the record, empty if it is not known.
+	ProtoMsgName string `json:"protoMsgName"`
+	Expected     string `json:"expected,omitempty"`
+	Stored       string `json:"stored,omitempty"`
+}
+
+// blockRecord is a record of any kind encoded as JSON to be compared.
+type blockRecord struct {
+	kind, key, protoMsgName string
+	blockHeight             int
+	value                   string
+}
+
+// DiffBlockRecords returns the differences of the stored records to the expected ones ordered by
+// block height. The records with the same key are compared as a set: the stored that are not expected
+// are mismatches while there are expected left, then duplicates, and the expected left are missing.
+func DiffBlockRecords(expected, stored BlockRecords) ([]*RecordDiff, error) {
+	expRecords, err := expected.records()
+	if err != nil {
+		return nil, err
+	}
+	stoRecords, err := stored.records()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+	expByKey, stoByKey := make(map[string][]blockRecord), make(map[string][]blockRecord)
+	for _, r := range expRecords {
+		k := r.kind + "/" + r.key
+		if _, ok := expByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		expByKey[k] = append(expByKey[k], r)
+	}
+	for _, r := range stoRecords {
+		k := r.kind + "/" + r.key
+		if _, ok := expByKey[k]; !ok {
+			if _, ok := stoByKey[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		stoByKey[k] = append(stoByKey[k], r)
+	}
+
+	diffs := make([]*RecordDiff, 0)
+	for _, k := range keys {
+		exp, sto := recordsNotIn(expByKey[k], stoByKey[k]), recordsNotIn(stoByKey[k], expByKey[k])
+		for i, r := range sto {
+			diff := newRecordDiff(r, RecordDiffExtra)
+			switch {
+			case i < len(exp):
+				diff.Type, diff.Expected = RecordDiffMismatch, exp[i].value
+			case len(expByKey[k]) > 0:
+				diff.Type = RecordDiffDuplicate
+			}
+			diffs = append(diffs, diff)
+		}
+		for i := len(sto); i < len(exp); i++ {
+			diff := newRecordDiff(exp[i], RecordDiffMissing)
+			diff.Expected, diff.Stored = exp[i].value, ""
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].BlockHeight < diffs[j].BlockHeight
+	})
+	return diffs, nil
+}
+
+func newRecordDiff(r blockRecord, diffType string) *RecordDiff {
+	return &RecordDiff{
+		Kind:         r.kind,
+		Key:          r.key,
+		Type:         diffType,
+		BlockHeight:  r.blockHeight,
+		ProtoMsgName: r.protoMsgName,
+		Stored:       r.value,
+	}
+}
+
+// recordsNotIn returns the records that are not in the other ones, counting the repeated ones.
+func recordsNotIn(records, others []blockRecord) (left []blockRecord) {
+	count := make(map[string]int)
+	for _, r := range others {
+		count[r.value]++
+	}
+	for _, r := range records {
+		if count[r.value] > 0 {
+			count[r.value]--
+			continue
+		}
+		left = append(left, r)
+	}
+	return left
+}
+
+func (r BlockRecords) records() ([]blockRecord, error) {
+	records := make([]blockRecord, 0)
+	add := func(kind, key, protoMsgName string, blockHeight int, v any) error {
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		records = append(records, blockRecord{kind: kind, key: key, protoMsgName: protoMsgName, blockHeight: blockHeight, value: string(bz)})
+		return nil
+	}
+
+	for _, tx := range r.Txs {
+		if err := add(RecordKindTx, fmt.Sprintf("%s-%s", tx.TxHash, tx.ProtoMsgName), TxMsgName(*tx), tx.BlockHeight, tx); err != nil {
+			return nil, err
+		}
+	}
+	for _, vote := range r.OracleVotes {
+		key := fmt.Sprintf("%s-%d", vote.Validator, vote.BlockHeight)
+		if err := add(RecordKindOracleVote, key, MsgNameAggregateExchangeRateVote, vote.BlockHeight, vote); err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range r.BalanceChanges {
+		if err := add(RecordKindBalanceChange, BalanceChangeDocID(*change), MsgNameBalanceChanges, change.BlockHeight, change); err != nil {
+			return nil, err
+		}
+	}
+	for _, evt := range r.UIBCEvents {
+		// the bad reverts are emitted by acknowledgements and timeouts alike.
+		protoMsgName := ""
+		if evt.EventType == UIBCEventQuotaExceeded {
+			protoMsgName = MsgNameTransfer
+		}
+		key := fmt.Sprintf("%s-%s", evt.TxHash, evt.EventType)
+		if err := add(RecordKindUIBCEvent, key, protoMsgName, evt.BlockHeight, evt); err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range r.TokenRegistryChanges {
+		if change.TxHash == "" {
+			continue
+		}
+		key := TokenRegistryChangeDocID(*change)
+		if err := add(RecordKindTokenRegistryChange, key, change.ProtoMsgName, change.BlockHeight, change); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+func TestDiffBlockRecords(t *testing.T) {
+	send := func(txHash string, height int, amount string) *types.IndexedTx {
+		return &types.IndexedTx{
+			TxHash:       txHash,
+			ProtoMsgName: types.MsgNameSend,
+			BlockHeight:  height,
+			MsgSend:      &types.MsgSend{FromAddress: "umee1from", ToAddress: "umee1to", Amount: amount},
+		}
+	}
+	vote := func(valoper string, height int) *types.OracleValidatorVote {
+		return &types.OracleValidatorVote{Validator: valoper, Voted: true, BlockHeight: height}
+	}
+
+	expected := types.BlockRecords{
+		Txs:         []*types.IndexedTx{send("A", 10, "1uumee"), send("B", 11, "2uumee"), send("C", 12, "3uumee"), send("D", 13, "4uumee")},
+		OracleVotes: []*types.OracleValidatorVote{vote("valA", 10), vote("valB", 10)},
+	}
+	stored := types.BlockRecords{
+		// B is duplicated, C mismatches, D is missing and E is extra.
+		Txs:         []*types.IndexedTx{send("A", 10, "1uumee"), send("B", 11, "2uumee"), send("B", 11, "2uumee"), send("C", 12, "30uumee"), send("E", 14, "5uumee")},
+		OracleVotes: []*types.OracleValidatorVote{vote("valB", 10), vote("valA", 10)},
+	}
+
+	diffs, err := types.DiffBlockRecords(expected, stored)
+	require.NoError(t, err)
+	require.Len(t, diffs, 4)
+
+	require.Equal(t, types.RecordDiffDuplicate, diffs[0].Type)
+	require.Equal(t, "B-"+types.MsgNameSend, diffs[0].Key)
+	require.Empty(t, diffs[0].Expected)
+
+	require.Equal(t, types.RecordDiffMismatch, diffs[1].Type)
+	require.Equal(t, 12, diffs[1].BlockHeight)
+	require.Contains(t, diffs[1].Expected, `"amount":"3uumee"`)
+	require.Contains(t, diffs[1].Stored, `"amount":"30uumee"`)
+
+	require.Equal(t, types.RecordDiffMissing, diffs[2].Type)
+	require.Equal(t, 13, diffs[2].BlockHeight)
+	require.Equal(t, types.MsgNameSend, diffs[2].ProtoMsgName)
+	require.Empty(t, diffs[2].Stored)
+
+	require.Equal(t, types.RecordDiffExtra, diffs[3].Type)
+	require.Equal(t, types.RecordKindTx, diffs[3].Kind)
+	require.Equal(t, 14, diffs[3].BlockHeight)
+}
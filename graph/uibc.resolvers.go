@@ -0,0 +1,31 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// UibcGovTxs is the resolver for the uibcGovTxs field.
+func (r *queryResolver) UibcGovTxs(ctx context.Context, chainID *string) ([]*types.IndexedTx, error) {
+	return r.db.GetUIBCGovTxs(ctx, defaultChainID(chainID))
+}
+
+// UibcEvents is the resolver for the uibcEvents field.
+func (r *queryResolver) UibcEvents(ctx context.Context, chainID *string, eventType *string, fromTimeUnix *int, toTimeUnix *int) ([]*types.UIBCEvent, error) {
+	return r.db.GetUIBCEvents(ctx, defaultChainID(chainID), eventType, fromTimeUnix, toTimeUnix)
+}
+
+// UibcOutflows is the resolver for the uibcOutflows field.
+func (r *queryResolver) UibcOutflows(ctx context.Context, chainID *string, denom *string, fromTimeUnix *int, toTimeUnix *int) ([]*types.UIBCOutflowWindow, error) {
+	return r.db.GetUIBCOutflows(ctx, defaultChainID(chainID), denom, fromTimeUnix, toTimeUnix)
+}
+
+// UibcQuotaSnapshots is the resolver for the uibcQuotaSnapshots field.
+func (r *queryResolver) UibcQuotaSnapshots(ctx context.Context, chainID *string, fromTimeUnix *int, toTimeUnix *int) ([]*types.UIBCQuotaSnapshot, error) {
+	return r.db.GetUIBCQuotaSnapshots(ctx, defaultChainID(chainID), fromTimeUnix, toTimeUnix)
+}
@@ -0,0 +1,135 @@
+package idx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// BackfillProgress is reported by the backfill after each block height it is done with.
+type BackfillProgress struct {
+	// Height is the last block height the backfill is done with.
+	Height int
+	// Done is the amount of blocks handled or that failed to be fetched, of the Total to backfill.
+	Done, Total int
+	// Failed is the amount of blocks that failed to be fetched.
+	Failed  int
+	Elapsed time.Duration
+}
+
+// ETA estimates the time left to backfill the blocks by the average time of the blocks done.
+func (p BackfillProgress) ETA() time.Duration {
+	if p.Done == 0 {
+		return 0
+	}
+	return p.Elapsed / time.Duration(p.Done) * time.Duration(p.Total-p.Done)
+}
+
+// fetchedBlock is a block fetched by a worker of the backfill.
+type fetchedBlock struct {
+	height int
+	blk    *tmtypes.Block
+	err    error
+}
+
+// Backfill indexes the blocks between the heights (inclusive) that need to be indexed for the msgs,
+// all the msgs if none is informed. A pool of workers fetches the blocks, which are handled in height
+// order, and the progress is reported after each block. It returns once all the blocks were handled,
+// with an error if some of them could not be fetched.
+func (i *Indexer) Backfill(ctx context.Context, fromHeight, toHeight, workers int, msgNames []string, progress func(BackfillProgress)) error {
+	if fromHeight < 1 || toHeight < fromHeight {
+		return fmt.Errorf("invalid block heights to backfill: %d ~ %d", fromHeight, toHeight)
+	}
+	workers = max(workers, 1)
+
+	i.onlyMsgs = msgNames
+	defer func() { i.onlyMsgs = nil }()
+
+	cosmosMsgs, _ := i.chainInfo.Copy()
+	heights := make([]int, 0)
+	for height := fromHeight; height <= toHeight; height++ {
+		if i.needsToIndex(cosmosMsgs, height) {
+			heights = append(heights, height)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the fetches are queued in height order, the queue and the workers bound the blocks kept in memory.
+	queue := make(chan chan fetchedBlock, workers*2)
+	sem := make(chan struct{}, workers)
+	go func() {
+		defer close(queue)
+		for _, height := range heights {
+			fetched := make(chan fetchedBlock, 1)
+			select {
+			case queue <- fetched:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(height int) {
+				defer func() { <-sem }()
+				blk, _, err := i.b.Block(ctx, int64(height))
+				if err == nil && blk == nil {
+					err = fmt.Errorf("block %d not available on node", height)
+				}
+				fetched <- fetchedBlock{height: height, blk: blk, err: err}
+			}(height)
+		}
+	}()
+
+	p := BackfillProgress{Total: len(heights)}
+	start := time.Now()
+	for fetched := range queue {
+		var f fetchedBlock
+		select {
+		case f = <-fetched:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// the block fetched is handled entirely even if the context is done meanwhile.
+		if f.err != nil {
+			i.logger.Err(f.err).Int("blockHeight", f.height).Msg("error getting block to backfill")
+			p.Failed++
+		} else if err := i.HandleBlock(context.WithoutCancel(ctx), f.blk); err != nil {
+			i.logger.Err(err).Int("blockHeight", f.height).Msg("error handling block to backfill")
+		}
+
+		p.Height, p.Elapsed = f.height, time.Since(start)
+		p.Done++
+		progress(p)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if p.Failed > 0 {
+		return fmt.Errorf("%d blocks failed to be fetched", p.Failed)
+	}
+	return nil
+}
+
+// needsToIndex checks if any msg needs to be indexed at the block height, only the msgs of the backfill
+// are checked if it was informed.
+func (i *Indexer) needsToIndex(cosmosMsgs []*types.CosmosMsgIndexed, blkHeight int) bool {
+	if len(i.onlyMsgs) == 0 {
+		return types.NeedsToIndex(cosmosMsgs, blkHeight)
+	}
+	for _, msgName := range i.onlyMsgs {
+		if types.NeedsToIndexForMsg(msgName, cosmosMsgs, blkHeight) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleBankMsg stores the bank send msgs.
+func (i *Indexer) HandleBankMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing bank msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		tx := types.IndexedTx{
+			TxHash:        hex.EncodeToString(tmTx.Hash()),
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *banktypes.MsgSend:
+			parsed := types.ParseTxSend(m)
+			tx.MsgSend = &parsed
+		case *banktypes.MsgMultiSend:
+			parsed := types.ParseTxMultiSend(m)
+			tx.MsgMultiSend = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into bank msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
+
+// HandleBalanceChanges stores the balance changes of every address in the block, from the coin_spent and
+// coin_received events of the begin block, of each tx (including the fees of failed txs) and of the end block.
+func (i *Indexer) HandleBalanceChanges(ctx context.Context, blk *tmtypes.Block) error {
+	blkHeight, blockTimeUnix := int(blk.Height), int(blk.Time.Unix())
+	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
+		if !i.needsToIndexForMsg(types.MsgNameBalanceChanges, info.CosmosMsgs, blkHeight) {
+			return nil
+		}
+
+		blkResults, err := i.b.BlockResults(ctx, blk.Height)
+		if err != nil {
+			return err
+		}
+
+		changes := types.ParseBalanceChanges(blkResults.BeginBlockEvents, types.BalanceChangeSourceBeginBlock, "", blkHeight, blockTimeUnix)
+		for idx, result := range blkResults.TxsResults {
+			if idx >= len(blk.Data.Txs) {
+				break
+			}
+			txHash := hex.EncodeToString(blk.Data.Txs[idx].Hash())
+			changes = append(changes, types.ParseBalanceChanges(result.Events, types.BalanceChangeSourceTx, txHash, blkHeight, blockTimeUnix)...)
+		}
+		changes = append(changes, types.ParseBalanceChanges(blkResults.EndBlockEvents, types.BalanceChangeSourceEndBlock, "", blkHeight, blockTimeUnix)...)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		i.logger.Debug().Int("height", blkHeight).Int("changes", len(changes)).Msg("storing balance changes")
+		return i.db.StoreBalanceChanges(ctx, *info, changes)
+	})
+}
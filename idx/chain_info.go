@@ -48,6 +48,19 @@ func (s *SafeChainInfo) Copy() (cosmosMsgs []*types.CosmosMsgIndexed, lastBlockH
 	return cosmosMsgs, lastBlockHeightReceived
 }
 
+// Head returns the chain id and the last block received, without the intervals indexed.
+func (s *SafeChainInfo) Head() (head types.ChainInfo) {
+	_ = s.Execute(func(info *types.ChainInfo) error {
+		head = types.ChainInfo{
+			ChainID:                   info.ChainID,
+			LastBlockHeightReceived:   info.LastBlockHeightReceived,
+			LastBlockTimeUnixReceived: info.LastBlockTimeUnixReceived,
+		}
+		return nil
+	})
+	return head
+}
+
 // UpdateFromBlock updates the general block info in the chain info.
 func (s *SafeChainInfo) UpdateFromBlock(blk *tmtypes.Block) {
 	_ = s.Execute(func(info *types.ChainInfo) error {
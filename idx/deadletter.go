@@ -0,0 +1,166 @@
+package idx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// blockHandler is a handler of the whole block, run after its txs.
+type blockHandler struct {
+	name   string
+	handle func(ctx context.Context, blk *tmtypes.Block) error
+}
+
+// blockHandlers returns the block handlers in the order they run, the name is the one of their dead letters.
+func (i *Indexer) blockHandlers() []blockHandler {
+	return []blockHandler{
+		{name: types.DeadLetterHandlerOracleMisses, handle: i.HandleOracleMisses},
+		{name: types.DeadLetterHandlerGovEndBlock, handle: i.HandleGovEndBlock},
+		{name: types.DeadLetterHandlerBalances, handle: i.HandleBalanceChanges},
+	}
+}
+
+// storeDeadLetter keeps the failure to be reprocessed later.
+func (i *Indexer) storeDeadLetter(ctx context.Context, letter types.DeadLetter) {
+	if err := i.db.StoreDeadLetter(ctx, i.chainID(), letter); err != nil {
+		i.logger.Err(err).Str("id", letter.ID).Msg("error storing dead letter")
+	}
+}
+
+// chainID returns the chain id of the node the indexer is connected to.
+func (i *Indexer) chainID() (chainID string) {
+	_ = i.chainInfo.Execute(func(info *types.ChainInfo) error {
+		chainID = info.ChainID
+		return nil
+	})
+	return chainID
+}
+
+// needsToIndexForMsg checks if the msg needs to be indexed at the block height, every msg is indexed
+// while reprocessing the dead letters, as their blocks were already marked as indexed. The backfill
+// of some msgs only indexes them.
+func (i *Indexer) needsToIndexForMsg(msgName string, cosmosMsgs []*types.CosmosMsgIndexed, blkHeight int) bool {
+	if i.reprocessing {
+		return true
+	}
+	if len(i.onlyMsgs) > 0 && !slices.Contains(i.onlyMsgs, msgName) {
+		return false
+	}
+	return types.NeedsToIndexForMsg(msgName, cosmosMsgs, blkHeight)
+}
+
+// ReprocessDeadLetters retries the dead letters of the chain, filtered by the handler if informed. The
+// dead letters reprocessed successfully are deleted and the ones that fail again have their attempts
+// increased. The chain must be the one of the node, and it must not run while the indexer is indexing blocks.
+func (i *Indexer) ReprocessDeadLetters(ctx context.Context, chainID string, handler *string) (reprocessed, failed int, err error) {
+	if nodeChainID := i.chainID(); nodeChainID != chainID {
+		return 0, 0, fmt.Errorf("the node is of the chain %s, not %s", nodeChainID, chainID)
+	}
+
+	letters, err := i.db.GetDeadLetters(ctx, chainID, handler)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	i.reprocessing = true
+	defer func() { i.reprocessing = false }()
+
+	for _, letter := range letters {
+		if err := i.reprocessDeadLetter(ctx, *letter); err != nil {
+			i.logger.Err(err).Str("id", letter.ID).Msg("error reprocessing dead letter")
+			failed++
+			retry := *letter
+			retry.Error, retry.Attempts, retry.FailedAtUnix = err.Error(), 1, int(time.Now().Unix())
+			if err := i.db.StoreDeadLetter(ctx, chainID, retry); err != nil {
+				return reprocessed, failed, err
+			}
+			continue
+		}
+
+		reprocessed++
+		if err := i.db.DeleteDeadLetter(ctx, chainID, letter.ID); err != nil {
+			return reprocessed, failed, err
+		}
+	}
+	return reprocessed, failed, nil
+}
+
+// reprocessDeadLetter runs again only the handler that failed. Its failure is returned and not stored, the
+// attempts of the dead letter are only increased by the caller.
+func (i *Indexer) reprocessDeadLetter(ctx context.Context, letter types.DeadLetter) error {
+	switch letter.Handler {
+	case types.DeadLetterHandlerDecodeTx, types.DeadLetterHandlerMsg:
+		tmTx, err := types.DeadLetterTx(letter)
+		if err != nil {
+			return err
+		}
+		tx, err := i.b.DecodeTx(int64(letter.BlockHeight), tmTx)
+		if err != nil {
+			return err
+		}
+		if letter.Handler == types.DeadLetterHandlerDecodeTx {
+			// the tx decodes now, its msgs failures are stored as dead letters of their own.
+			i.handleTxMsgs(ctx, letter.BlockHeight, letter.BlockTimeUnix, tmTx, tx)
+			return nil
+		}
+
+		msg, exec, err := i.msgAtPath(ctx, tmTx, tx.GetMsgs(), letter.MsgPath)
+		if err != nil {
+			return err
+		}
+		return i.HandleMsg(ctx, letter.BlockHeight, letter.BlockTimeUnix, tmTx, tmTx.Hash(), letter.MsgPath, exec, msg)
+	}
+
+	for _, h := range i.blockHandlers() {
+		if h.name != letter.Handler {
+			continue
+		}
+		blk, _, err := i.b.Block(ctx, int64(letter.BlockHeight))
+		if err != nil {
+			return err
+		}
+		if blk == nil {
+			return fmt.Errorf("block %d is not available on the node", letter.BlockHeight)
+		}
+		return h.handle(ctx, blk)
+	}
+	return fmt.Errorf("unknown dead letter handler: %s", letter.Handler)
+}
+
+// msgAtPath walks the msg tree of the tx to the msg at the path, returning it with its execution context.
+func (i *Indexer) msgAtPath(ctx context.Context, tmTx tmtypes.Tx, msgs []sdktypes.Msg, path string) (proto.Message, *types.MsgExecution, error) {
+	var (
+		msg  sdktypes.Msg
+		exec *types.MsgExecution
+	)
+	for depth, idx := range strings.Split(path, ".") {
+		n, err := strconv.Atoi(idx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid msg path %s: %w", path, err)
+		}
+
+		grantee := ""
+		if depth > 0 {
+			msgs, grantee = i.innerMsgs(ctx, tmTx, msg)
+		}
+		if n < 0 || n >= len(msgs) {
+			return nil, nil, fmt.Errorf("msg path %s not found in the tx", path)
+		}
+
+		if depth > 0 {
+			innerPath := strings.Join(strings.Split(path, ".")[:depth+1], ".")
+			exec = types.NewMsgExecution(innerPath, proto.MessageName(msg), grantee, msgSigner(msgs[n]))
+		}
+		msg = msgs[n]
+	}
+	return msg, exec, nil
+}
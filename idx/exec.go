@@ -0,0 +1,82 @@
+package idx
+
+import (
+	"context"
+	"errors"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/gogoproto/proto"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleMsgTree handles the msg and recursively the msgs executed inside of it, like the authz MsgExec
+// and the interchain accounts txs received by umee as host. Each inner msg is handled with its execution
+// context (nesting path, grantee and granter).
+func (i *Indexer) HandleMsgTree(ctx context.Context, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, txHash []byte, path string, exec *types.MsgExecution, msg proto.Message) {
+	err := i.HandleMsg(ctx, blkHeight, blockTimeUnix, tmTx, txHash, path, exec, msg)
+	observeHandled(types.DeadLetterHandlerMsg, proto.MessageName(msg), err)
+	if err != nil {
+		i.logger.Err(err).Str("path", path).Msg("error handling msg")
+		// the txs that failed on chain are not indexing failures.
+		if !errors.Is(err, types.ErrTxFailed) {
+			i.storeDeadLetter(ctx, types.NewDeadLetter(blkHeight, blockTimeUnix, types.DeadLetterHandlerMsg, tmTx, path, proto.MessageName(msg), err))
+		}
+	}
+
+	wrapper := proto.MessageName(msg)
+	innerMsgs, grantee := i.innerMsgs(ctx, tmTx, msg)
+	for idx, innerMsg := range innerMsgs {
+		innerPath := types.InnerMsgPath(path, idx)
+		innerExec := types.NewMsgExecution(innerPath, wrapper, grantee, msgSigner(innerMsg))
+		i.HandleMsgTree(ctx, blkHeight, blockTimeUnix, tmTx, txHash, innerPath, innerExec, innerMsg)
+	}
+}
+
+// innerMsgs returns the msgs executed inside of the msg and who executed them on behalf of the signers,
+// nothing is returned for msgs that do not wrap others, if the inner msgs could not be decoded or if the
+// interchain account host failed to execute them.
+func (i *Indexer) innerMsgs(ctx context.Context, tmTx tmtypes.Tx, msg proto.Message) (innerMsgs []sdktypes.Msg, grantee string) {
+	switch m := msg.(type) {
+	case *authz.MsgExec:
+		innerMsgs, err := m.GetMessages()
+		if err != nil {
+			i.logger.Err(err).Str("grantee", m.Grantee).Msg("error unpacking authz exec msgs")
+			return nil, ""
+		}
+		return innerMsgs, m.Grantee
+	case *channeltypes.MsgRecvPacket:
+		if m.Packet.DestinationPort != icatypes.HostPortID {
+			return nil, ""
+		}
+		innerMsgs, err := i.b.DecodeICAPacketMsgs(m.Packet.Data)
+		if err != nil {
+			i.logger.Err(err).Str("port", m.Packet.SourcePort).Msg("error decoding interchain account msgs")
+			return nil, ""
+		}
+		// the relayer tx succeeds even when the host execution fails, it is returned in the acknowledgement.
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			i.logger.Err(err).Str("port", m.Packet.SourcePort).Msg("error getting the interchain account tx result")
+			return nil, ""
+		}
+		if result.IsErr() || !types.PacketExecuted(result.Events, m.Packet) {
+			i.logger.Debug().Str("port", m.Packet.SourcePort).Msg("interchain account msgs not executed by the host")
+			return nil, ""
+		}
+		return innerMsgs, types.ICAControllerOwner(m.Packet.SourcePort)
+	}
+	return nil, ""
+}
+
+// msgSigner returns the first signer of the msg, for inner msgs it is the account that granted the execution.
+func msgSigner(msg sdktypes.Msg) string {
+	signers := msg.GetSigners()
+	if len(signers) == 0 {
+		return ""
+	}
+	return signers[0].String()
+}
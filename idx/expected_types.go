@@ -2,9 +2,19 @@ package idx
 
 import (
 	"context"
+	"time"
 
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umee/v6/x/metoken"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umee/v6/x/uibc"
 )
 
 // Blockchain is the expected blockchain interface the indexer needs to store data in the database.
@@ -13,8 +23,22 @@ type Blockchain interface {
 	ChainID() string
 	ChainHeader() (chainID string, height uint64, err error)
 	SetChainHeader(blk *tmtypes.Block)
-	DecodeTx(tx tmtypes.Tx) (sdktypes.Tx, error)
+	DecodeTx(height int64, tx tmtypes.Tx) (sdktypes.Tx, error)
+	DecodeICAPacketMsgs(data []byte) ([]sdktypes.Msg, error)
 	SubscribeNewBlock(ctx context.Context) (cNewBlock <-chan *tmtypes.Block, err error)
 	Block(ctx context.Context, height int64) (blk *tmtypes.Block, minimumBlkHeight int, err error)
 	CheckTx(ctx context.Context, tx tmtypes.Tx) (err error)
+	TxResult(ctx context.Context, tx tmtypes.Tx) (result *abcitypes.ResponseDeliverTx, err error)
+	BlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error)
+	MsgJSON(msg proto.Message) (string, error)
+	OracleParams(ctx context.Context, height int64) (oracletypes.Params, error)
+	OracleAggregateVotes(ctx context.Context, height int64) (voters []string, err error)
+	BondedValidators(ctx context.Context, height int64) (valopers []string, err error)
+	GovProposal(ctx context.Context, proposalID uint64, height int64) (*govv1.Proposal, error)
+	OngoingIncentivePrograms(ctx context.Context) (programs []incentive.IncentiveProgram, err error)
+	MetokenIndexBalances(ctx context.Context) (balances []metoken.IndexBalances, err error)
+	UIBCParams(ctx context.Context, height int64) (uibc.Params, error)
+	UIBCOutflows(ctx context.Context) (outflows []uibc.DecCoinSymbol, err error)
+	UIBCQuotaExpires(ctx context.Context, height int64) (time.Time, error)
+	DenomTrace(ctx context.Context, denom string) (transfertypes.DenomTrace, error)
 }
@@ -0,0 +1,143 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleGovMsg stores the gov msgs, the submitted proposals and deposits also update the proposal record.
+func (i *Indexer) HandleGovMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing gov msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			return err
+		}
+		events := result.Events
+
+		txHash := hex.EncodeToString(tmTx.Hash())
+		tx := types.IndexedTx{
+			TxHash:        txHash,
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *govv1.MsgSubmitProposal:
+			innerMsgs, err := m.GetMsgs()
+			if err != nil {
+				return err
+			}
+			proposalID, err := types.SubmittedProposalID(events, innerMsgs)
+			if err != nil {
+				return err
+			}
+
+			msgs := i.parseGovProposalMsgs(innerMsgs)
+			votingStarted := types.GovVotingStarted(events, proposalID)
+			parsed := types.ParseTxSubmitProposal(m, proposalID, msgs)
+			tx.MsgSubmitProposal = &parsed
+			proposal := types.NewGovProposal(m, proposalID, msgs, votingStarted, blkHeight, blockTimeUnix, txHash)
+			return i.db.StoreGovProposal(ctx, *info, &tx, proposal)
+		case *govv1.MsgDeposit:
+			votingStarted := types.GovVotingStarted(events, int(m.ProposalId))
+			parsed := types.ParseTxDeposit(m, votingStarted)
+			tx.MsgDeposit = &parsed
+			return i.db.StoreGovProposal(ctx, *info, &tx, types.NewGovProposalDeposit(m, votingStarted, blkHeight, blockTimeUnix))
+		case *govv1.MsgVote:
+			parsed := types.ParseTxVote(m)
+			tx.MsgVote = &parsed
+		case *govv1.MsgVoteWeighted:
+			parsed := types.ParseTxVoteWeighted(m)
+			tx.MsgVoteWeighted = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into gov msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
+
+// HandleGovEndBlock stores the proposal status transitions emitted at the end block, when the deposit
+// or the voting period of the proposals ends. The final tally is queried from the chain at that height and
+// the token registry changes of the passed proposals are stored.
+func (i *Indexer) HandleGovEndBlock(ctx context.Context, blk *tmtypes.Block) error {
+	blkHeight := int(blk.Height)
+	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
+		if !i.needsToIndexForMsg(types.MsgNameSubmitProposal, info.CosmosMsgs, blkHeight) {
+			return nil
+		}
+
+		blkResults, err := i.b.BlockResults(ctx, blk.Height)
+		if err != nil {
+			return err
+		}
+
+		blockTimeUnix := int(blk.Time.Unix())
+		for _, result := range types.GovProposalResults(blkResults.EndBlockEvents) {
+			var (
+				finalTally *types.GovTally
+				msgs       []*types.GovProposalMsg
+			)
+			if result.VotingEnded {
+				proposal, err := i.b.GovProposal(ctx, uint64(result.ProposalID), blk.Height)
+				if err != nil {
+					// the node might not have the state of that height anymore.
+					i.logger.Err(err).Int("proposalID", result.ProposalID).Int("height", blkHeight).Msg("error querying proposal final tally")
+				} else {
+					finalTally = types.ParseGovTally(proposal.FinalTallyResult)
+					msgs, err = i.parseProposalMsgs(proposal)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			i.logger.Debug().Int("proposalID", result.ProposalID).Str("status", result.Status).Msg("storing proposal status")
+			update := types.NewGovProposalResult(result, finalTally, blkHeight, blockTimeUnix)
+			if err := i.db.StoreGovProposal(ctx, *info, nil, update); err != nil {
+				return err
+			}
+
+			if result.Status != govv1.StatusPassed.String() {
+				continue
+			}
+			if err := i.storeProposalRegistryChanges(ctx, *info, result.ProposalID, msgs, blkHeight, blockTimeUnix); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parseProposalMsgs parses the msgs of the proposal queried from the chain.
+func (i *Indexer) parseProposalMsgs(proposal *govv1.Proposal) ([]*types.GovProposalMsg, error) {
+	innerMsgs, err := proposal.GetMsgs()
+	if err != nil {
+		return nil, err
+	}
+	return i.parseGovProposalMsgs(innerMsgs), nil
+}
+
+// parseGovProposalMsgs parses the proposal msgs with their JSON content, a msg that fails to be encoded
+// is still parsed without content.
+func (i *Indexer) parseGovProposalMsgs(innerMsgs []sdktypes.Msg) []*types.GovProposalMsg {
+	msgs := make([]*types.GovProposalMsg, len(innerMsgs))
+	for idx, innerMsg := range innerMsgs {
+		content, err := i.b.MsgJSON(innerMsg)
+		if err != nil {
+			i.logger.Err(err).Str("messageName", proto.MessageName(innerMsg)).Msg("error encoding proposal msg")
+		}
+		msgs[idx] = types.ParseGovProposalMsg(innerMsg, content)
+	}
+	return msgs
+}
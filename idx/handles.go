@@ -3,10 +3,14 @@ package idx
 import (
 	"context"
 	"encoding/hex"
+	"strconv"
 
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/gogoproto/proto"
 	lvgtypes "github.com/umee-network/umee/v6/x/leverage/types"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
 	"github.com/umee-network/umeed-indexer/graph/types"
 )
 
@@ -17,9 +21,14 @@ func (i *Indexer) HandleNewBlock(ctx context.Context, blk *tmtypes.Block) error
 
 	// since it is a new block, updates the chain info base information
 	i.chainInfo.UpdateFromBlock(blk)
+	i.HandleSnapshots(ctx, blk)
 
 	// and continues to handle a block normally.
-	return i.HandleBlock(ctx, blk)
+	if err := i.HandleBlock(ctx, blk); err != nil {
+		return err
+	}
+	tipHeight.Set(float64(blk.Height))
+	return nil
 }
 
 // HandleBlock handles the receive of an block from the chain.
@@ -31,34 +40,58 @@ func (i *Indexer) HandleBlock(ctx context.Context, blk *tmtypes.Block) error {
 		}
 	}
 
+	for _, h := range i.blockHandlers() {
+		err := h.handle(ctx, blk)
+		observeHandled(h.name, "", err)
+		if err != nil {
+			i.logger.Err(err).Int64("height", blk.Height).Str("handler", h.name).Msg("error handling block")
+			i.storeDeadLetter(ctx, types.NewDeadLetter(int(blk.Height), int(blk.Time.Unix()), h.name, nil, "", "", err))
+		}
+	}
+
+	head := i.chainInfo.Head()
+	// only the block height is added to the intervals stored, the other indexers of the chain add theirs.
+	if err := i.db.IndexBlockRange(ctx, head, i.onlyMsgs, int(blk.Height), int(blk.Height)); err != nil {
+		return err
+	}
 	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
-		info.IndexBlockHeight(int(blk.Height))
-		return i.db.UpsertChainInfo(ctx, *info)
+		if len(i.onlyMsgs) == 0 {
+			info.IndexBlockHeight(int(blk.Height))
+		}
+		for _, msgName := range i.onlyMsgs {
+			info.IndexBlockHeightForMsg(msgName, int(blk.Height))
+		}
+		blocksIndexed.Inc()
+		i.observeChainInfo(info)
+		return nil
 	})
 }
 
 // HandleTx handles the receive of new Tx from the chain.
 func (i *Indexer) HandleTx(ctx context.Context, blockHeight, blockTimeUnix int, tmTx tmtypes.Tx) error {
-	tx, err := i.b.DecodeTx(tmTx)
+	tx, err := i.b.DecodeTx(int64(blockHeight), tmTx)
 	if err != nil {
+		observeHandled(types.DeadLetterHandlerDecodeTx, "", err)
 		i.logger.Err(err).Msg("error decoding Tx")
+		i.storeDeadLetter(ctx, types.NewDeadLetter(blockHeight, blockTimeUnix, types.DeadLetterHandlerDecodeTx, tmTx, "", "", err))
 		return err
 	}
 
-	txHash := tmTx.Hash()
-	txMsgs := tx.GetMsgs()
+	i.handleTxMsgs(ctx, blockHeight, blockTimeUnix, tmTx, tx)
+	return nil
+}
 
-	for _, msg := range txMsgs {
-		if err := i.HandleMsg(ctx, blockHeight, blockTimeUnix, tmTx, txHash, msg); err != nil {
-			i.logger.Err(err).Msg("error handling msg")
-			continue
-		}
+// handleTxMsgs handles the msgs of the decoded tx, their failures are stored as dead letters.
+func (i *Indexer) handleTxMsgs(ctx context.Context, blockHeight, blockTimeUnix int, tmTx tmtypes.Tx, tx sdktypes.Tx) {
+	txHash := tmTx.Hash()
+	for idx, msg := range tx.GetMsgs() {
+		i.HandleMsgTree(ctx, blockHeight, blockTimeUnix, tmTx, txHash, strconv.Itoa(idx), nil, msg)
 	}
-	return nil
 }
 
-// HandleMsg handles the receive of new msg from the chain Tx.
-func (i *Indexer) HandleMsg(ctx context.Context, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, txHash []byte, msg proto.Message) error {
+// HandleMsg handles the receive of new msg from the chain Tx, path is the nesting path of the msg in the tx and
+// exec is nil for msgs that are not executed inside of another msg.
+func (i *Indexer) HandleMsg(ctx context.Context, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, txHash []byte, path string, exec *types.MsgExecution, msg proto.Message) error {
 	msgName := proto.MessageName(msg)
 
 	switch msgName {
@@ -71,7 +104,7 @@ func (i *Indexer) HandleMsg(ctx context.Context, blkHeight, blockTimeUnix int, t
 
 		i.logger.Debug().Msg("storing msg liquidate")
 		return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
-			return i.db.StoreMsgLiquidate(ctx, *info, blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()), types.ParseTxLiquidate(msgLiq))
+			return i.db.StoreMsgLiquidate(ctx, *info, blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()), exec, types.ParseTxLiquidate(msgLiq))
 		})
 	case types.MsgNameLeveragedLiquidate:
 		msgLevLiq, ok := msg.(*lvgtypes.MsgLeveragedLiquidate)
@@ -82,8 +115,76 @@ func (i *Indexer) HandleMsg(ctx context.Context, blkHeight, blockTimeUnix int, t
 
 		i.logger.Debug().Msg("storing msg leverage liquidate")
 		return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
-			return i.db.StoreMsgLeverageLiquidate(ctx, *info, blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()), types.ParseTxLeverageLiquidate(msgLevLiq))
+			return i.db.StoreMsgLeverageLiquidate(ctx, *info, blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()), exec, types.ParseTxLeverageLiquidate(msgLevLiq))
+		})
+	case types.MsgNameGovUpdateRegistry, types.MsgNameGovUpdateSpecialAssets:
+		return i.HandleLeverageGovMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameAggregateExchangeRateVote:
+		msgVote, ok := msg.(*oracletypes.MsgAggregateExchangeRateVote)
+		if !ok {
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into *oracletypes.MsgAggregateExchangeRateVote")
+			return nil
+		}
+
+		i.logger.Debug().Msg("storing msg oracle vote")
+		return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+			params, err := i.b.OracleParams(ctx, int64(blkHeight))
+			if err != nil {
+				return err
+			}
+			vote := types.ParseOracleVote(msgVote, int(params.VotePeriod), int(params.SlashWindow), blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()))
+			return i.db.StoreOracleVotes(ctx, *info, []types.OracleValidatorVote{vote})
 		})
+	case types.MsgNameDelegateFeedConsent:
+		msgFeed, ok := msg.(*oracletypes.MsgDelegateFeedConsent)
+		if !ok {
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into *oracletypes.MsgDelegateFeedConsent")
+			return nil
+		}
+
+		i.logger.Debug().Msg("storing msg delegate feed consent")
+		return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+			return i.db.StoreMsgDelegateFeedConsent(ctx, *info, blkHeight, blockTimeUnix, hex.EncodeToString(tmTx.Hash()), exec, types.ParseTxDelegateFeedConsent(msgFeed))
+		})
+	case types.MsgNameBond, types.MsgNameBeginUnbonding, types.MsgNameEmergencyUnbond,
+		types.MsgNameClaim, types.MsgNameSponsor, types.MsgNameGovCreatePrograms:
+		return i.HandleIncentiveMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, path, exec, msg)
+	case types.MsgNameSwap, types.MsgNameRedeem:
+		return i.HandleMetokenMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameGovUpdateQuota, types.MsgNameGovSetIBCStatus:
+		return i.HandleUIBCGovMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameTransfer:
+		return i.HandleIBCTransfer(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameDelegate, types.MsgNameUndelegate, types.MsgNameBeginRedelegate, types.MsgNameCancelUnbondingDelegation,
+		types.MsgNameWithdrawDelegatorReward, types.MsgNameWithdrawValidatorCommission:
+		return i.HandleStakingMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameSubmitProposal, types.MsgNameDeposit, types.MsgNameVote, types.MsgNameVoteWeighted:
+		return i.HandleGovMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameSend, types.MsgNameMultiSend:
+		return i.HandleBankMsg(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameRawAny:
+		msgRaw, ok := msg.(*types.RawAnyMsg)
+		if !ok {
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into *types.RawAnyMsg")
+			return nil
+		}
+
+		i.logger.Debug().Str("typeURL", msgRaw.TypeUrl).Msg("storing raw msg of unknown type")
+		return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+			parsed := types.ParseTxRawAny(msgRaw)
+			return i.db.StoreTx(ctx, *info, types.IndexedTx{
+				TxHash:        hex.EncodeToString(tmTx.Hash()),
+				ProtoMsgName:  types.RawAnyProtoMsgName(msgRaw),
+				BlockHeight:   blkHeight,
+				BlockTimeUnix: blockTimeUnix,
+				Execution:     exec,
+				MsgRawAny:     &parsed,
+			})
+		})
+	case types.MsgNameRecvPacket:
+		return i.HandleIBCRecvPacket(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
+	case types.MsgNameAcknowledgement, types.MsgNameTimeout:
+		return i.HandleIBCPacketResult(ctx, msgName, blkHeight, blockTimeUnix, tmTx, exec, msg)
 	default:
 		// i.logger.Debug().Str("messageName", msgName).Msg("no handle for msg")
 	}
@@ -93,7 +194,7 @@ func (i *Indexer) HandleMsg(ctx context.Context, blkHeight, blockTimeUnix int, t
 // indexMsg verifies if there is a need to stores that msg in the tx and if there is, verify if the tx was already processed.
 func (i *Indexer) indexMsg(ctx context.Context, msgName string, blkHeight int, tmTx tmtypes.Tx, store func(info *types.ChainInfo) error) error {
 	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
-		if !types.NeedsToIndexForMsg(msgName, info.CosmosMsgs, blkHeight) {
+		if !i.needsToIndexForMsg(msgName, info.CosmosMsgs, blkHeight) {
 			i.logger.Debug().Str("messageName", msgName).Int("height", blkHeight).Msg("no need to store msg for this block height")
 			return nil
 		}
@@ -107,3 +208,22 @@ func (i *Indexer) indexMsg(ctx context.Context, msgName string, blkHeight int, t
 		return store(info)
 	})
 }
+
+// indexMsgResult verifies if there is a need to stores that msg in the tx and if there is, gives the tx result
+// to the store function, including failed txs for the msgs that also index failures.
+func (i *Indexer) indexMsgResult(ctx context.Context, msgName string, blkHeight int, tmTx tmtypes.Tx, store func(info *types.ChainInfo, result *abcitypes.ResponseDeliverTx) error) error {
+	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
+		if !i.needsToIndexForMsg(msgName, info.CosmosMsgs, blkHeight) {
+			i.logger.Debug().Str("messageName", msgName).Int("height", blkHeight).Msg("no need to store msg for this block height")
+			return nil
+		}
+
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			return err
+		}
+
+		i.logger.Debug().Str("messageName", msgName).Str("txHash", hex.EncodeToString(tmTx.Hash())).Int("height", blkHeight).Uint32("code", result.Code).Msg("storing msg result into db")
+		return store(info, result)
+	})
+}
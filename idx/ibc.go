@@ -0,0 +1,127 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleIBCTransfer stores the outgoing transfer as pending and adds it to the outflow of the uibc quota window.
+// Transfers rejected by the uibc quota are stored as uibc events.
+func (i *Indexer) HandleIBCTransfer(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	msgTransfer, ok := msg.(*transfertypes.MsgTransfer)
+	if !ok {
+		i.logger.Error().Str("messageName", msgName).Msg("not able to parse into *transfertypes.MsgTransfer")
+		return nil
+	}
+
+	i.logger.Debug().Msg("storing msg ibc transfer")
+	return i.indexMsgResult(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo, result *abcitypes.ResponseDeliverTx) error {
+		txHash := hex.EncodeToString(tmTx.Hash())
+		if result.IsErr() {
+			if !types.IsUIBCQuotaExceeded(result.Codespace, result.Code) {
+				return nil
+			}
+			evt := types.NewUIBCQuotaExceeded(msgTransfer.Sender, msgTransfer.Token, result.Log, blkHeight, blockTimeUnix, txHash)
+			return i.db.StoreUIBCEvents(ctx, *info, []types.UIBCEvent{evt})
+		}
+
+		trace, err := i.b.DenomTrace(ctx, msgTransfer.Token.Denom)
+		if err != nil {
+			return err
+		}
+		step := types.IBCTransferStep{TxHash: txHash, BlockHeight: blkHeight, BlockTimeUnix: blockTimeUnix, Execution: exec}
+		transfer, err := types.ParseTxTransfer(msgTransfer, result.Events, trace, step)
+		if err != nil {
+			return err
+		}
+		if err := i.db.StoreIBCTransfer(ctx, *info, transfer); err != nil {
+			return err
+		}
+
+		params, err := i.b.UIBCParams(ctx, int64(blkHeight))
+		if err != nil {
+			return err
+		}
+		quotaExpires, err := i.b.UIBCQuotaExpires(ctx, int64(blkHeight))
+		if err != nil {
+			return err
+		}
+		outflow := types.NewUIBCOutflowWindow(msgTransfer.Token, quotaExpires, params.QuotaDuration)
+		return i.db.StoreUIBCOutflow(ctx, *info, outflow, types.NewUIBCOutflowTransfer(transfer, outflow, txHash, blkHeight))
+	})
+}
+
+// HandleIBCRecvPacket stores the incoming transfer as received.
+func (i *Indexer) HandleIBCRecvPacket(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	msgRecv, ok := msg.(*channeltypes.MsgRecvPacket)
+	if !ok {
+		i.logger.Error().Str("messageName", msgName).Msg("not able to parse into *channeltypes.MsgRecvPacket")
+		return nil
+	}
+
+	step := types.IBCTransferStep{TxHash: hex.EncodeToString(tmTx.Hash()), BlockHeight: blkHeight, BlockTimeUnix: blockTimeUnix, Execution: exec}
+	transfer, ok := types.ParsePacketTransfer(msgRecv.Packet, types.IBCDirectionIncoming, types.IBCStatusReceived, step)
+	if !ok {
+		return nil
+	}
+
+	i.logger.Debug().Msg("storing msg ibc recv packet")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		return i.db.StoreIBCTransfer(ctx, *info, transfer)
+	})
+}
+
+// HandleIBCPacketResult stores the acknowledgement or timeout of the outgoing transfer and the uibc events
+// emitted when the outflow quota could not be reverted.
+func (i *Indexer) HandleIBCPacketResult(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	txHash := hex.EncodeToString(tmTx.Hash())
+	step := types.IBCTransferStep{TxHash: txHash, BlockHeight: blkHeight, BlockTimeUnix: blockTimeUnix, Execution: exec}
+
+	var (
+		transfer   types.IBCTransfer
+		isTransfer bool
+	)
+	switch m := msg.(type) {
+	case *channeltypes.MsgAcknowledgement:
+		transfer, isTransfer = types.ParsePacketTransfer(m.Packet, types.IBCDirectionOutgoing, types.IBCStatusAcked, step)
+		transfer.AckError = types.AckError(m.Acknowledgement)
+	case *channeltypes.MsgTimeout:
+		transfer, isTransfer = types.ParsePacketTransfer(m.Packet, types.IBCDirectionOutgoing, types.IBCStatusTimedOut, step)
+	default:
+		i.logger.Error().Str("messageName", msgName).Msg("not able to parse into ibc packet msg")
+		return nil
+	}
+
+	return i.indexMsgResult(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo, result *abcitypes.ResponseDeliverTx) error {
+		if result.IsErr() {
+			return nil
+		}
+
+		if isTransfer {
+			if err := i.db.StoreIBCTransfer(ctx, *info, transfer); err != nil {
+				return err
+			}
+		}
+
+		var events []types.UIBCEvent
+		for _, evt := range types.TypedEvents(result.Events) {
+			if evt, ok := evt.(*uibc.EventBadRevert); ok {
+				events = append(events, types.ParseEventBadRevert(evt, blkHeight, blockTimeUnix, txHash))
+			}
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		i.logger.Debug().Str("messageName", msgName).Int("events", len(events)).Msg("storing uibc bad revert events")
+		return i.db.StoreUIBCEvents(ctx, *info, events)
+	})
+}
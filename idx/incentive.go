@@ -0,0 +1,70 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleIncentiveMsg stores the incentive msgs with the rewards and fundings found in the tx events.
+func (i *Indexer) HandleIncentiveMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, path string, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing incentive msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			return err
+		}
+		// the transfers of the other msgs of the tx are not part of this msg, the inner msgs share the
+		// events of the msg at their root.
+		msgIndex, err := types.RootMsgIndex(path)
+		if err != nil {
+			return err
+		}
+		events := types.MsgEvents(result.Events, msgIndex)
+
+		// rewards are paid and sponsorships are received by the incentive module account.
+		moduleAddr := authtypes.NewModuleAddress(incentive.ModuleName).String()
+		rewardsTo := func(account string) string {
+			return types.CoinsTransferred(events, moduleAddr, account).String()
+		}
+
+		tx := types.IndexedTx{
+			TxHash:        hex.EncodeToString(tmTx.Hash()),
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *incentive.MsgBond:
+			parsed := types.ParseTxBond(m, rewardsTo(m.Account))
+			tx.MsgBond = &parsed
+		case *incentive.MsgBeginUnbonding:
+			parsed := types.ParseTxBeginUnbonding(m, rewardsTo(m.Account))
+			tx.MsgBeginUnbonding = &parsed
+		case *incentive.MsgEmergencyUnbond:
+			parsed := types.ParseTxEmergencyUnbond(m, rewardsTo(m.Account))
+			tx.MsgEmergencyUnbond = &parsed
+		case *incentive.MsgClaim:
+			parsed := types.ParseTxClaim(m, rewardsTo(m.Account))
+			tx.MsgClaim = &parsed
+		case *incentive.MsgSponsor:
+			parsed := types.ParseTxSponsor(m, types.CoinsTransferred(events, m.Sponsor, moduleAddr).String())
+			tx.MsgSponsor = &parsed
+		case *incentive.MsgGovCreatePrograms:
+			parsed := types.ParseTxGovCreatePrograms(m)
+			tx.MsgGovCreatePrograms = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into incentive msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
@@ -2,6 +2,7 @@ package idx
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -9,12 +10,16 @@ import (
 
 	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/rs/zerolog"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
 	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/migrations"
 	"github.com/umee-network/umeed-indexer/graph/types"
 )
 
 const (
 	IDX_BLOCKS_PER_MINUTE = 100
+	// IDX_SNAPSHOT_BLOCKS is the amount of blocks between each snapshot of the chain state.
+	IDX_SNAPSHOT_BLOCKS = 600
 )
 
 // Indexer struct responsible for calling blockchain rpc/websocket for data and
@@ -29,6 +34,16 @@ type Indexer struct {
 	// defines the lest block that the node has available in his store,
 	// usually nodes do not keep all the blocks forever.
 	lowestBlockHeightAvailableOnNode int
+	// reprocessing is set while the dead letters are reprocessed.
+	reprocessing bool
+	// onlyMsgs are the msgs indexed by the backfill, all the msgs are indexed if empty.
+	onlyMsgs []string
+	// inFlight tracks the old blocks being indexed in the background, waited by the Drain.
+	inFlight sync.WaitGroup
+
+	// oracleMu guards oracleParams, the last oracle params queried, which only change by governance.
+	oracleMu     sync.Mutex
+	oracleParams *oracletypes.Params
 }
 
 // NewIndexer returns a new indexer struct with open connections.
@@ -52,7 +67,8 @@ func (i *Indexer) Index(ctx context.Context) error {
 	return i.IndexCases(ctx, newBlock)
 }
 
-// IndexCases handle all the cases for the indexer.
+// IndexCases handle all the cases for the indexer, until the context is done.
+// The connections are kept open, the Drain waits for the blocks in flight before closing them.
 func (i *Indexer) IndexCases(
 	ctx context.Context,
 	cNewBlock <-chan *tmtypes.Block,
@@ -62,18 +78,23 @@ func (i *Indexer) IndexCases(
 
 	for {
 		select {
-		// only closes the connections if the context is done.
+		// stops receiving blocks if the context is done.
 		case <-ctx.Done():
-			return i.Close(ctx)
+			return nil
 
 		case blk := <-cNewBlock: // listen to new blocks being produced.
-			if err := i.HandleNewBlock(ctx, blk); err != nil {
+			// the block is handled entirely even if the context is done meanwhile.
+			if err := i.HandleNewBlock(context.WithoutCancel(ctx), blk); err != nil {
 				i.logger.Err(err).Msg("error handling block")
 			}
 
 		case <-oneMin.C: // every minute. Tries to index from old blocks, if needed.
 			i.logger.Info().Msgf("One minute passed")
-			go i.IndexOldBlocks(ctx)
+			i.inFlight.Add(1)
+			go func() {
+				defer i.inFlight.Done()
+				i.IndexOldBlocks(ctx)
+			}()
 		}
 	}
 }
@@ -109,16 +130,19 @@ func (i *Indexer) IndexOldBlocks(ctx context.Context) {
 		return
 	}
 
-	if err := i.HandleBlock(ctx, blk); err != nil {
+	if err := i.HandleBlock(context.WithoutCancel(ctx), blk); err != nil {
 		i.logger.Err(err).Int("blockHeight", blockHeight).Msg("error handling old block")
 	}
 	i.IndexBlocksFromTo(ctx, lowestBlock+1, heighestBlock, cosmosMsgs)
 }
 
-// IndexBlocksFromTo index blocks from specific heights.
+// IndexBlocksFromTo index blocks from specific heights, it stops before the next block if the context is done.
 func (i *Indexer) IndexBlocksFromTo(ctx context.Context, from, to int, cosmosMsgs []*types.CosmosMsgIndexed) {
-	var wg sync.WaitGroup
-	mapBlockByHeight := make(map[int]*tmtypes.Block)
+	var (
+		wg               sync.WaitGroup
+		mu               sync.Mutex
+		mapBlockByHeight = make(map[int]*tmtypes.Block)
+	)
 
 	for blockHeight := from; blockHeight < to; blockHeight++ {
 		blockHeight := blockHeight
@@ -135,8 +159,12 @@ func (i *Indexer) IndexBlocksFromTo(ctx context.Context, from, to int, cosmosMsg
 				i.logger.Err(err).Int("blockHeight", blockHeight).Msg("error getting old block from blockchain")
 				return
 			}
+			if blk == nil { // not available on the node.
+				return
+			}
+			mu.Lock()
 			mapBlockByHeight[blockHeight] = blk
-
+			mu.Unlock()
 		}(blockHeight)
 	}
 
@@ -146,18 +174,38 @@ func (i *Indexer) IndexBlocksFromTo(ctx context.Context, from, to int, cosmosMsg
 		if !ok {
 			continue
 		}
+		if ctx.Err() != nil {
+			return
+		}
 
-		if err := i.HandleBlock(ctx, blk); err != nil {
+		if err := i.HandleBlock(context.WithoutCancel(ctx), blk); err != nil {
 			i.logger.Err(err).Int("blockHeight", blockHeight).Msg("error handling old block")
 		}
 	}
 
 }
 
-// UpsertChainInfo updates the chain info.
-func (i *Indexer) UpsertChainInfo(ctx context.Context) error {
+// UpdateChainHead updates the last block received of the chain info stored.
+func (i *Indexer) UpdateChainHead(ctx context.Context) error {
+	return i.db.UpdateChainHead(ctx, i.chainInfo.Head())
+}
+
+// SkipBlocksBeforeMsgStart marks the block heights before the start height of each msg as indexed,
+// as the msgs added to the chain by an upgrade, so the old blocks are not fetched for them.
+func (i *Indexer) SkipBlocksBeforeMsgStart(ctx context.Context, msgStartHeights map[string]int) error {
 	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
-		return i.db.UpsertChainInfo(ctx, *info)
+		for msgName, startHeight := range msgStartHeights {
+			if startHeight <= 1 {
+				continue
+			}
+			if !info.IndexBlockRangeForMsg(msgName, 1, startHeight-1) {
+				return fmt.Errorf("msg %s is not indexed", msgName)
+			}
+			if err := i.db.IndexBlockRange(ctx, *info, []string{msgName}, 1, startHeight-1); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
@@ -174,14 +222,51 @@ func (i *Indexer) loadChainHeader(ctx context.Context) error {
 		i.logger.Err(err).Msg("error loading chain header")
 		return err
 	}
+	// the docs stored by older versions are migrated before indexing new ones.
+	if _, err := migrations.Run(ctx, i.db, chainID, i.logger); err != nil {
+		i.logger.Err(err).Msg("error migrating the chain data")
+		return err
+	}
 	info, err := i.db.GetChainInfo(ctx, chainID)
 	if err != nil {
 		i.logger.Err(err).Msg("error loading chain info")
 		return err
 	}
 	info.LastBlockHeightReceived = int(height)
+	i.observeChainInfo(info)
+	// the intervals indexed stored by the other indexers of the chain are only loaded here.
 	i.chainInfo = *NewSafeChainInfo(info)
-	return i.UpsertChainInfo(ctx)
+	return i.UpdateChainHead(ctx)
+}
+
+// Drain waits for the blocks being indexed to be stored, then stores the chain head, both until the
+// context is done. The indexing must be stopped before and the connections are kept open.
+func (i *Indexer) Drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		i.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		// the blocks not stored yet are not marked as indexed, they are indexed again on the next start,
+		// which stores the chain head too.
+		return fmt.Errorf("timeout waiting for the blocks being indexed: %w", ctx.Err())
+	}
+
+	// the database may not stop writing when the context is done, so it is not waited after it.
+	stored := make(chan error, 1)
+	go func() {
+		stored <- i.UpdateChainHead(ctx)
+	}()
+	select {
+	case err := <-stored:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timeout storing the chain head: %w", ctx.Err())
+	}
 }
 
 // Close closes all the open connections.
@@ -0,0 +1,530 @@
+package idx_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/database/firebase"
+	"github.com/umee-network/umeed-indexer/database/memory"
+	"github.com/umee-network/umeed-indexer/database/migrations"
+	"github.com/umee-network/umeed-indexer/export"
+	"github.com/umee-network/umeed-indexer/graph/types"
+	"github.com/umee-network/umeed-indexer/idx"
+	"github.com/umee-network/umeed-indexer/replay"
+)
+
+const (
+	// recordingLiquidations has a liquidation, a leveraged liquidation with a failed liquidation in the
+	// same block and an oracle vote at the last block of the vote period, where one validator missed.
+	recordingLiquidations = "testdata/umee-liquidations"
+
+	chainID     = "umee-1"
+	liquidator  = "umee1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5d53pzw"
+	borrower    = "umee19q5j52ev95hz7vp3xgengdfkxuurjw3mcyzlf8"
+	levBorrower = "umee12pg4y56524t9wkzetfd4ch27tasxzcnrzcangw"
+	valoperVote = "umeevaloper15zs69gay5kn2029f4246etdw47ctrv4nq9z56j"
+	valoperMiss = "umeevaloper1eryu4j7veh8vl5x36tfaf4wk6lvdnkkmg8qhyd"
+)
+
+func TestIndexerReplay(t *testing.T) {
+	b := replayBlockchain(t, recordingLiquidations)
+	db := indexAll(t, b, 7942001, 7942004)
+	requireLiquidationsIndexed(t, db)
+}
+
+func TestRecorderReplay(t *testing.T) {
+	recording, err := archive.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	rec, err := replay.NewRecorder(replayBlockchain(t, recordingLiquidations), recording, zerolog.Nop())
+	require.NoError(t, err)
+	requireLiquidationsIndexed(t, indexAll(t, rec, 7942001, 7942004))
+
+	// the recording of the indexing is enough to index the same blocks again.
+	b, err := replay.NewBlockchain(recording, 0)
+	require.NoError(t, err)
+	requireLiquidationsIndexed(t, indexAll(t, b, 7942001, 7942004))
+}
+
+func TestStatus(t *testing.T) {
+	b := replayBlockchain(t, recordingLiquidations)
+	db := indexAll(t, b, 7942001, 7942004)
+
+	status, err := idx.Status(context.Background(), b, db, chainID)
+	require.NoError(t, err)
+	require.Equal(t, 7942004, status.ChainHeight)
+	require.Equal(t, 7942001, status.LowestHeightOnNode)
+	for _, msg := range status.Msgs {
+		require.Equal(t, 4, msg.BlocksIndexed, msg.ProtoMsgName)
+		require.Empty(t, msg.Gaps)
+		require.Zero(t, msg.Lag)
+	}
+
+	_, err = idx.Status(context.Background(), b, db, "umee-2")
+	require.ErrorContains(t, err, "not umee-2")
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New(zerolog.Nop())
+
+	// both indexers start before any block is indexed, like a live indexer and a backfill job.
+	live, err := idx.NewIndexer(ctx, replayBlockchain(t, recordingLiquidations), db, zerolog.Nop(), 7942001)
+	require.NoError(t, err)
+	backfill, err := idx.NewIndexer(ctx, replayBlockchain(t, recordingLiquidations), db, zerolog.Nop(), 7942001)
+	require.NoError(t, err)
+
+	var last idx.BackfillProgress
+	require.NoError(t, backfill.Backfill(ctx, 7942001, 7942004, 4, []string{types.MsgNameLiquidate}, func(p idx.BackfillProgress) {
+		last = p
+	}))
+	require.Equal(t, idx.BackfillProgress{Height: 7942004, Done: 4, Total: 4, Elapsed: last.Elapsed}, last)
+
+	txs, err := db.GetLiquidateMsgs(ctx, chainID, borrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	txs, err = db.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	// the live indexer keeps the intervals stored by the backfill.
+	require.NoError(t, live.Backfill(ctx, 7942004, 7942004, 1, nil, func(idx.BackfillProgress) {}))
+	info, err := db.GetChainInfo(ctx, chainID)
+	require.NoError(t, err)
+	for height := 7942001; height <= 7942004; height++ {
+		require.False(t, types.NeedsToIndexForMsg(types.MsgNameLiquidate, info.CosmosMsgs, height))
+		require.Equal(t, height != 7942004, types.NeedsToIndexForMsg(types.MsgNameLeveragedLiquidate, info.CosmosMsgs, height))
+	}
+}
+
+func TestReindex(t *testing.T) {
+	ctx := context.Background()
+	db := indexAll(t, replayBlockchain(t, recordingLiquidations), 7942001, 7942004)
+
+	deleted, err := idx.Reindex(ctx, db, chainID, types.MsgNameLiquidate, 7942001, 7942002)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	deleted, err = idx.Reindex(ctx, db, chainID, types.MsgNameAggregateExchangeRateVote, 7942001, 7942004)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	// the leveraged liquidation of the same block is kept.
+	txs, err := db.GetLiquidateMsgs(ctx, chainID, borrower)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	txs, err = db.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+
+	perfs, err := db.GetOracleValidatorPerformance(ctx, chainID, valoperMiss, nil)
+	require.NoError(t, err)
+	require.Len(t, perfs, 1)
+	require.Equal(t, 0, perfs[0].Misses)
+
+	info, err := db.GetChainInfo(ctx, chainID)
+	require.NoError(t, err)
+	require.True(t, types.NeedsToIndexForMsg(types.MsgNameLiquidate, info.CosmosMsgs, 7942002))
+	require.False(t, types.NeedsToIndexForMsg(types.MsgNameLiquidate, info.CosmosMsgs, 7942003))
+	require.False(t, types.NeedsToIndexForMsg(types.MsgNameLeveragedLiquidate, info.CosmosMsgs, 7942002))
+
+	_, err = idx.Reindex(ctx, db, chainID, types.MsgNameTransfer, 7942001, 7942004)
+	require.ErrorContains(t, err, "can not be reindexed")
+
+	// the balance changes are kept by reindexing the bank msgs, they are reindexed apart.
+	_, err = idx.Reindex(ctx, db, chainID, types.MsgNameSend, 7942001, 7942004)
+	require.NoError(t, err)
+	changes, err := db.GetBalanceChanges(ctx, chainID, liquidator, nil, nil, nil, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	deleted, err = idx.Reindex(ctx, db, chainID, types.MsgNameBalanceChanges, 7942001, 7942004)
+	require.NoError(t, err)
+	require.NotZero(t, deleted)
+	changes, err = db.GetBalanceChanges(ctx, chainID, liquidator, nil, nil, nil, 10, nil)
+	require.NoError(t, err)
+	require.Empty(t, changes.Changes)
+
+	i, err := idx.NewIndexer(ctx, replayBlockchain(t, recordingLiquidations), db, zerolog.Nop(), 7942001)
+	require.NoError(t, err)
+	var heights []int
+	msgs := []string{types.MsgNameLiquidate, types.MsgNameAggregateExchangeRateVote, types.MsgNameBalanceChanges}
+	require.NoError(t, i.Backfill(ctx, 7942001, 7942004, 2, msgs, func(p idx.BackfillProgress) {
+		heights = append(heights, p.Height)
+	}))
+	require.Equal(t, []int{7942001, 7942002, 7942003, 7942004}, heights)
+	requireLiquidationsIndexed(t, db)
+
+	// the chain head stored by a running indexer stops the reindex.
+	head := types.ChainInfo{ChainID: chainID, LastBlockHeightReceived: 7942005, LastBlockTimeUnixReceived: int(time.Now().Unix())}
+	require.NoError(t, db.UpdateChainHead(ctx, head))
+	_, err = idx.Reindex(ctx, db, chainID, types.MsgNameLiquidate, 7942001, 7942002)
+	require.ErrorContains(t, err, "stop its indexers")
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	b := replayBlockchain(t, recordingLiquidations)
+	db := indexAll(t, b, 7942001, 7942004)
+
+	diffs, err := idx.Verify(ctx, b, db, zerolog.Nop(), chainID, 7942001, 7942004, 2)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	// deletes the liquidation keeping the block height indexed and stores the leveraged one twice.
+	deleted, err := db.DeleteMsgRecords(ctx, chainID, types.MsgNameLiquidate, 7942001, 7942001)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	txs, err := db.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	info, err := db.GetChainInfo(ctx, chainID)
+	require.NoError(t, err)
+	require.NoError(t, db.StoreTx(ctx, *info, *txs[0]))
+
+	diffs, err = idx.Verify(ctx, b, db, zerolog.Nop(), chainID, 7942001, 7942004, 2)
+	require.NoError(t, err)
+	require.Len(t, diffs, 2)
+	require.Equal(t, types.RecordDiffMissing, diffs[0].Type)
+	require.Equal(t, types.MsgNameLiquidate, diffs[0].ProtoMsgName)
+	require.Equal(t, 7942001, diffs[0].BlockHeight)
+	require.Equal(t, types.RecordDiffDuplicate, diffs[1].Type)
+	require.Equal(t, types.MsgNameLeveragedLiquidate, diffs[1].ProtoMsgName)
+	require.Equal(t, 7942002, diffs[1].BlockHeight)
+
+	unrepaired, err := idx.Repair(ctx, b, db, zerolog.Nop(), chainID, diffs, 2)
+	require.NoError(t, err)
+	require.Empty(t, unrepaired)
+	requireLiquidationsIndexed(t, db)
+
+	diffs, err = idx.Verify(ctx, b, db, zerolog.Nop(), chainID, 7942001, 7942004, 2)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	db := indexAll(t, replayBlockchain(t, recordingLiquidations), 7942001, 7942004)
+
+	for _, format := range []string{export.FormatJSONL, export.FormatParquet} {
+		path := filepath.Join(t.TempDir(), "umee-1."+format)
+		w, err := export.CreateFile(path, format)
+		require.NoError(t, err)
+		exported, err := export.Export(ctx, db, chainID, export.Filter{}, w)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		imported := memory.New(zerolog.Nop())
+		r, closeFile, err := export.OpenFile(path, export.FormatOfFile(path))
+		require.NoError(t, err)
+		count, err := export.Import(ctx, imported, r)
+		require.NoError(t, err)
+		require.NoError(t, closeFile())
+		require.Equal(t, exported, count)
+		requireLiquidationsIndexed(t, imported)
+
+		info, err := imported.GetChainInfo(ctx, chainID)
+		require.NoError(t, err)
+		require.False(t, info.NeedsToIndex(7942004))
+	}
+
+	// only the liquidation and the blocks indexed of its msg are exported.
+	path := filepath.Join(t.TempDir(), "liquidate.jsonl")
+	w, err := export.CreateFile(path, export.FormatJSONL)
+	require.NoError(t, err)
+	filter := export.Filter{MsgNames: []string{types.MsgNameLiquidate}, FromHeight: 7942001, ToHeight: 7942002}
+	exported, err := export.Export(ctx, db, chainID, filter, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, exported)
+
+	imported := memory.New(zerolog.Nop())
+	r, closeFile, err := export.OpenFile(path, export.FormatJSONL)
+	require.NoError(t, err)
+	_, err = export.Import(ctx, imported, r)
+	require.NoError(t, err)
+	require.NoError(t, closeFile())
+
+	txs, err := imported.GetLiquidateMsgs(ctx, chainID, borrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	txs, err = imported.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	info, err := imported.GetChainInfo(ctx, chainID)
+	require.NoError(t, err)
+	require.False(t, types.NeedsToIndexForMsg(types.MsgNameLiquidate, info.CosmosMsgs, 7942002))
+	require.True(t, types.NeedsToIndexForMsg(types.MsgNameLiquidate, info.CosmosMsgs, 7942003))
+	require.True(t, types.NeedsToIndexForMsg(types.MsgNameLeveragedLiquidate, info.CosmosMsgs, 7942002))
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	src := indexAll(t, replayBlockchain(t, recordingLiquidations), 7942001, 7942004)
+	dst := memory.New(zerolog.Nop())
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	// the first run is interrupted after two batches.
+	cp, err := export.LoadCheckpoint(path, chainID)
+	require.NoError(t, err)
+	saves := 0
+	err = export.Migrate(ctx, src, dst, cp, 1, func(cp *export.Checkpoint) error {
+		if saves++; saves > 2 {
+			return errors.New("interrupted")
+		}
+		return cp.Save(path)
+	})
+	require.ErrorContains(t, err, "interrupted")
+
+	mismatches, err := export.VerifyMigration(ctx, src, dst, chainID)
+	require.NoError(t, err)
+	require.NotEmpty(t, mismatches)
+
+	cp, err = export.LoadCheckpoint(path, chainID)
+	require.NoError(t, err)
+	require.Equal(t, 2, cp.Copied[firebase.CollTransactions])
+	require.False(t, cp.Done[firebase.CollTransactions])
+	require.NoError(t, export.Migrate(ctx, src, dst, cp, 2, func(cp *export.Checkpoint) error {
+		return cp.Save(path)
+	}))
+	require.True(t, cp.ChainInfoDone)
+
+	mismatches, err = export.VerifyMigration(ctx, src, dst, chainID)
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+	requireLiquidationsIndexed(t, dst)
+
+	_, err = export.LoadCheckpoint(path, "umee-2")
+	require.ErrorContains(t, err, "not umee-2")
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New(zerolog.Nop())
+
+	// a leveraged liquidation stored with the msg name of the liquidations before the schema version 1.
+	info := types.DefaultChainInfo(chainID)
+	require.NoError(t, db.StoreTx(ctx, *info, types.IndexedTx{
+		TxHash:               "A1B2",
+		ProtoMsgName:         types.MsgNameLiquidate,
+		BlockHeight:          7942002,
+		MsgLeverageLiquidate: &types.MsgLeverageLiquidate{Liquidator: liquidator, Borrower: levBorrower},
+	}))
+	// the balance changes were stored in the blocks indexed of MsgSend.
+	require.NoError(t, db.IndexBlockRange(ctx, *info, []string{types.MsgNameSend}, 7942001, 7942004))
+	_, err := idx.Reindex(ctx, db, chainID, types.MsgNameLeveragedLiquidate, 7942001, 7942004)
+	require.ErrorContains(t, err, "run the migrations")
+
+	ran, err := migrations.Run(ctx, db, chainID, zerolog.Nop())
+	require.NoError(t, err)
+	require.Len(t, ran, migrations.LatestVersion())
+	version, err := db.GetSchemaVersion(ctx, chainID)
+	require.NoError(t, err)
+	require.Equal(t, migrations.LatestVersion(), version)
+
+	txs, err := db.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, types.MsgNameLeveragedLiquidate, txs[0].ProtoMsgName)
+	stored, err := db.GetChainInfo(ctx, chainID)
+	require.NoError(t, err)
+	require.False(t, types.NeedsToIndexForMsg(types.MsgNameBalanceChanges, stored.CosmosMsgs, 7942002))
+
+	ran, err = migrations.Run(ctx, db, chainID, zerolog.Nop())
+	require.NoError(t, err)
+	require.Empty(t, ran)
+
+	deleted, err := idx.Reindex(ctx, db, chainID, types.MsgNameLeveragedLiquidate, 7942001, 7942004)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+}
+
+func TestDrainTimeout(t *testing.T) {
+	ctx := context.Background()
+	db := &stuckDB{Database: memory.New(zerolog.Nop()), release: make(chan struct{})}
+	defer close(db.release)
+
+	i, err := idx.NewIndexer(ctx, replayBlockchain(t, recordingLiquidations), db, zerolog.Nop(), 7942001)
+	require.NoError(t, err)
+
+	// the chain head is stored by a database that does not stop on the context done.
+	db.stuck.Store(true)
+	drainCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = i.Drain(drainCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "chain head")
+	require.Less(t, time.Since(start), time.Second, "the drain ends by the deadline")
+}
+
+func TestOracleMisses(t *testing.T) {
+	ctx := context.Background()
+	b := &oracleParamsCounter{Blockchain: replayBlockchain(t, recordingLiquidations)}
+	db := indexAll(t, b, 7942001, 7942004)
+	requireLiquidationsIndexed(t, db)
+
+	// the params are not queried inside of the vote period ending at 7942004, once they are cached.
+	b.mu.Lock()
+	require.NotContains(t, b.heights, int64(7942002))
+	require.NotContains(t, b.heights, int64(7942003))
+	require.Contains(t, b.heights, int64(7942004))
+	b.mu.Unlock()
+
+	// the misses of a block handled again are not counted twice.
+	miss := types.NewOracleMiss(valoperMiss, 5, 100, 7942004, 0)
+	require.NoError(t, db.StoreOracleVotes(ctx, types.ChainInfo{ChainID: chainID}, []types.OracleValidatorVote{miss, miss}))
+	perfs, err := db.GetOracleValidatorPerformance(ctx, chainID, valoperMiss, nil)
+	require.NoError(t, err)
+	require.Len(t, perfs, 1)
+	require.Equal(t, 1, perfs[0].Misses)
+}
+
+// oracleParamsCounter records the heights of the oracle params queried.
+type oracleParamsCounter struct {
+	*replay.Blockchain
+	mu      sync.Mutex
+	heights []int64
+}
+
+func (b *oracleParamsCounter) OracleParams(ctx context.Context, height int64) (oracletypes.Params, error) {
+	b.mu.Lock()
+	b.heights = append(b.heights, height)
+	b.mu.Unlock()
+	return b.Blockchain.OracleParams(ctx, height)
+}
+
+func TestReprocessDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	db := indexAll(t, replayBlockchain(t, recordingLiquidations), 7942001, 7942004)
+
+	// a tx that still fails to be decoded.
+	letter := types.NewDeadLetter(7942002, 0, types.DeadLetterHandlerDecodeTx, []byte("not a tx"), "", "", errors.New("decoding"))
+	require.NoError(t, db.StoreDeadLetter(ctx, chainID, letter))
+
+	i, err := idx.NewIndexer(ctx, replayBlockchain(t, recordingLiquidations), db, zerolog.Nop(), 7942001)
+	require.NoError(t, err)
+	_, _, err = i.ReprocessDeadLetters(ctx, "umee-2", nil)
+	require.ErrorContains(t, err, "the node is of the chain umee-1, not umee-2")
+
+	reprocessed, failed, err := i.ReprocessDeadLetters(ctx, chainID, nil)
+	require.NoError(t, err)
+	require.Zero(t, reprocessed)
+	require.Equal(t, 1, failed)
+
+	// the attempt that failed again is counted once.
+	letters, err := db.GetDeadLetters(ctx, chainID, nil)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, letter.ID, letters[0].ID)
+	require.Equal(t, 2, letters[0].Attempts)
+}
+
+// stuckDB is a database where the chain head writes hang until it is released, once stuck.
+type stuckDB struct {
+	*memory.Database
+	stuck   atomic.Bool
+	release chan struct{}
+}
+
+func (db *stuckDB) UpdateChainHead(ctx context.Context, info types.ChainInfo) error {
+	if db.stuck.Load() {
+		<-db.release
+	}
+	return db.Database.UpdateChainHead(ctx, info)
+}
+
+func requireLiquidationsIndexed(t *testing.T, db *memory.Database) {
+	ctx := context.Background()
+
+	txs, err := db.GetLiquidateMsgs(ctx, chainID, borrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, 7942001, txs[0].BlockHeight)
+	require.Equal(t, types.MsgNameLiquidate, txs[0].ProtoMsgName)
+	require.Equal(t, types.MsgLiquidate{
+		Liquidator:  liquidator,
+		Borrower:    borrower,
+		Repayment:   "1000000uumee",
+		RewardDenom: "uumee",
+	}, *txs[0].MsgLiquidate)
+
+	// the failed liquidation of the same borrower is not indexed.
+	txs, err = db.GetLiquidateMsgs(ctx, chainID, levBorrower)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, 7942002, txs[0].BlockHeight)
+	require.Nil(t, txs[0].MsgLiquidate)
+	require.Equal(t, types.MsgNameLeveragedLiquidate, txs[0].ProtoMsgName)
+	require.Equal(t, "250.500000000000000000", txs[0].MsgLeverageLiquidate.MaxRepay)
+
+	changes, err := db.GetBalanceChanges(ctx, chainID, liquidator, nil, nil, nil, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	require.Equal(t, "50000", changes.Changes[0].Amount)
+	require.Equal(t, 7942001, changes.Changes[0].BlockHeight)
+
+	perfs, err := db.GetOracleValidatorPerformance(ctx, chainID, valoperVote, nil)
+	require.NoError(t, err)
+	require.Len(t, perfs, 1)
+	require.Equal(t, 1, perfs[0].Votes)
+	require.Equal(t, 0, perfs[0].Misses)
+
+	perfs, err = db.GetOracleValidatorPerformance(ctx, chainID, valoperMiss, nil)
+	require.NoError(t, err)
+	require.Len(t, perfs, 1)
+	require.Equal(t, 0, perfs[0].Votes)
+	require.Equal(t, 1, perfs[0].Misses)
+
+	letters, err := db.GetDeadLetters(ctx, chainID, nil)
+	require.NoError(t, err)
+	require.Empty(t, letters)
+}
+
+// indexAll runs the indexer over the blockchain until the blocks between the heights are indexed.
+func indexAll(t *testing.T, b idx.Blockchain, fromHeight, toHeight int) *memory.Database {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := memory.New(zerolog.Nop())
+	i, err := idx.NewIndexer(ctx, b, db, zerolog.Nop(), fromHeight)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- i.Index(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		info, err := db.GetChainInfo(ctx, chainID)
+		require.NoError(t, err)
+		for height := fromHeight; height <= toHeight; height++ {
+			if types.NeedsToIndex(info.CosmosMsgs, height) {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.NoError(t, i.Drain(context.Background()))
+	require.NoError(t, i.Close(context.Background()))
+	return db
+}
+
+func replayBlockchain(t *testing.T, dir string) *replay.Blockchain {
+	store, err := archive.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	b, err := replay.NewBlockchain(store, 0)
+	require.NoError(t, err)
+	return b
+}
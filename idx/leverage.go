@@ -0,0 +1,73 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	lvgtypes "github.com/umee-network/umee/v6/x/leverage/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleLeverageGovMsg stores the leverage registry msgs executed directly by a tx and the token
+// registry changes made by them.
+func (i *Indexer) HandleLeverageGovMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing leverage gov msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		txHash := hex.EncodeToString(tmTx.Hash())
+		tx := types.IndexedTx{
+			TxHash:        txHash,
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *lvgtypes.MsgGovUpdateRegistry:
+			parsed := types.ParseTxGovUpdateRegistry(m)
+			tx.MsgGovUpdateRegistry = &parsed
+		case *lvgtypes.MsgGovUpdateSpecialAssets:
+			parsed := types.ParseTxGovUpdateSpecialAssets(m)
+			tx.MsgGovUpdateSpecialAssets = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into leverage gov msg")
+			return nil
+		}
+
+		changes := types.NewTokenRegistryChanges(&types.GovProposalMsg{
+			ProtoMsgName:              msgName,
+			MsgGovUpdateRegistry:      tx.MsgGovUpdateRegistry,
+			MsgGovUpdateSpecialAssets: tx.MsgGovUpdateSpecialAssets,
+		}, blkHeight, blockTimeUnix, 0, txHash)
+		return i.db.StoreTokenRegistryChanges(ctx, *info, &tx, changes)
+	})
+}
+
+// storeProposalRegistryChanges stores the token registry changes made by the msgs of a passed proposal.
+// The msgs are taken from the chain proposal when it was queried, otherwise from the indexed proposal.
+func (i *Indexer) storeProposalRegistryChanges(ctx context.Context, info types.ChainInfo, proposalID int, msgs []*types.GovProposalMsg, blkHeight, blockTimeUnix int) error {
+	if msgs == nil {
+		stored, err := i.db.GetGovProposal(ctx, info.ChainID, proposalID)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			i.logger.Warn().Int("proposalID", proposalID).Msg("passed proposal was not indexed, its registry changes are unknown")
+			return nil
+		}
+		msgs = stored.Messages
+	}
+
+	var changes []*types.TokenRegistryChange
+	for _, msg := range msgs {
+		changes = append(changes, types.NewTokenRegistryChanges(msg, blkHeight, blockTimeUnix, proposalID, "")...)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	i.logger.Debug().Int("proposalID", proposalID).Int("changes", len(changes)).Msg("storing token registry changes")
+	return i.db.StoreTokenRegistryChanges(ctx, info, nil, changes)
+}
@@ -0,0 +1,60 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/metoken"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleMetokenMsg stores the metoken swap and redeem msgs with the amounts and fees emitted in the tx events.
+func (i *Indexer) HandleMetokenMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing metoken msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			return err
+		}
+		events := result.Events
+		typedEvents := types.TypedEvents(events)
+
+		tx := types.IndexedTx{
+			TxHash:        hex.EncodeToString(tmTx.Hash()),
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *metoken.MsgSwap:
+			var evtSwap *metoken.EventSwap
+			for _, evt := range typedEvents {
+				if evt, ok := evt.(*metoken.EventSwap); ok && evt.Recipient == m.User && evt.Asset.Denom == m.Asset.Denom {
+					evtSwap = evt
+					break
+				}
+			}
+			parsed := types.ParseTxSwap(m, evtSwap)
+			tx.MsgSwap = &parsed
+		case *metoken.MsgRedeem:
+			var evtRedeem *metoken.EventRedeem
+			for _, evt := range typedEvents {
+				if evt, ok := evt.(*metoken.EventRedeem); ok && evt.Recipient == m.User && evt.Metoken.Denom == m.Metoken.Denom {
+					evtRedeem = evt
+					break
+				}
+			}
+			parsed := types.ParseTxRedeem(m, evtRedeem)
+			tx.MsgRedeem = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into metoken msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
@@ -0,0 +1,70 @@
+package idx
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+const (
+	resultOK       = "ok"
+	resultError    = "error"
+	resultTxFailed = "tx_failed"
+)
+
+var (
+	chainHeight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "indexer",
+		Name:      "chain_height",
+		Help:      "Height of the last block received from the chain.",
+	})
+	tipHeight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "indexer",
+		Name:      "tip_height",
+		Help:      "Height of the last new block indexed, it lags behind the chain height while the blocks are handled.",
+	})
+	blocksIndexed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "indexer",
+		Name:      "blocks_indexed_total",
+		Help:      "Amount of blocks indexed, new, old and backfilled ones.",
+	})
+	blocksRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "indexer",
+		Name:      "blocks_remaining",
+		Help:      "Amount of blocks available on the node up to the chain height that were not indexed yet by msg.",
+	}, []string{"msg"})
+	handled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "umeed_indexer",
+		Subsystem: "indexer",
+		Name:      "handled_total",
+		Help:      "Amount of msgs and blocks handled by handler, msg and result: ok, error or tx_failed.",
+	}, []string{"handler", "msg", "result"})
+)
+
+// observeChainInfo records the heights received and the blocks not indexed yet of each msg.
+func (i *Indexer) observeChainInfo(info *types.ChainInfo) {
+	chainHeight.Set(float64(info.LastBlockHeightReceived))
+	lowestHeight := max(i.lowestBlockHeightAvailableOnNode, 1)
+	for _, cosmosMsg := range info.CosmosMsgs {
+		remaining := types.BlocksNotIndexed(cosmosMsg.BlocksIndexed, lowestHeight, info.LastBlockHeightReceived)
+		blocksRemaining.WithLabelValues(cosmosMsg.ProtoMsgName).Set(float64(remaining))
+	}
+}
+
+// observeHandled records the result of the handler.
+func observeHandled(handler, msgName string, err error) {
+	result := resultOK
+	switch {
+	case errors.Is(err, types.ErrTxFailed):
+		result = resultTxFailed
+	case err != nil:
+		result = resultError
+	}
+	handled.WithLabelValues(handler, msgName, result).Inc()
+}
@@ -0,0 +1,103 @@
+package idx
+
+import (
+	"context"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleOracleMisses stores the validators that missed the oracle vote, it only does something
+// at the last block of the vote period, which is when the oracle module counts the misses.
+func (i *Indexer) HandleOracleMisses(ctx context.Context, blk *tmtypes.Block) error {
+	blkHeight := int(blk.Height)
+	return i.chainInfo.Execute(func(info *types.ChainInfo) error {
+		if !i.needsToIndexForMsg(types.MsgNameAggregateExchangeRateVote, info.CosmosMsgs, blkHeight) {
+			return nil
+		}
+
+		params, err := i.oracleParamsAtPeriodEnd(ctx, blk.Height)
+		if err != nil || params == nil {
+			return err
+		}
+		votePeriod, slashWindow := int(params.VotePeriod), int(params.SlashWindow)
+
+		voted, err := i.oracleVotersInPeriod(ctx, blk)
+		if err != nil {
+			return err
+		}
+
+		// the validator set that the oracle module uses to count the misses.
+		valopers, err := i.b.BondedValidators(ctx, blk.Height-1)
+		if err != nil {
+			return err
+		}
+
+		misses := types.OracleMisses(valopers, voted, votePeriod, slashWindow, blkHeight, int(blk.Time.Unix()))
+		if len(misses) == 0 {
+			return nil
+		}
+
+		i.logger.Debug().Int("height", blkHeight).Int("misses", len(misses)).Msg("storing oracle misses")
+		return i.db.StoreOracleVotes(ctx, *info, misses)
+	})
+}
+
+// oracleParamsAtPeriodEnd returns the oracle params if the block is the last of its vote period, nil otherwise.
+// The vote period is checked with the cached params first and the params are only queried at the last blocks,
+// a vote period changed by governance is seen at the last block of the cached vote period.
+func (i *Indexer) oracleParamsAtPeriodEnd(ctx context.Context, height int64) (*oracletypes.Params, error) {
+	i.oracleMu.Lock()
+	cached := i.oracleParams
+	i.oracleMu.Unlock()
+	if cached != nil && !types.IsPeriodLastBlock(int(height), int(cached.VotePeriod)) {
+		return nil, nil
+	}
+
+	params, err := i.b.OracleParams(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	i.oracleMu.Lock()
+	i.oracleParams = &params
+	i.oracleMu.Unlock()
+
+	if !types.IsPeriodLastBlock(int(height), int(params.VotePeriod)) {
+		return nil, nil
+	}
+	return &params, nil
+}
+
+// oracleVotersInPeriod returns the validators that voted in the vote period ending at this block.
+// The votes stored in the state until the previous block are summed with the votes in this block.
+func (i *Indexer) oracleVotersInPeriod(ctx context.Context, blk *tmtypes.Block) (voted map[string]struct{}, err error) {
+	voters, err := i.b.OracleAggregateVotes(ctx, blk.Height-1)
+	if err != nil {
+		return nil, err
+	}
+
+	voted = make(map[string]struct{}, len(voters))
+	for _, voter := range voters {
+		voted[voter] = struct{}{}
+	}
+
+	for _, tmTx := range blk.Data.Txs {
+		tx, err := i.b.DecodeTx(blk.Height, tmTx)
+		if err != nil {
+			continue
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			msgVote, ok := msg.(*oracletypes.MsgAggregateExchangeRateVote)
+			if !ok {
+				continue
+			}
+			if err := i.b.CheckTx(ctx, tmTx); err != nil {
+				break
+			}
+			voted[msgVote.Validator] = struct{}{}
+		}
+	}
+	return voted, nil
+}
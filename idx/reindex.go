@@ -0,0 +1,45 @@
+package idx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/migrations"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// ReindexIdleTime is how long the chain head stored must be older than for the reindex to run, the
+// indexers of the chain store it on every block.
+const ReindexIdleTime = time.Minute
+
+// Reindex removes the block heights from the intervals indexed of the msg and deletes the records
+// stored for them, so they are indexed again by the backfill.
+// It must not run while an indexer of the chain is running, it could store records of the block heights
+// while they are deleted, so it fails if the chain head was stored in the last ReindexIdleTime.
+func Reindex(ctx context.Context, db database.Database, chainID, msgName string, fromHeight, toHeight int) (deleted int, err error) {
+	if fromHeight < 1 || toHeight < fromHeight {
+		return 0, fmt.Errorf("invalid block heights to reindex: %d ~ %d", fromHeight, toHeight)
+	}
+	if err := types.ValidateReindexMsg(msgName); err != nil {
+		return 0, err
+	}
+	// the records are found by the msg name they are stored with in the latest schema.
+	if err := migrations.RequireLatest(ctx, db, chainID); err != nil {
+		return 0, err
+	}
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return 0, err
+	}
+	if idle := time.Since(time.Unix(int64(info.LastBlockTimeUnixReceived), 0)); idle < ReindexIdleTime {
+		return 0, fmt.Errorf("the chain head of %s was stored %s ago, stop its indexers and run it again after %s", chainID, idle.Round(time.Second), ReindexIdleTime)
+	}
+
+	// the block heights are unindexed first, if deleting the records fails running it again deletes the rest.
+	if err := db.UnindexBlockRange(ctx, chainID, msgName, fromHeight, toHeight); err != nil {
+		return 0, err
+	}
+	return db.DeleteMsgRecords(ctx, chainID, msgName, fromHeight, toHeight)
+}
@@ -0,0 +1,79 @@
+package idx
+
+import (
+	"context"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleSnapshots stores the state queried from the chain every {IDX_SNAPSHOT_BLOCKS}.
+// Snapshots are only taken from new blocks, the node usually does not keep the state of old heights.
+func (i *Indexer) HandleSnapshots(ctx context.Context, blk *tmtypes.Block) {
+	if blk.Height%IDX_SNAPSHOT_BLOCKS != 0 {
+		return
+	}
+
+	if err := i.snapshotIncentivePrograms(ctx, blk); err != nil {
+		i.logger.Err(err).Int64("height", blk.Height).Msg("error taking snapshot of incentive programs")
+	}
+	if err := i.snapshotMetokenIndexes(ctx, blk); err != nil {
+		i.logger.Err(err).Int64("height", blk.Height).Msg("error taking snapshot of metoken indexes")
+	}
+	if err := i.snapshotUIBCQuota(ctx, blk); err != nil {
+		i.logger.Err(err).Int64("height", blk.Height).Msg("error taking snapshot of uibc quota")
+	}
+}
+
+// snapshotIncentivePrograms stores the current state of the ongoing incentive programs.
+func (i *Indexer) snapshotIncentivePrograms(ctx context.Context, blk *tmtypes.Block) error {
+	programs, err := i.b.OngoingIncentivePrograms(ctx)
+	if err != nil {
+		return err
+	}
+	if len(programs) == 0 {
+		return nil
+	}
+
+	snapshots := make([]types.IncentiveProgramSnapshot, len(programs))
+	for idx, program := range programs {
+		snapshots[idx] = types.NewIncentiveProgramSnapshot(program, int(blk.Height), int(blk.Time.Unix()))
+	}
+	return i.db.StoreIncentiveProgramSnapshots(ctx, blk.ChainID, snapshots)
+}
+
+// snapshotMetokenIndexes stores the current balances and reserves of the meToken indexes.
+func (i *Indexer) snapshotMetokenIndexes(ctx context.Context, blk *tmtypes.Block) error {
+	balances, err := i.b.MetokenIndexBalances(ctx)
+	if err != nil {
+		return err
+	}
+	if len(balances) == 0 {
+		return nil
+	}
+
+	snapshots := make([]types.MetokenIndexSnapshot, len(balances))
+	for idx, balance := range balances {
+		snapshots[idx] = types.NewMetokenIndexSnapshot(balance, int(blk.Height), int(blk.Time.Unix()))
+	}
+	return i.db.StoreMetokenIndexSnapshots(ctx, blk.ChainID, snapshots)
+}
+
+// snapshotUIBCQuota stores the current uibc quota params and the outflows of the quota period.
+func (i *Indexer) snapshotUIBCQuota(ctx context.Context, blk *tmtypes.Block) error {
+	params, err := i.b.UIBCParams(ctx, blk.Height)
+	if err != nil {
+		return err
+	}
+	outflows, err := i.b.UIBCOutflows(ctx)
+	if err != nil {
+		return err
+	}
+	quotaExpires, err := i.b.UIBCQuotaExpires(ctx, blk.Height)
+	if err != nil {
+		return err
+	}
+
+	snapshot := types.NewUIBCQuotaSnapshot(params, outflows, quotaExpires, int(blk.Height), int(blk.Time.Unix()))
+	return i.db.StoreUIBCQuotaSnapshot(ctx, blk.ChainID, snapshot)
+}
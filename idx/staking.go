@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleStakingMsg stores the staking and distribution msgs with the rewards withdrawn in the tx events
+// and the completion time of the unbondings.
+func (i *Indexer) HandleStakingMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing staking msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		result, err := i.b.TxResult(ctx, tmTx)
+		if err != nil {
+			return err
+		}
+		events := result.Events
+
+		tx := types.IndexedTx{
+			TxHash:        hex.EncodeToString(tmTx.Hash()),
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *stakingtypes.MsgDelegate:
+			parsed := types.ParseTxDelegate(m, types.WithdrawnRewards(events, m.DelegatorAddress, m.ValidatorAddress).String())
+			tx.MsgDelegate = &parsed
+		case *stakingtypes.MsgUndelegate:
+			rewards := types.WithdrawnRewards(events, m.DelegatorAddress, m.ValidatorAddress).String()
+			completionTimeUnix := types.UnbondingCompletionTimeUnix(events, m.DelegatorAddress, m.ValidatorAddress, m.Amount.String())
+			parsed := types.ParseTxUndelegate(m, rewards, completionTimeUnix)
+			tx.MsgUndelegate = &parsed
+		case *stakingtypes.MsgBeginRedelegate:
+			rewards := types.WithdrawnRewards(events, m.DelegatorAddress, m.ValidatorSrcAddress, m.ValidatorDstAddress).String()
+			completionTimeUnix := types.RedelegationCompletionTimeUnix(events, m.ValidatorSrcAddress, m.ValidatorDstAddress, m.Amount.String())
+			parsed := types.ParseTxBeginRedelegate(m, rewards, completionTimeUnix)
+			tx.MsgBeginRedelegate = &parsed
+		case *stakingtypes.MsgCancelUnbondingDelegation:
+			parsed := types.ParseTxCancelUnbondingDelegation(m)
+			tx.MsgCancelUnbondingDelegation = &parsed
+		case *distrtypes.MsgWithdrawDelegatorReward:
+			parsed := types.ParseTxWithdrawDelegatorReward(m, types.WithdrawnRewards(events, m.DelegatorAddress, m.ValidatorAddress).String())
+			tx.MsgWithdrawDelegatorReward = &parsed
+		case *distrtypes.MsgWithdrawValidatorCommission:
+			parsed := types.ParseTxWithdrawValidatorCommission(m, types.WithdrawnCommission(events).String())
+			tx.MsgWithdrawValidatorCommission = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into staking msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
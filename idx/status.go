@@ -0,0 +1,48 @@
+package idx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// Status returns the indexing coverage of the chain stored in the database, with the lag behind the
+// chain tip and the lowest block height available on the node.
+func Status(ctx context.Context, b Blockchain, db database.Database, chainID string) (*types.ChainStatus, error) {
+	nodeChainID, height, err := b.ChainHeader()
+	if err != nil {
+		return nil, err
+	}
+	if nodeChainID != chainID {
+		return nil, fmt.Errorf("the node is of the chain %s, not %s", nodeChainID, chainID)
+	}
+
+	lowestHeight, err := LowestBlockHeightOnNode(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	info.MergeWithDefaults()
+
+	status := types.NewChainStatus(*info, int(height), lowestHeight)
+	return &status, nil
+}
+
+// LowestBlockHeightOnNode returns the lowest block height the node still has, nodes usually prune the
+// old blocks.
+func LowestBlockHeightOnNode(ctx context.Context, b Blockchain) (int, error) {
+	blk, minimumNodeBlkHeight, err := b.Block(ctx, 1)
+	if err != nil {
+		return 0, err
+	}
+	if blk != nil {
+		return int(blk.Height), nil
+	}
+	return minimumNodeBlkHeight, nil
+}
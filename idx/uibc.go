@@ -0,0 +1,39 @@
+package idx
+
+import (
+	"context"
+	"encoding/hex"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// HandleUIBCGovMsg stores the uibc msgs executed by governance.
+func (i *Indexer) HandleUIBCGovMsg(ctx context.Context, msgName string, blkHeight, blockTimeUnix int, tmTx tmtypes.Tx, exec *types.MsgExecution, msg proto.Message) error {
+	i.logger.Debug().Str("messageName", msgName).Msg("storing uibc msg")
+	return i.indexMsg(ctx, msgName, blkHeight, tmTx, func(info *types.ChainInfo) error {
+		tx := types.IndexedTx{
+			TxHash:        hex.EncodeToString(tmTx.Hash()),
+			ProtoMsgName:  msgName,
+			BlockHeight:   blkHeight,
+			BlockTimeUnix: blockTimeUnix,
+			Execution:     exec,
+		}
+
+		switch m := msg.(type) {
+		case *uibc.MsgGovUpdateQuota:
+			parsed := types.ParseTxGovUpdateQuota(m)
+			tx.MsgGovUpdateQuota = &parsed
+		case *uibc.MsgGovSetIBCStatus:
+			parsed := types.ParseTxGovSetIBCStatus(m)
+			tx.MsgGovSetIBCStatus = &parsed
+		default:
+			i.logger.Error().Str("messageName", msgName).Msg("not able to parse into uibc msg")
+			return nil
+		}
+
+		return i.db.StoreTx(ctx, *info, tx)
+	})
+}
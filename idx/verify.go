@@ -0,0 +1,121 @@
+package idx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog"
+	"github.com/umee-network/umeed-indexer/database"
+	"github.com/umee-network/umeed-indexer/database/memory"
+	"github.com/umee-network/umeed-indexer/graph/types"
+)
+
+// Verify fetches the blocks between the heights (inclusive) again, runs the handlers against an
+// in-memory database and returns the differences of the records stored in the database to them.
+// The records of msgs that were not indexed yet at their block height are not compared.
+func Verify(ctx context.Context, b Blockchain, db database.Database, logger zerolog.Logger, chainID string, fromHeight, toHeight, workers int) ([]*types.RecordDiff, error) {
+	nodeChainID, _, err := b.ChainHeader()
+	if err != nil {
+		return nil, err
+	}
+	if nodeChainID != chainID {
+		return nil, fmt.Errorf("the node is of the chain %s, not %s", nodeChainID, chainID)
+	}
+
+	dryDB := memory.New(logger)
+	// the dry run is not closed, closing the indexer would close the blockchain as well.
+	dry, err := NewIndexer(ctx, b, dryDB, logger, fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	if err := dry.Backfill(ctx, fromHeight, toHeight, workers, nil, func(BackfillProgress) {}); err != nil {
+		return nil, err
+	}
+
+	expected, err := dryDB.GetBlockRecords(ctx, chainID, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := db.GetBlockRecords(ctx, chainID, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := types.DiffBlockRecords(*expected, *stored)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := db.GetChainInfo(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	info.MergeWithDefaults()
+
+	indexedDiffs := make([]*types.RecordDiff, 0, len(diffs))
+	for _, diff := range diffs {
+		if diff.ProtoMsgName != "" && types.NeedsToIndexForMsg(diff.ProtoMsgName, info.CosmosMsgs, diff.BlockHeight) {
+			continue
+		}
+		indexedDiffs = append(indexedDiffs, diff)
+	}
+	return indexedDiffs, nil
+}
+
+// Repair reindexes the block heights of the differences by the msg that stores the records, returning
+// the differences that can not be repaired, as the ones of msgs that can not be reindexed.
+// It must not run while an indexer of the chain is running, as the Reindex.
+func Repair(ctx context.Context, b Blockchain, db database.Database, logger zerolog.Logger, chainID string, diffs []*types.RecordDiff, workers int) (unrepaired []*types.RecordDiff, err error) {
+	heightsByMsg := make(map[string][]int)
+	unrepaired = make([]*types.RecordDiff, 0)
+	for _, diff := range diffs {
+		if diff.ProtoMsgName == "" || types.ValidateReindexMsg(diff.ProtoMsgName) != nil {
+			unrepaired = append(unrepaired, diff)
+			continue
+		}
+		heightsByMsg[diff.ProtoMsgName] = append(heightsByMsg[diff.ProtoMsgName], diff.BlockHeight)
+	}
+	if len(heightsByMsg) == 0 {
+		return unrepaired, nil
+	}
+
+	msgNames := make([]string, 0, len(heightsByMsg))
+	for msgName := range heightsByMsg {
+		msgNames = append(msgNames, msgName)
+	}
+	sort.Strings(msgNames)
+
+	for _, msgName := range msgNames {
+		for _, interval := range heightIntervals(heightsByMsg[msgName]) {
+			if _, err := Reindex(ctx, db, chainID, msgName, interval.IdxFromBlockHeight, interval.IdxToBlockHeight); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the indexer loads the chain info only after all the block heights were unindexed.
+	i, err := NewIndexer(ctx, b, db, logger, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, msgName := range msgNames {
+		heights := heightsByMsg[msgName]
+		fromHeight, toHeight := heights[0], heights[0]
+		for _, height := range heights {
+			fromHeight, toHeight = min(fromHeight, height), max(toHeight, height)
+		}
+		if err := i.Backfill(ctx, fromHeight, toHeight, workers, []string{msgName}, func(BackfillProgress) {}); err != nil {
+			return nil, err
+		}
+	}
+	return unrepaired, nil
+}
+
+// heightIntervals returns the block heights joined into sorted intervals of consecutive heights.
+func heightIntervals(heights []int) []*types.BlockIndexedInterval {
+	intervals := make([]*types.BlockIndexedInterval, 0, len(heights))
+	for _, height := range heights {
+		intervals = append(intervals, &types.BlockIndexedInterval{IdxFromBlockHeight: height, IdxToBlockHeight: height})
+	}
+	return types.MergeBlockIndexedIntervals(intervals, nil)
+}
@@ -0,0 +1,106 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umee/v6/x/metoken"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+// Blockchain replays a recording without a node: the blocks, block results and tx results are served
+// by the archive and the chain state queries are answered with the recorded answers.
+type Blockchain struct {
+	*archive.Blockchain
+	queries *Queries
+}
+
+var _ idx.Blockchain = &Blockchain{}
+
+// NewBlockchain returns the blockchain replaying the recording in the blob store. SubscribeNewBlock
+// sends the recorded blocks from the height on.
+func NewBlockchain(store archive.BlobStore, fromHeight int64) (*Blockchain, error) {
+	queries, err := NewQueries(store)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := archive.NewBlockchain(archive.NewArchive(store), nil, fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &Blockchain{Blockchain: b, queries: queries}, nil
+}
+
+func (b *Blockchain) OracleParams(ctx context.Context, height int64) (oracletypes.Params, error) {
+	var params oracletypes.Params
+	err := b.queries.getProto(ctx, queryOracleParams, &params, height)
+	return params, err
+}
+
+func (b *Blockchain) OracleAggregateVotes(ctx context.Context, height int64) (voters []string, err error) {
+	err = b.queries.getJSON(ctx, queryOracleAggregateVotes, &voters, height)
+	return voters, err
+}
+
+func (b *Blockchain) BondedValidators(ctx context.Context, height int64) (valopers []string, err error) {
+	err = b.queries.getJSON(ctx, queryBondedValidators, &valopers, height)
+	return valopers, err
+}
+
+func (b *Blockchain) GovProposal(ctx context.Context, proposalID uint64, height int64) (*govv1.Proposal, error) {
+	var proposal govv1.Proposal
+	if err := b.queries.getProto(ctx, queryGovProposal, &proposal, proposalID, height); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (b *Blockchain) OngoingIncentivePrograms(ctx context.Context) (programs []incentive.IncentiveProgram, err error) {
+	var resp incentive.QueryOngoingIncentiveProgramsResponse
+	err = b.queries.getProto(ctx, queryIncentivePrograms, &resp)
+	return resp.Programs, err
+}
+
+func (b *Blockchain) MetokenIndexBalances(ctx context.Context) (balances []metoken.IndexBalances, err error) {
+	var resp metoken.QueryIndexBalancesResponse
+	err = b.queries.getProto(ctx, queryMetokenBalances, &resp)
+	return resp.IndexBalances, err
+}
+
+func (b *Blockchain) UIBCParams(ctx context.Context, height int64) (uibc.Params, error) {
+	var params uibc.Params
+	err := b.queries.getProto(ctx, queryUIBCParams, &params, height)
+	return params, err
+}
+
+func (b *Blockchain) UIBCOutflows(ctx context.Context) (outflows []uibc.DecCoinSymbol, err error) {
+	var resp uibc.QueryAllOutflowsResponse
+	err = b.queries.getProto(ctx, queryUIBCOutflows, &resp)
+	return resp.Outflows, err
+}
+
+func (b *Blockchain) UIBCQuotaExpires(ctx context.Context, height int64) (time.Time, error) {
+	var resp uibc.QueryQuotaExpiresResponse
+	err := b.queries.getProto(ctx, queryUIBCQuotaExpires, &resp, height)
+	return resp.EndTime, err
+}
+
+// DenomTrace answers the recorded traces, the native denoms that were not recorded are parsed locally.
+func (b *Blockchain) DenomTrace(ctx context.Context, denom string) (transfertypes.DenomTrace, error) {
+	var trace transfertypes.DenomTrace
+	err := b.queries.getProto(ctx, queryDenomTrace, &trace, denom)
+	if errors.Is(err, ErrNotRecorded) {
+		if trace, err := b.Blockchain.DenomTrace(ctx, denom); err == nil {
+			return trace, nil
+		}
+	}
+	return trace, err
+}
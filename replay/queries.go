@@ -0,0 +1,106 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/chain"
+)
+
+const (
+	prefixQueries = "queries/"
+
+	queryOracleParams         = "oracle_params"
+	queryOracleAggregateVotes = "oracle_aggregate_votes"
+	queryBondedValidators     = "bonded_validators"
+	queryGovProposal          = "gov_proposal"
+	queryIncentivePrograms    = "incentive_ongoing_programs"
+	queryMetokenBalances      = "metoken_index_balances"
+	queryUIBCParams           = "uibc_params"
+	queryUIBCOutflows         = "uibc_outflows"
+	queryUIBCQuotaExpires     = "uibc_quota_expires"
+	queryDenomTrace           = "denom_trace"
+)
+
+// ErrNotRecorded is returned by the replay when the answer of a chain state query was not recorded.
+var ErrNotRecorded = errors.New("query not recorded")
+
+// Queries keeps the answers of the chain state queries next to the archived blocks, as JSON by query
+// name and arguments. The proto answers are encoded with the umee codec, like the node gRPC gateway.
+type Queries struct {
+	store archive.BlobStore
+	cdc   codec.Codec
+}
+
+// NewQueries returns the query answers kept in the blob store.
+func NewQueries(store archive.BlobStore) (*Queries, error) {
+	decoders, err := chain.NewUmeeDecoderRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return &Queries{store: store, cdc: decoders.Latest().EncodingConfig.Codec}, nil
+}
+
+// putProto stores the proto answer of the query, overwriting the previous one with the same arguments.
+func (q *Queries) putProto(ctx context.Context, name string, msg proto.Message, args ...any) error {
+	bz, err := q.cdc.MarshalJSON(msg)
+	if err != nil {
+		return err
+	}
+	return q.store.Put(ctx, queryKey(name, args...), bz)
+}
+
+// getProto decodes the recorded answer of the query into msg, ErrNotRecorded if there is none.
+func (q *Queries) getProto(ctx context.Context, name string, msg proto.Message, args ...any) error {
+	bz, err := q.get(ctx, name, args...)
+	if err != nil {
+		return err
+	}
+	return q.cdc.UnmarshalJSON(bz, msg)
+}
+
+// putJSON stores the answers that are not proto messages.
+func (q *Queries) putJSON(ctx context.Context, name string, v any, args ...any) error {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return q.store.Put(ctx, queryKey(name, args...), bz)
+}
+
+func (q *Queries) getJSON(ctx context.Context, name string, v any, args ...any) error {
+	bz, err := q.get(ctx, name, args...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
+
+func (q *Queries) get(ctx context.Context, name string, args ...any) ([]byte, error) {
+	key := queryKey(name, args...)
+	bz, err := q.store.Get(ctx, key)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+	}
+	return bz, err
+}
+
+// queryKey returns the key of the query answer, the queries without arguments keep only the last
+// answer and the others one answer by arguments, ex.: queries/gov_proposal/12-7942001.json.
+func queryKey(name string, args ...any) string {
+	if len(args) == 0 {
+		return prefixQueries + name + ".json"
+	}
+
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = strings.ReplaceAll(fmt.Sprint(arg), "/", "_")
+	}
+	return prefixQueries + name + "/" + strings.Join(values, "-") + ".json"
+}
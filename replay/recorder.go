@@ -0,0 +1,131 @@
+package replay
+
+import (
+	"context"
+	"time"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/rs/zerolog"
+	"github.com/umee-network/umee/v6/x/incentive"
+	"github.com/umee-network/umee/v6/x/metoken"
+	oracletypes "github.com/umee-network/umee/v6/x/oracle/types"
+	"github.com/umee-network/umee/v6/x/uibc"
+	"github.com/umee-network/umeed-indexer/archive"
+	"github.com/umee-network/umeed-indexer/idx"
+)
+
+// Recorder is a blockchain that records what the indexer asks to the node: the blocks are archived
+// with their results and the answers of the chain state queries are kept, so the same indexing can
+// be replayed later. Recording failures are logged and the answers are still returned.
+type Recorder struct {
+	idx.Blockchain
+	queries *Queries
+	logger  zerolog.Logger
+}
+
+var _ idx.Blockchain = &Recorder{}
+
+// NewRecorder returns the blockchain b recording into the blob store.
+func NewRecorder(b idx.Blockchain, store archive.BlobStore, logger zerolog.Logger) (*Recorder, error) {
+	queries, err := NewQueries(store)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		Blockchain: archive.NewSink(b, archive.NewArchive(store), logger),
+		queries:    queries,
+		logger:     logger.With().Str("package", "replay").Logger(),
+	}, nil
+}
+
+func (r *Recorder) OracleParams(ctx context.Context, height int64) (oracletypes.Params, error) {
+	params, err := r.Blockchain.OracleParams(ctx, height)
+	if err == nil {
+		r.record(queryOracleParams, r.queries.putProto(ctx, queryOracleParams, &params, height))
+	}
+	return params, err
+}
+
+func (r *Recorder) OracleAggregateVotes(ctx context.Context, height int64) (voters []string, err error) {
+	voters, err = r.Blockchain.OracleAggregateVotes(ctx, height)
+	if err == nil {
+		r.record(queryOracleAggregateVotes, r.queries.putJSON(ctx, queryOracleAggregateVotes, voters, height))
+	}
+	return voters, err
+}
+
+func (r *Recorder) BondedValidators(ctx context.Context, height int64) (valopers []string, err error) {
+	valopers, err = r.Blockchain.BondedValidators(ctx, height)
+	if err == nil {
+		r.record(queryBondedValidators, r.queries.putJSON(ctx, queryBondedValidators, valopers, height))
+	}
+	return valopers, err
+}
+
+func (r *Recorder) GovProposal(ctx context.Context, proposalID uint64, height int64) (*govv1.Proposal, error) {
+	proposal, err := r.Blockchain.GovProposal(ctx, proposalID, height)
+	if err == nil && proposal != nil {
+		r.record(queryGovProposal, r.queries.putProto(ctx, queryGovProposal, proposal, proposalID, height))
+	}
+	return proposal, err
+}
+
+func (r *Recorder) OngoingIncentivePrograms(ctx context.Context) (programs []incentive.IncentiveProgram, err error) {
+	programs, err = r.Blockchain.OngoingIncentivePrograms(ctx)
+	if err == nil {
+		resp := &incentive.QueryOngoingIncentiveProgramsResponse{Programs: programs}
+		r.record(queryIncentivePrograms, r.queries.putProto(ctx, queryIncentivePrograms, resp))
+	}
+	return programs, err
+}
+
+func (r *Recorder) MetokenIndexBalances(ctx context.Context) (balances []metoken.IndexBalances, err error) {
+	balances, err = r.Blockchain.MetokenIndexBalances(ctx)
+	if err == nil {
+		resp := &metoken.QueryIndexBalancesResponse{IndexBalances: balances}
+		r.record(queryMetokenBalances, r.queries.putProto(ctx, queryMetokenBalances, resp))
+	}
+	return balances, err
+}
+
+func (r *Recorder) UIBCParams(ctx context.Context, height int64) (uibc.Params, error) {
+	params, err := r.Blockchain.UIBCParams(ctx, height)
+	if err == nil {
+		r.record(queryUIBCParams, r.queries.putProto(ctx, queryUIBCParams, &params, height))
+	}
+	return params, err
+}
+
+func (r *Recorder) UIBCOutflows(ctx context.Context) (outflows []uibc.DecCoinSymbol, err error) {
+	outflows, err = r.Blockchain.UIBCOutflows(ctx)
+	if err == nil {
+		resp := &uibc.QueryAllOutflowsResponse{Outflows: outflows}
+		r.record(queryUIBCOutflows, r.queries.putProto(ctx, queryUIBCOutflows, resp))
+	}
+	return outflows, err
+}
+
+func (r *Recorder) UIBCQuotaExpires(ctx context.Context, height int64) (time.Time, error) {
+	endTime, err := r.Blockchain.UIBCQuotaExpires(ctx, height)
+	if err == nil {
+		resp := &uibc.QueryQuotaExpiresResponse{EndTime: endTime}
+		r.record(queryUIBCQuotaExpires, r.queries.putProto(ctx, queryUIBCQuotaExpires, resp, height))
+	}
+	return endTime, err
+}
+
+func (r *Recorder) DenomTrace(ctx context.Context, denom string) (transfertypes.DenomTrace, error) {
+	trace, err := r.Blockchain.DenomTrace(ctx, denom)
+	if err == nil {
+		r.record(queryDenomTrace, r.queries.putProto(ctx, queryDenomTrace, &trace, denom))
+	}
+	return trace, err
+}
+
+func (r *Recorder) record(query string, err error) {
+	if err != nil {
+		r.logger.Err(err).Str("query", query).Msg("error recording query answer")
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	fieldMultiple = "multiple"
+	fieldOther    = "other"
+)
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "umeed_indexer",
+	Subsystem: "api",
+	Name:      "request_duration_seconds",
+	Help:      "Latency of the GraphQL responses by root field queried and if they have errors.",
+	Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+}, []string{"field", "errors"})
+
+// observeResponses records the latency of every GraphQL response by the root field queried.
+func observeResponses(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	start := time.Now()
+	resp := next(ctx)
+
+	hasErrors := "false"
+	if resp != nil && len(resp.Errors) > 0 {
+		hasErrors = "true"
+	}
+	requestDuration.WithLabelValues(rootField(ctx), hasErrors).Observe(time.Since(start).Seconds())
+	return resp
+}
+
+// rootField returns the root field queried by the operation of the context, the operation names are
+// set by the clients, but the fields validated come from the schema, so the label has a bounded set
+// of values. It is multiple if the operation queries many root fields and other if it was not validated.
+func rootField(ctx context.Context) string {
+	if !graphql.HasOperationContext(ctx) {
+		return fieldOther
+	}
+	op := graphql.GetOperationContext(ctx).Operation
+	if op == nil {
+		return fieldOther
+	}
+
+	name := ""
+	for _, selection := range op.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok || field.Definition == nil {
+			return fieldOther
+		}
+		if name != "" && name != field.Name {
+			return fieldMultiple
+		}
+		name = field.Name
+	}
+	if name == "" {
+		return fieldOther
+	}
+	return name
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/umee-network/umeed-indexer/database"
 	"github.com/umee-network/umeed-indexer/graph"
@@ -27,6 +28,7 @@ func NewRouter(
 	// Set up the GraphQL server
 	config := graph.Config{Resolvers: graph.NewResolver(db, logger)}
 	r.Handle("/graphql", newServer(config))
+	r.Handle("/metrics", promhttp.Handler())
 
 	return r, nil
 }
@@ -53,6 +55,8 @@ func newServer(config graph.Config) (srv *handler.Server) {
 
 	srv.SetQueryCache(lru.New(1000))
 
+	srv.AroundResponses(observeResponses)
+
 	srv.Use(extension.Introspection{})
 	srv.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New(100),